@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -10,6 +12,8 @@ import (
 	"github.com/vasujain275/expense-tracker-api/internal/config"
 	"github.com/vasujain275/expense-tracker-api/internal/database"
 	"github.com/vasujain275/expense-tracker-api/internal/handlers"
+	"github.com/vasujain275/expense-tracker-api/internal/jobs"
+	"github.com/vasujain275/expense-tracker-api/internal/middleware"
 	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 	"github.com/vasujain275/expense-tracker-api/internal/services"
 )
@@ -35,6 +39,11 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
 	cfg, err := config.Load()
 
 	if err != nil {
@@ -48,26 +57,69 @@ func main() {
 
 	db := database.Connect(cfg.PostgresConnectionDsn())
 
-	// Run migrations
-	if err := database.Migrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	// Refuse to start against an outdated schema
+	if err := database.CheckSchema(db); err != nil {
+		log.Fatalf("Schema check failed: %v", err)
+	}
+
+	schemaVersion, err := database.SchemaVersion()
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
 	}
 
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	accountRepo := repositories.NewAccountRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	statementRepo := repositories.NewCreditCardStatementRepository(db)
+	netWorthRepo := repositories.NewNetWorthRepository(db)
 	transactionRepo := repositories.NewTransactionRepository(db)
-
-	userService := services.NewUserService(userRepo)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	recurringRepo := repositories.NewRecurringRepository(db)
+	importProfileRepo := repositories.NewImportProfileRepository(db)
+	backupRepo := repositories.NewBackupRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
+
+	authService := services.NewAuthService(userRepo, cfg.JWTSecret, cfg.JWTExpiration, cfg.MaxLoginAttempts, cfg.LockoutDuration)
+	userService := services.NewUserService(unitOfWork, userRepo)
 	accountService := services.NewAccountService(accountRepo, userRepo)
-	categoryService := services.NewCategoryService(categoryRepo)
-	transactionService := services.NewTransactionService(transactionRepo, accountRepo, categoryRepo, userRepo)
-
+	creditCardService := services.NewCreditCardService(accountRepo, statementRepo)
+	netWorthService := services.NewNetWorthService(netWorthRepo, userRepo)
+	categoryService := services.NewCategoryService(unitOfWork, categoryRepo)
+	tagService := services.NewTagService(tagRepo, transactionRepo)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo, userRepo)
+	transactionService := services.NewTransactionService(unitOfWork, transactionRepo, accountRepo, categoryRepo, userRepo)
+	transferService := services.NewTransferService(unitOfWork, transactionRepo, accountRepo)
+	budgetService := services.NewBudgetService(budgetRepo, categoryRepo, transactionRepo, userRepo)
+	recurringService := services.NewRecurringService(recurringRepo, accountRepo, categoryRepo, transactionService)
+	importService := services.NewImportService(unitOfWork, importProfileRepo, transactionRepo, accountRepo, categoryRepo)
+	exportService := services.NewExportService(userRepo, accountRepo, categoryRepo, transactionRepo)
+	backupService := services.NewBackupService(unitOfWork, backupRepo, schemaVersion, cfg.BackupSecret)
+
+	// Start background jobs
+	go jobs.RunBalanceCheck(context.Background(), accountService, cfg.BalanceCheckInterval)
+	go jobs.RunRecurringTransactions(context.Background(), recurringService, cfg.RecurringInterval)
+	go jobs.RunStatementGeneration(context.Background(), creditCardService, cfg.StatementInterval)
+	go jobs.RunNetWorthSnapshots(context.Background(), netWorthService, cfg.NetWorthInterval)
+
+	authHandler := handlers.NewAuthHandler(authService, userService)
 	userHandler := handlers.NewUserHandler(userService)
+	netWorthHandler := handlers.NewNetWorthHandler(netWorthService)
 	accountHandler := handlers.NewAccountHandler(accountService)
+	creditCardHandler := handlers.NewCreditCardHandler(creditCardService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(exchangeRateService)
+	currencyHandler := handlers.NewCurrencyHandler()
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
+	transferHandler := handlers.NewTransferHandler(transferService)
+	budgetHandler := handlers.NewBudgetHandler(budgetService)
+	recurringHandler := handlers.NewRecurringHandler(recurringService)
+	importHandler := handlers.NewImportHandler(importService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	backupHandler := handlers.NewBackupHandler(backupService)
 
 	// Initialize router
 	router := gin.Default()
@@ -85,36 +137,114 @@ func main() {
 	// API v1 group
 	v1 := router.Group("/api/v1")
 	{
+		// Public auth routes
+		v1.POST("/auth/register", authHandler.Register)
+		v1.POST("/auth/login", authHandler.Login)
+
+		// Currency registry, needed before registering
+		v1.GET("/currencies", currencyHandler.GetCurrencies)
+	}
+
+	// Routes below require a valid bearer token
+	protected := v1.Group("", middleware.RequireAuth(authService))
+	{
+		// Auth routes
+		protected.POST("/auth/refresh", authHandler.RefreshToken)
+		protected.PUT("/auth/password", authHandler.ChangePassword)
+
 		// User routes
-		v1.POST("/users", userHandler.CreateUser)
-		v1.GET("/users/:id", userHandler.GetUser)
-		v1.PUT("/users/:id", userHandler.UpdateUser)
-		v1.DELETE("/users/:id", userHandler.DeleteUser)
+		protected.GET("/users/:id", userHandler.GetUser)
+		protected.PUT("/users/:id", userHandler.UpdateUser)
+		protected.DELETE("/users/:id", userHandler.DeleteUser)
+		protected.GET("/users/:id/backup", backupHandler.BackupUser)
+		protected.POST("/users/restore", backupHandler.RestoreUser)
+		protected.GET("/users/:id/net-worth", netWorthHandler.GetNetWorth)
+		protected.GET("/users/:id/net-worth/history", netWorthHandler.GetNetWorthHistory)
 
 		// Account routes
-		v1.POST("/accounts", accountHandler.CreateAccount)
-		v1.GET("/accounts", accountHandler.GetUserAccounts)
-		v1.GET("/accounts/:id", accountHandler.GetAccount)
-		v1.PUT("/accounts/:id", accountHandler.UpdateAccount)
-		v1.DELETE("/accounts/:id", accountHandler.DeleteAccount)
-		v1.GET("/accounts/:id/balance", accountHandler.GetAccountBalance)
+		protected.POST("/accounts", accountHandler.CreateAccount)
+		protected.GET("/accounts", accountHandler.GetUserAccounts)
+		protected.GET("/accounts/:id", accountHandler.GetAccount)
+		protected.PUT("/accounts/:id", accountHandler.UpdateAccount)
+		protected.DELETE("/accounts/:id", accountHandler.DeleteAccount)
+		protected.GET("/accounts/:id/balance", accountHandler.GetAccountBalance)
+		protected.GET("/accounts/:id/balance-history", accountHandler.GetBalanceHistory)
+		protected.GET("/accounts/:id/reconcile", accountHandler.ReconcileAccount)
+		protected.POST("/accounts/:id/recompute", accountHandler.RecomputeBalance)
+		protected.GET("/accounts/:id/statements", creditCardHandler.GetStatements)
 
 		// Category routes
-		v1.POST("/categories", categoryHandler.CreateCategory)
-		v1.GET("/categories", categoryHandler.GetAllCategories)
-		v1.GET("/categories/:id", categoryHandler.GetCategory)
-		v1.PUT("/categories/:id", categoryHandler.UpdateCategory)
-		v1.DELETE("/categories/:id", categoryHandler.DeleteCategory)
-		v1.GET("/categories/type/:type", categoryHandler.GetCategoriesByType)
+		protected.POST("/categories", categoryHandler.CreateCategory)
+		protected.GET("/categories", categoryHandler.GetAllCategories)
+		protected.GET("/categories/:id", categoryHandler.GetCategory)
+		protected.PUT("/categories/:id", categoryHandler.UpdateCategory)
+		protected.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+		protected.POST("/categories/:id/merge", categoryHandler.MergeCategory)
+		protected.GET("/categories/type/:type", categoryHandler.GetCategoriesByType)
+
+		// Tag routes
+		protected.POST("/tags", tagHandler.CreateTag)
+		protected.GET("/tags", tagHandler.GetTags)
+		protected.GET("/tags/:id", tagHandler.GetTag)
+		protected.PUT("/tags/:id", tagHandler.UpdateTag)
+		protected.DELETE("/tags/:id", tagHandler.DeleteTag)
+		protected.POST("/tags/:id/transactions", tagHandler.TagTransactions)
+		protected.DELETE("/tags/:id/transactions", tagHandler.UntagTransactions)
+
+		// Exchange rate routes
+		protected.POST("/exchange-rates", exchangeRateHandler.LoadExchangeRates)
+		protected.POST("/exchange-rates/import", exchangeRateHandler.ImportExchangeRates)
+		protected.GET("/exchange-rates", exchangeRateHandler.GetExchangeRates)
+		protected.DELETE("/exchange-rates/:id", exchangeRateHandler.DeleteExchangeRate)
 
 		// Transaction routes
-		v1.POST("/transactions", transactionHandler.CreateTransaction)
-		v1.GET("/transactions", transactionHandler.GetTransactions)
-		v1.GET("/transactions/:id", transactionHandler.GetTransaction)
-		v1.PUT("/transactions/:id", transactionHandler.UpdateTransaction)
-		v1.DELETE("/transactions/:id", transactionHandler.DeleteTransaction)
-		v1.GET("/transactions/summary", transactionHandler.GetTransactionSummary)
-		v1.GET("/transactions/monthly-total", transactionHandler.GetMonthlyTotal)
+		protected.POST("/transactions", transactionHandler.CreateTransaction)
+		protected.GET("/transactions", transactionHandler.GetTransactions)
+		protected.GET("/transactions/:id", transactionHandler.GetTransaction)
+		protected.PUT("/transactions/:id", transactionHandler.UpdateTransaction)
+		protected.DELETE("/transactions/:id", transactionHandler.DeleteTransaction)
+		protected.GET("/transactions/summary", transactionHandler.GetTransactionSummary)
+		protected.GET("/transactions/summary/tags", transactionHandler.GetTagSummary)
+		protected.GET("/transactions/monthly-total", transactionHandler.GetMonthlyTotal)
+		protected.GET("/transactions/export", transactionHandler.ExportTransactions)
+
+		// Transfer routes
+		protected.POST("/transfers", transferHandler.CreateTransfer)
+		protected.GET("/transfers/:id", transferHandler.GetTransfer)
+		protected.DELETE("/transfers/:id", transferHandler.DeleteTransfer)
+
+		// Budget routes
+		protected.POST("/budgets", budgetHandler.CreateBudget)
+		protected.GET("/budgets", budgetHandler.GetBudgets)
+		protected.GET("/budgets/status", budgetHandler.GetBudgetStatus)
+		protected.GET("/budgets/:id", budgetHandler.GetBudget)
+		protected.PUT("/budgets/:id", budgetHandler.UpdateBudget)
+		protected.DELETE("/budgets/:id", budgetHandler.DeleteBudget)
+
+		// Recurring transaction routes
+		protected.POST("/recurring-transactions", recurringHandler.CreateRecurring)
+		protected.GET("/recurring-transactions", recurringHandler.GetRecurringTransactions)
+		protected.GET("/recurring-transactions/:id", recurringHandler.GetRecurring)
+		protected.PUT("/recurring-transactions/:id", recurringHandler.UpdateRecurring)
+		protected.DELETE("/recurring-transactions/:id", recurringHandler.DeleteRecurring)
+		protected.GET("/recurring-transactions/:id/occurrences", recurringHandler.PreviewOccurrences)
+		protected.POST("/recurring-transactions/:id/occurrences/:date/skip", recurringHandler.SkipOccurrence)
+		protected.PUT("/recurring-transactions/:id/occurrences/:date", recurringHandler.ModifyOccurrence)
+		protected.DELETE("/recurring-transactions/:id/occurrences/:date", recurringHandler.RestoreOccurrence)
+
+		// Statement import routes
+		protected.POST("/import-profiles", importHandler.CreateImportProfile)
+		protected.GET("/import-profiles", importHandler.GetImportProfiles)
+		protected.GET("/import-profiles/:id", importHandler.GetImportProfile)
+		protected.PUT("/import-profiles/:id", importHandler.UpdateImportProfile)
+		protected.DELETE("/import-profiles/:id", importHandler.DeleteImportProfile)
+		protected.POST("/accounts/:id/import/csv", importHandler.ImportCSV)
+		protected.POST("/accounts/:id/import/ofx", importHandler.ImportOFX)
+		protected.POST("/accounts/:id/import/camt053", importHandler.ImportCAMT053)
+		protected.POST("/accounts/:id/import/mt940", importHandler.ImportMT940)
+
+		// Plain-text accounting export routes
+		protected.GET("/export/journal", exportHandler.ExportJournal)
 	}
 
 	// Start server
@@ -0,0 +1,272 @@
+// Package backup reads and writes portable archives of a user's data.
+//
+// An archive is a JSON document holding the data set and the schema version
+// it was taken at, signed with an HMAC-SHA256 of the data under a server-side
+// key. Only archives written with the same key can be read back. Archives from
+// older schema versions are brought up to date when read, so backups remain
+// restorable after the schema changes.
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Kind identifies a document as a backup archive
+	Kind = "expense-tracker-backup"
+
+	// FormatVersion is the version of the archive envelope
+	FormatVersion = 1
+
+	// MinSchemaVersion is the oldest schema version archives can be restored from
+	MinSchemaVersion int64 = 5
+
+	signaturePrefix = "hmac-sha256:"
+)
+
+// Archive is the envelope of a backup. Signature covers the compacted JSON of Data.
+type Archive struct {
+	Kind          string          `json:"kind"`
+	FormatVersion int             `json:"format_version"`
+	SchemaVersion int64           `json:"schema_version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Signature     string          `json:"signature"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// upgrades convert archive data taken at the previous schema version to the
+// keyed version. Every migration that changes the shape of backed up data
+// adds an entry here, together with the matching change to Data.
+var upgrades = map[int64]func(data map[string]interface{}) error{
+	// Categories became private to each user. The migration gave every user
+	// copies of the global categories, so the categories in the backup get new
+	// IDs as well and every reference to them follows.
+	6: func(data map[string]interface{}) error {
+		ids := make(map[string]string)
+		categories, err := records(data, "categories")
+		if err != nil {
+			return err
+		}
+		for _, category := range categories {
+			id, ok := category["id"].(string)
+			if !ok {
+				return errors.New("category ID is missing")
+			}
+			ids[id] = uuid.NewString()
+			category["id"] = ids[id]
+		}
+
+		remap := func(record map[string]interface{}, key string) {
+			if id, ok := record[key].(string); ok && ids[id] != "" {
+				record[key] = ids[id]
+			}
+		}
+
+		transactions, err := records(data, "transactions")
+		if err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			remap(transaction, "category_id")
+			splits, err := records(transaction, "splits")
+			if err != nil {
+				return err
+			}
+			for _, split := range splits {
+				remap(split, "category_id")
+			}
+		}
+
+		recurring, err := records(data, "recurring_transactions")
+		if err != nil {
+			return err
+		}
+		for _, r := range recurring {
+			remap(r, "category_id")
+			exceptions, err := records(r, "exceptions")
+			if err != nil {
+				return err
+			}
+			for _, exception := range exceptions {
+				remap(exception, "category_id")
+			}
+		}
+
+		budgets, err := records(data, "budgets")
+		if err != nil {
+			return err
+		}
+		for _, budget := range budgets {
+			categoryIDs, _ := budget["category_ids"].([]interface{})
+			for i, item := range categoryIDs {
+				if id, ok := item.(string); ok && ids[id] != "" {
+					categoryIDs[i] = ids[id]
+				}
+			}
+		}
+
+		profiles, err := records(data, "import_profiles")
+		if err != nil {
+			return err
+		}
+		for _, profile := range profiles {
+			remap(profile, "default_category_id")
+		}
+		return nil
+	},
+
+	// Accounts gained a currency, which used to be the user's
+	8: func(data map[string]interface{}) error {
+		user, ok := data["user"].(map[string]interface{})
+		if !ok {
+			return errors.New("user is missing")
+		}
+		currency, ok := user["currency"].(string)
+		if !ok {
+			return errors.New("user currency is missing")
+		}
+		accounts, _ := data["accounts"].([]interface{})
+		for _, item := range accounts {
+			account, ok := item.(map[string]interface{})
+			if !ok {
+				return errors.New("invalid account")
+			}
+			account["currency"] = currency
+		}
+		return nil
+	},
+}
+
+// Write encodes data as an archive taken at the given schema version and
+// signs it with key
+func Write(w io.Writer, data *Data, schemaVersion int64, key []byte) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	archive := Archive{
+		Kind:          Kind,
+		FormatVersion: FormatVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Signature:     sign(raw, key),
+		Data:          raw,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// Read decodes an archive, verifies its signature with key and upgrades its
+// data to the current schema version. Archives from a newer schema than
+// current are rejected.
+func Read(r io.Reader, currentSchemaVersion int64, key []byte) (*Data, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+
+	if archive.Kind != Kind {
+		return nil, errors.New("invalid backup archive: not an expense tracker backup")
+	}
+	if archive.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", archive.FormatVersion)
+	}
+	if archive.SchemaVersion > currentSchemaVersion {
+		return nil, fmt.Errorf("backup was taken at schema version %d, which is newer than this server's %d",
+			archive.SchemaVersion, currentSchemaVersion)
+	}
+	if archive.SchemaVersion < MinSchemaVersion {
+		return nil, fmt.Errorf("backup schema version %d is older than the oldest supported version %d",
+			archive.SchemaVersion, MinSchemaVersion)
+	}
+	if len(archive.Data) == 0 {
+		return nil, errors.New("invalid backup archive: data is missing")
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, archive.Data); err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if !hmac.Equal([]byte(strings.ToLower(archive.Signature)), []byte(sign(compacted.Bytes(), key))) {
+		return nil, errors.New("backup signature mismatch: the archive was not written by this server or has been modified")
+	}
+
+	raw, err := upgrade(compacted.Bytes(), archive.SchemaVersion, currentSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var data Data
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid backup data: %w", err)
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// upgrade applies the upgrades between the archive's schema version and the
+// current one in order
+func upgrade(raw []byte, from, to int64) ([]byte, error) {
+	var steps []int64
+	for version := from + 1; version <= to; version++ {
+		if _, ok := upgrades[version]; ok {
+			steps = append(steps, version)
+		}
+	}
+	if len(steps) == 0 {
+		return raw, nil
+	}
+
+	// Numbers are kept as written so amounts do not lose precision
+	var data map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid backup data: %w", err)
+	}
+	for _, version := range steps {
+		if err := upgrades[version](data); err != nil {
+			return nil, fmt.Errorf("failed to upgrade backup to schema version %d: %w", version, err)
+		}
+	}
+	return json.Marshal(data)
+}
+
+// records returns the objects in the array under key, which may be absent
+func records(parent map[string]interface{}, key string) ([]map[string]interface{}, error) {
+	items, _ := parent[key].([]interface{})
+	result := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		record, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid %s entry", key)
+		}
+		result[i] = record
+	}
+	return result, nil
+}
+
+// sign returns the HMAC-SHA256 signature of data under key
+func sign(data, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testKey = []byte("test-backup-key")
+
+func testData() *Data {
+	return &Data{
+		User: User{
+			ID: uuid.New(), Email: "ada@example.com", Name: "Ada", Currency: "EUR",
+			CreatedAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	data := testData()
+	var buf bytes.Buffer
+	if err := Write(&buf, data, 12, testKey); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if strings.Contains(buf.String(), "password") {
+		t.Errorf("archive contains a password field:\n%s", buf.String())
+	}
+
+	read, err := Read(&buf, 12, testKey)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if read.User.ID != data.User.ID || read.User.Email != data.User.Email {
+		t.Errorf("user = %+v, want %+v", read.User, data.User)
+	}
+}
+
+func TestArchiveSignature(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testData(), 12, testKey); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	signed := buf.String()
+
+	var archive Archive
+	if err := json.Unmarshal(buf.Bytes(), &archive); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	// Changing the data invalidates the signature
+	tampered := archive
+	tampered.Data = json.RawMessage(strings.Replace(string(archive.Data), "Ada", "Eve", 1))
+	tamperedJSON, _ := json.Marshal(tampered)
+
+	tests := []struct {
+		name    string
+		archive string
+		key     []byte
+	}{
+		{"other key", signed, []byte("another-server")},
+		{"modified data", string(tamperedJSON), testKey},
+		{"missing signature", strings.Replace(signed, archive.Signature, "", 1), testKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.archive), 12, tt.key)
+			if err == nil || !strings.Contains(err.Error(), "signature mismatch") {
+				t.Fatalf("got error %v, want a signature mismatch", err)
+			}
+		})
+	}
+}
+
+// readFixture reads an archive from testdata at schema version 12
+func readFixture(t *testing.T, name string) *Data {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	data, err := Read(f, 12, testKey)
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return data
+}
+
+// TestUpgradeSchema5 reads an archive taken while categories were global.
+// Validate would reject the upgraded data if any reference were left
+// pointing at a global category ID.
+func TestUpgradeSchema5(t *testing.T) {
+	globalIDs := map[uuid.UUID]bool{
+		uuid.MustParse("00000000-0000-4000-8000-000000000001"): true,
+		uuid.MustParse("00000000-0000-4000-8000-000000000002"): true,
+		uuid.MustParse("00000000-0000-4000-8000-000000000003"): true,
+	}
+
+	data := readFixture(t, "schema5.json")
+	if len(data.Categories) != 3 {
+		t.Fatalf("got %d categories, want 3", len(data.Categories))
+	}
+	byName := make(map[string]uuid.UUID)
+	for _, category := range data.Categories {
+		if globalIDs[category.ID] {
+			t.Errorf("category %q kept its global ID %s", category.Name, category.ID)
+		}
+		byName[category.Name] = category.ID
+	}
+
+	if currency := data.Accounts[0].Currency; currency != "EUR" {
+		t.Errorf("account currency = %q, want the user's EUR", currency)
+	}
+	if got := data.Transactions[1].Splits[0].CategoryID; got != byName["Groceries"] {
+		t.Errorf("split category = %s, want Groceries %s", got, byName["Groceries"])
+	}
+	if got := data.Recurring[0].Exceptions[0].CategoryID; got == nil || *got != byName["Food"] {
+		t.Errorf("exception category = %v, want Food %s", got, byName["Food"])
+	}
+	if got := data.ImportProfiles[0].DefaultCategoryID; got != byName["Groceries"] {
+		t.Errorf("import profile category = %s, want Groceries %s", got, byName["Groceries"])
+	}
+
+	// Every read gives the categories new IDs, so the same archive can be
+	// restored for two users with preserved IDs
+	again := readFixture(t, "schema5.json")
+	for _, category := range again.Categories {
+		if category.ID == byName[category.Name] {
+			t.Errorf("category %q got the same ID %s on both reads", category.Name, category.ID)
+		}
+	}
+
+	// The upgraded data survives a round trip at the current schema version
+	var buf bytes.Buffer
+	if err := Write(&buf, data, 12, testKey); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	restored, err := Read(&buf, 12, testKey)
+	if err != nil {
+		t.Fatalf("read written archive: %v", err)
+	}
+	if !reflect.DeepEqual(restored, data) {
+		t.Errorf("round trip changed the data:\n got %+v\nwant %+v", restored, data)
+	}
+}
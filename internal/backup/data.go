@@ -0,0 +1,907 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// Data is the content of an archive. Records belong to User, so they do not
+// repeat the user ID. New kinds of user data are added here as new fields.
+type Data struct {
+	User           User            `json:"user"`
+	Accounts       []Account       `json:"accounts"`
+	Categories     []Category      `json:"categories"`
+	Tags           []Tag           `json:"tags"`
+	ExchangeRates  []ExchangeRate  `json:"exchange_rates"`
+	Statements     []Statement     `json:"credit_card_statements"`
+	NetWorth       []Snapshot      `json:"net_worth_snapshots"`
+	Recurring      []Recurring     `json:"recurring_transactions"`
+	Transactions   []Transaction   `json:"transactions"`
+	Budgets        []Budget        `json:"budgets"`
+	ImportProfiles []ImportProfile `json:"import_profiles"`
+}
+
+// User is the backed up user. The password hash is left out, so a restore
+// sets a new password.
+type User struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Account struct {
+	ID             uuid.UUID          `json:"id"`
+	Name           string             `json:"name"`
+	Type           models.AccountType `json:"type"`
+	Currency       string             `json:"currency"`
+	OpeningBalance decimal.Decimal    `json:"opening_balance"`
+	Balance        decimal.Decimal    `json:"balance"`
+	IsActive       bool               `json:"is_active"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+
+	CreditLimit         *decimal.Decimal `json:"credit_limit,omitempty"`
+	StatementClosingDay *int             `json:"statement_closing_day,omitempty"`
+	PaymentDueDay       *int             `json:"payment_due_day,omitempty"`
+	APR                 *decimal.Decimal `json:"apr,omitempty"`
+}
+
+type Category struct {
+	ID        uuid.UUID           `json:"id"`
+	ParentID  *uuid.UUID          `json:"parent_id,omitempty"`
+	Name      string              `json:"name"`
+	Type      models.CategoryType `json:"type"`
+	Color     string              `json:"color"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ExchangeRate struct {
+	ID            uuid.UUID       `json:"id"`
+	BaseCurrency  string          `json:"base_currency"`
+	QuoteCurrency string          `json:"quote_currency"`
+	Rate          decimal.Decimal `json:"rate"`
+	Date          time.Time       `json:"date"`
+	Source        string          `json:"source"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type Statement struct {
+	ID               uuid.UUID        `json:"id"`
+	AccountID        uuid.UUID        `json:"account_id"`
+	PeriodStart      time.Time        `json:"period_start"`
+	PeriodEnd        time.Time        `json:"period_end"`
+	DueDate          time.Time        `json:"due_date"`
+	PreviousBalance  decimal.Decimal  `json:"previous_balance"`
+	Charges          decimal.Decimal  `json:"charges"`
+	Credits          decimal.Decimal  `json:"credits"`
+	StatementBalance decimal.Decimal  `json:"statement_balance"`
+	MinimumPayment   decimal.Decimal  `json:"minimum_payment"`
+	CreditLimit      *decimal.Decimal `json:"credit_limit,omitempty"`
+	APR              *decimal.Decimal `json:"apr,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+type Snapshot struct {
+	ID          uuid.UUID       `json:"id"`
+	Date        time.Time       `json:"date"`
+	Currency    string          `json:"currency"`
+	Assets      decimal.Decimal `json:"assets"`
+	Liabilities decimal.Decimal `json:"liabilities"`
+	NetWorth    decimal.Decimal `json:"net_worth"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type Recurring struct {
+	ID             uuid.UUID            `json:"id"`
+	AccountID      uuid.UUID            `json:"account_id"`
+	CategoryID     uuid.UUID            `json:"category_id"`
+	Amount         decimal.Decimal      `json:"amount"`
+	Description    string               `json:"description"`
+	RRule          string               `json:"rrule"`
+	StartDate      time.Time            `json:"start_date"`
+	NextOccurrence *time.Time           `json:"next_occurrence"`
+	IsActive       bool                 `json:"is_active"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	Exceptions     []RecurringException `json:"exceptions"`
+}
+
+type RecurringException struct {
+	ID             uuid.UUID                       `json:"id"`
+	OccurrenceDate time.Time                       `json:"occurrence_date"`
+	Action         models.RecurringExceptionAction `json:"action"`
+	Amount         *decimal.Decimal                `json:"amount,omitempty"`
+	Description    *string                         `json:"description,omitempty"`
+	CategoryID     *uuid.UUID                      `json:"category_id,omitempty"`
+	Date           *time.Time                      `json:"date,omitempty"`
+	CreatedAt      time.Time                       `json:"created_at"`
+	UpdatedAt      time.Time                       `json:"updated_at"`
+}
+
+type Transaction struct {
+	ID               uuid.UUID        `json:"id"`
+	AccountID        uuid.UUID        `json:"account_id"`
+	CategoryID       *uuid.UUID       `json:"category_id,omitempty"`
+	TransferID       *uuid.UUID       `json:"transfer_id,omitempty"`
+	Amount           decimal.Decimal  `json:"amount"`
+	OriginalAmount   *decimal.Decimal `json:"original_amount,omitempty"`
+	OriginalCurrency *string          `json:"original_currency,omitempty"`
+	Description      string           `json:"description"`
+	Date             time.Time        `json:"date"`
+	RecurringID      *uuid.UUID       `json:"recurring_id,omitempty"`
+	OccurrenceDate   *time.Time       `json:"occurrence_date,omitempty"`
+	ExternalID       *string          `json:"external_id,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	Splits           []Split          `json:"splits,omitempty"`
+	TagIDs           []uuid.UUID      `json:"tag_ids,omitempty"`
+}
+
+type Split struct {
+	ID         uuid.UUID       `json:"id"`
+	CategoryID uuid.UUID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type Budget struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Amount      decimal.Decimal     `json:"amount"`
+	Period      models.BudgetPeriod `json:"period"`
+	Rollover    bool                `json:"rollover"`
+	StartDate   time.Time           `json:"start_date"`
+	CategoryIDs []uuid.UUID         `json:"category_ids"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+type ImportProfile struct {
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"has_header"`
+	SkipRows          int       `json:"skip_rows"`
+	DateColumn        string    `json:"date_column"`
+	AmountColumn      string    `json:"amount_column"`
+	DebitColumn       string    `json:"debit_column"`
+	CreditColumn      string    `json:"credit_column"`
+	DescriptionColumn string    `json:"description_column"`
+	ExternalIDColumn  string    `json:"external_id_column"`
+	DateFormat        string    `json:"date_format"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	SignConvention    string    `json:"sign_convention"`
+	DefaultCategoryID uuid.UUID `json:"default_category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// FromUserData converts a loaded data set to archive data
+func FromUserData(source *repositories.UserData) *Data {
+	u := source.User
+	data := &Data{
+		User: User{
+			ID:        u.ID,
+			Email:     u.Email,
+			Name:      u.Name,
+			Currency:  u.Currency,
+			CreatedAt: u.CreatedAt,
+			UpdatedAt: u.UpdatedAt,
+		},
+		Accounts:       make([]Account, 0, len(source.Accounts)),
+		Categories:     make([]Category, 0, len(source.Categories)),
+		Tags:           make([]Tag, 0, len(source.Tags)),
+		ExchangeRates:  make([]ExchangeRate, 0, len(source.ExchangeRates)),
+		Statements:     make([]Statement, 0, len(source.Statements)),
+		NetWorth:       make([]Snapshot, 0, len(source.NetWorthSnapshots)),
+		Recurring:      make([]Recurring, 0, len(source.Recurring)),
+		Transactions:   make([]Transaction, 0, len(source.Transactions)),
+		Budgets:        make([]Budget, 0, len(source.Budgets)),
+		ImportProfiles: make([]ImportProfile, 0, len(source.ImportProfiles)),
+	}
+
+	for _, a := range source.Accounts {
+		data.Accounts = append(data.Accounts, Account{
+			ID:             a.ID,
+			Name:           a.Name,
+			Type:           a.Type,
+			Currency:       a.Currency,
+			OpeningBalance: a.OpeningBalance,
+			Balance:        a.Balance,
+			IsActive:       a.IsActive,
+			CreatedAt:      a.CreatedAt,
+			UpdatedAt:      a.UpdatedAt,
+
+			CreditLimit:         a.CreditLimit,
+			StatementClosingDay: a.StatementClosingDay,
+			PaymentDueDay:       a.PaymentDueDay,
+			APR:                 a.APR,
+		})
+	}
+
+	for _, c := range source.Categories {
+		data.Categories = append(data.Categories, Category{
+			ID:        c.ID,
+			ParentID:  c.ParentID,
+			Name:      c.Name,
+			Type:      c.Type,
+			Color:     c.Color,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	for _, t := range source.Tags {
+		data.Tags = append(data.Tags, Tag{
+			ID:        t.ID,
+			Name:      t.Name,
+			Color:     t.Color,
+			CreatedAt: t.CreatedAt,
+			UpdatedAt: t.UpdatedAt,
+		})
+	}
+
+	for _, e := range source.ExchangeRates {
+		data.ExchangeRates = append(data.ExchangeRates, ExchangeRate{
+			ID:            e.ID,
+			BaseCurrency:  e.BaseCurrency,
+			QuoteCurrency: e.QuoteCurrency,
+			Rate:          e.Rate,
+			Date:          e.Date,
+			Source:        e.Source,
+			CreatedAt:     e.CreatedAt,
+			UpdatedAt:     e.UpdatedAt,
+		})
+	}
+
+	for _, st := range source.Statements {
+		data.Statements = append(data.Statements, Statement{
+			ID:               st.ID,
+			AccountID:        st.AccountID,
+			PeriodStart:      st.PeriodStart,
+			PeriodEnd:        st.PeriodEnd,
+			DueDate:          st.DueDate,
+			PreviousBalance:  st.PreviousBalance,
+			Charges:          st.Charges,
+			Credits:          st.Credits,
+			StatementBalance: st.StatementBalance,
+			MinimumPayment:   st.MinimumPayment,
+			CreditLimit:      st.CreditLimit,
+			APR:              st.APR,
+			CreatedAt:        st.CreatedAt,
+			UpdatedAt:        st.UpdatedAt,
+		})
+	}
+
+	for _, n := range source.NetWorthSnapshots {
+		data.NetWorth = append(data.NetWorth, Snapshot{
+			ID:          n.ID,
+			Date:        n.Date,
+			Currency:    n.Currency,
+			Assets:      n.Assets,
+			Liabilities: n.Liabilities,
+			NetWorth:    n.NetWorth,
+			CreatedAt:   n.CreatedAt,
+			UpdatedAt:   n.UpdatedAt,
+		})
+	}
+
+	for _, r := range source.Recurring {
+		recurring := Recurring{
+			ID:             r.ID,
+			AccountID:      r.AccountID,
+			CategoryID:     r.CategoryID,
+			Amount:         r.Amount,
+			Description:    r.Description,
+			RRule:          r.RRule,
+			StartDate:      r.StartDate,
+			NextOccurrence: r.NextOccurrence,
+			IsActive:       r.IsActive,
+			CreatedAt:      r.CreatedAt,
+			UpdatedAt:      r.UpdatedAt,
+			Exceptions:     make([]RecurringException, 0, len(r.Exceptions)),
+		}
+		for _, e := range r.Exceptions {
+			recurring.Exceptions = append(recurring.Exceptions, RecurringException{
+				ID:             e.ID,
+				OccurrenceDate: e.OccurrenceDate,
+				Action:         e.Action,
+				Amount:         e.Amount,
+				Description:    e.Description,
+				CategoryID:     e.CategoryID,
+				Date:           e.Date,
+				CreatedAt:      e.CreatedAt,
+				UpdatedAt:      e.UpdatedAt,
+			})
+		}
+		data.Recurring = append(data.Recurring, recurring)
+	}
+
+	for _, t := range source.Transactions {
+		transaction := Transaction{
+			ID:               t.ID,
+			AccountID:        t.AccountID,
+			CategoryID:       t.CategoryID,
+			TransferID:       t.TransferID,
+			Amount:           t.Amount,
+			OriginalAmount:   t.OriginalAmount,
+			OriginalCurrency: t.OriginalCurrency,
+			Description:      t.Description,
+			Date:             t.Date,
+			RecurringID:      t.RecurringID,
+			OccurrenceDate:   t.OccurrenceDate,
+			ExternalID:       t.ExternalID,
+			CreatedAt:        t.CreatedAt,
+			UpdatedAt:        t.UpdatedAt,
+		}
+		for _, s := range t.Splits {
+			transaction.Splits = append(transaction.Splits, Split{
+				ID:         s.ID,
+				CategoryID: s.CategoryID,
+				Amount:     s.Amount,
+				Memo:       s.Memo,
+				CreatedAt:  s.CreatedAt,
+				UpdatedAt:  s.UpdatedAt,
+			})
+		}
+		for _, tag := range t.Tags {
+			transaction.TagIDs = append(transaction.TagIDs, tag.ID)
+		}
+		data.Transactions = append(data.Transactions, transaction)
+	}
+
+	for _, b := range source.Budgets {
+		budget := Budget{
+			ID:          b.ID,
+			Name:        b.Name,
+			Amount:      b.Amount,
+			Period:      b.Period,
+			Rollover:    b.Rollover,
+			StartDate:   b.StartDate,
+			CategoryIDs: make([]uuid.UUID, 0, len(b.Categories)),
+			CreatedAt:   b.CreatedAt,
+			UpdatedAt:   b.UpdatedAt,
+		}
+		for _, c := range b.Categories {
+			budget.CategoryIDs = append(budget.CategoryIDs, c.ID)
+		}
+		data.Budgets = append(data.Budgets, budget)
+	}
+
+	for _, p := range source.ImportProfiles {
+		data.ImportProfiles = append(data.ImportProfiles, ImportProfile{
+			ID:                p.ID,
+			Name:              p.Name,
+			Delimiter:         p.Delimiter,
+			HasHeader:         p.HasHeader,
+			SkipRows:          p.SkipRows,
+			DateColumn:        p.DateColumn,
+			AmountColumn:      p.AmountColumn,
+			DebitColumn:       p.DebitColumn,
+			CreditColumn:      p.CreditColumn,
+			DescriptionColumn: p.DescriptionColumn,
+			ExternalIDColumn:  p.ExternalIDColumn,
+			DateFormat:        p.DateFormat,
+			DecimalSeparator:  p.DecimalSeparator,
+			SignConvention:    p.SignConvention,
+			DefaultCategoryID: p.DefaultCategoryID,
+			CreatedAt:         p.CreatedAt,
+			UpdatedAt:         p.UpdatedAt,
+		})
+	}
+
+	return data
+}
+
+// UserData converts archive data to models ready to be inserted
+func (d *Data) UserData() *repositories.UserData {
+	userID := d.User.ID
+	target := &repositories.UserData{
+		User: models.User{
+			ID:        userID,
+			Email:     d.User.Email,
+			Name:      d.User.Name,
+			Currency:  d.User.Currency,
+			CreatedAt: d.User.CreatedAt,
+			UpdatedAt: d.User.UpdatedAt,
+		},
+	}
+
+	for _, a := range d.Accounts {
+		target.Accounts = append(target.Accounts, models.Account{
+			ID:             a.ID,
+			UserID:         userID,
+			Name:           a.Name,
+			Type:           a.Type,
+			Currency:       a.Currency,
+			OpeningBalance: a.OpeningBalance,
+			Balance:        a.Balance,
+			IsActive:       a.IsActive,
+			CreatedAt:      a.CreatedAt,
+			UpdatedAt:      a.UpdatedAt,
+
+			CreditLimit:         a.CreditLimit,
+			StatementClosingDay: a.StatementClosingDay,
+			PaymentDueDay:       a.PaymentDueDay,
+			APR:                 a.APR,
+		})
+	}
+
+	for _, c := range d.Categories {
+		target.Categories = append(target.Categories, models.Category{
+			ID:        c.ID,
+			UserID:    userID,
+			ParentID:  c.ParentID,
+			Name:      c.Name,
+			Type:      c.Type,
+			Color:     c.Color,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	for _, t := range d.Tags {
+		target.Tags = append(target.Tags, models.Tag{
+			ID:        t.ID,
+			UserID:    userID,
+			Name:      t.Name,
+			Color:     t.Color,
+			CreatedAt: t.CreatedAt,
+			UpdatedAt: t.UpdatedAt,
+		})
+	}
+
+	for _, e := range d.ExchangeRates {
+		target.ExchangeRates = append(target.ExchangeRates, models.ExchangeRate{
+			ID:            e.ID,
+			UserID:        userID,
+			BaseCurrency:  e.BaseCurrency,
+			QuoteCurrency: e.QuoteCurrency,
+			Rate:          e.Rate,
+			Date:          e.Date,
+			Source:        e.Source,
+			CreatedAt:     e.CreatedAt,
+			UpdatedAt:     e.UpdatedAt,
+		})
+	}
+
+	for _, st := range d.Statements {
+		target.Statements = append(target.Statements, models.CreditCardStatement{
+			ID:               st.ID,
+			AccountID:        st.AccountID,
+			PeriodStart:      st.PeriodStart,
+			PeriodEnd:        st.PeriodEnd,
+			DueDate:          st.DueDate,
+			PreviousBalance:  st.PreviousBalance,
+			Charges:          st.Charges,
+			Credits:          st.Credits,
+			StatementBalance: st.StatementBalance,
+			MinimumPayment:   st.MinimumPayment,
+			CreditLimit:      st.CreditLimit,
+			APR:              st.APR,
+			CreatedAt:        st.CreatedAt,
+			UpdatedAt:        st.UpdatedAt,
+		})
+	}
+
+	for _, n := range d.NetWorth {
+		target.NetWorthSnapshots = append(target.NetWorthSnapshots, models.NetWorthSnapshot{
+			ID:          n.ID,
+			UserID:      userID,
+			Date:        n.Date,
+			Currency:    n.Currency,
+			Assets:      n.Assets,
+			Liabilities: n.Liabilities,
+			NetWorth:    n.NetWorth,
+			CreatedAt:   n.CreatedAt,
+			UpdatedAt:   n.UpdatedAt,
+		})
+	}
+
+	for _, r := range d.Recurring {
+		recurring := models.RecurringTransaction{
+			ID:             r.ID,
+			UserID:         userID,
+			AccountID:      r.AccountID,
+			CategoryID:     r.CategoryID,
+			Amount:         r.Amount,
+			Description:    r.Description,
+			RRule:          r.RRule,
+			StartDate:      r.StartDate,
+			NextOccurrence: r.NextOccurrence,
+			IsActive:       r.IsActive,
+			CreatedAt:      r.CreatedAt,
+			UpdatedAt:      r.UpdatedAt,
+		}
+		for _, e := range r.Exceptions {
+			recurring.Exceptions = append(recurring.Exceptions, models.RecurringException{
+				ID:             e.ID,
+				RecurringID:    r.ID,
+				OccurrenceDate: e.OccurrenceDate,
+				Action:         e.Action,
+				Amount:         e.Amount,
+				Description:    e.Description,
+				CategoryID:     e.CategoryID,
+				Date:           e.Date,
+				CreatedAt:      e.CreatedAt,
+				UpdatedAt:      e.UpdatedAt,
+			})
+		}
+		target.Recurring = append(target.Recurring, recurring)
+	}
+
+	for _, t := range d.Transactions {
+		transaction := models.Transaction{
+			ID:               t.ID,
+			UserID:           userID,
+			AccountID:        t.AccountID,
+			CategoryID:       t.CategoryID,
+			TransferID:       t.TransferID,
+			Amount:           t.Amount,
+			OriginalAmount:   t.OriginalAmount,
+			OriginalCurrency: t.OriginalCurrency,
+			Description:      t.Description,
+			Date:             t.Date,
+			RecurringID:      t.RecurringID,
+			OccurrenceDate:   t.OccurrenceDate,
+			ExternalID:       t.ExternalID,
+			CreatedAt:        t.CreatedAt,
+			UpdatedAt:        t.UpdatedAt,
+		}
+		for _, s := range t.Splits {
+			transaction.Splits = append(transaction.Splits, models.TransactionSplit{
+				ID:            s.ID,
+				TransactionID: t.ID,
+				CategoryID:    s.CategoryID,
+				Amount:        s.Amount,
+				Memo:          s.Memo,
+				CreatedAt:     s.CreatedAt,
+				UpdatedAt:     s.UpdatedAt,
+			})
+		}
+		for _, id := range t.TagIDs {
+			transaction.Tags = append(transaction.Tags, models.Tag{ID: id})
+		}
+		target.Transactions = append(target.Transactions, transaction)
+	}
+
+	for _, b := range d.Budgets {
+		budget := models.Budget{
+			ID:        b.ID,
+			UserID:    userID,
+			Name:      b.Name,
+			Amount:    b.Amount,
+			Period:    b.Period,
+			Rollover:  b.Rollover,
+			StartDate: b.StartDate,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+		}
+		for _, id := range b.CategoryIDs {
+			budget.Categories = append(budget.Categories, models.Category{ID: id})
+		}
+		target.Budgets = append(target.Budgets, budget)
+	}
+
+	for _, p := range d.ImportProfiles {
+		target.ImportProfiles = append(target.ImportProfiles, models.ImportProfile{
+			ID:                p.ID,
+			UserID:            userID,
+			Name:              p.Name,
+			Delimiter:         p.Delimiter,
+			HasHeader:         p.HasHeader,
+			SkipRows:          p.SkipRows,
+			DateColumn:        p.DateColumn,
+			AmountColumn:      p.AmountColumn,
+			DebitColumn:       p.DebitColumn,
+			CreditColumn:      p.CreditColumn,
+			DescriptionColumn: p.DescriptionColumn,
+			ExternalIDColumn:  p.ExternalIDColumn,
+			DateFormat:        p.DateFormat,
+			DecimalSeparator:  p.DecimalSeparator,
+			SignConvention:    p.SignConvention,
+			DefaultCategoryID: p.DefaultCategoryID,
+			CreatedAt:         p.CreatedAt,
+			UpdatedAt:         p.UpdatedAt,
+		})
+	}
+
+	return target
+}
+
+// MapIDs replaces every identifier in the data, and every reference to it,
+// with the result of fn. fn must return the same ID for the same input so
+// references stay consistent.
+func (d *Data) MapIDs(fn func(id uuid.UUID) uuid.UUID) {
+	mapOptional := func(id *uuid.UUID) *uuid.UUID {
+		if id == nil {
+			return nil
+		}
+		mapped := fn(*id)
+		return &mapped
+	}
+
+	d.User.ID = fn(d.User.ID)
+	for i := range d.Accounts {
+		d.Accounts[i].ID = fn(d.Accounts[i].ID)
+	}
+	for i := range d.Categories {
+		d.Categories[i].ID = fn(d.Categories[i].ID)
+		d.Categories[i].ParentID = mapOptional(d.Categories[i].ParentID)
+	}
+	for i := range d.Tags {
+		d.Tags[i].ID = fn(d.Tags[i].ID)
+	}
+	for i := range d.ExchangeRates {
+		d.ExchangeRates[i].ID = fn(d.ExchangeRates[i].ID)
+	}
+	for i := range d.Statements {
+		d.Statements[i].ID = fn(d.Statements[i].ID)
+		d.Statements[i].AccountID = fn(d.Statements[i].AccountID)
+	}
+	for i := range d.NetWorth {
+		d.NetWorth[i].ID = fn(d.NetWorth[i].ID)
+	}
+	for i := range d.Recurring {
+		r := &d.Recurring[i]
+		r.ID = fn(r.ID)
+		r.AccountID = fn(r.AccountID)
+		r.CategoryID = fn(r.CategoryID)
+		for j := range r.Exceptions {
+			e := &r.Exceptions[j]
+			e.ID = fn(e.ID)
+			e.CategoryID = mapOptional(e.CategoryID)
+		}
+	}
+	for i := range d.Transactions {
+		t := &d.Transactions[i]
+		t.ID = fn(t.ID)
+		t.AccountID = fn(t.AccountID)
+		t.CategoryID = mapOptional(t.CategoryID)
+		t.TransferID = mapOptional(t.TransferID)
+		t.RecurringID = mapOptional(t.RecurringID)
+		for j := range t.Splits {
+			s := &t.Splits[j]
+			s.ID = fn(s.ID)
+			s.CategoryID = fn(s.CategoryID)
+		}
+		for j := range t.TagIDs {
+			t.TagIDs[j] = fn(t.TagIDs[j])
+		}
+	}
+	for i := range d.Budgets {
+		b := &d.Budgets[i]
+		b.ID = fn(b.ID)
+		for j := range b.CategoryIDs {
+			b.CategoryIDs[j] = fn(b.CategoryIDs[j])
+		}
+	}
+	for i := range d.ImportProfiles {
+		p := &d.ImportProfiles[i]
+		p.ID = fn(p.ID)
+		p.DefaultCategoryID = fn(p.DefaultCategoryID)
+	}
+}
+
+// Validate checks that identifiers are unique and that every reference points
+// to a record in the data
+func (d *Data) Validate() error {
+	if d.User.ID == uuid.Nil || d.User.Email == "" {
+		return errors.New("invalid backup data: user ID and email are required")
+	}
+
+	seen := map[uuid.UUID]bool{d.User.ID: true}
+	add := func(kind string, id uuid.UUID) error {
+		if id == uuid.Nil {
+			return fmt.Errorf("invalid backup data: %s has no ID", kind)
+		}
+		if seen[id] {
+			return fmt.Errorf("invalid backup data: duplicate ID %s", id)
+		}
+		seen[id] = true
+		return nil
+	}
+
+	accounts := make(map[uuid.UUID]bool, len(d.Accounts))
+	for _, a := range d.Accounts {
+		if err := add("account", a.ID); err != nil {
+			return err
+		}
+		accounts[a.ID] = true
+	}
+	categories := make(map[uuid.UUID]bool, len(d.Categories))
+	parents := make(map[uuid.UUID]uuid.UUID, len(d.Categories))
+	for _, c := range d.Categories {
+		if err := add("category", c.ID); err != nil {
+			return err
+		}
+		categories[c.ID] = true
+		if c.ParentID != nil {
+			parents[c.ID] = *c.ParentID
+		}
+	}
+	for id, parent := range parents {
+		if !categories[parent] {
+			return fmt.Errorf("invalid backup data: category %s refers to unknown parent category %s", id, parent)
+		}
+		// Walking up from any category must reach a top-level category
+		for steps, current := 0, parent; ; steps++ {
+			next, ok := parents[current]
+			if !ok {
+				break
+			}
+			if steps > len(parents) {
+				return fmt.Errorf("invalid backup data: category %s is part of a parent cycle", id)
+			}
+			current = next
+		}
+	}
+
+	tags := make(map[uuid.UUID]bool, len(d.Tags))
+	names := make(map[string]bool, len(d.Tags))
+	for _, t := range d.Tags {
+		if err := add("tag", t.ID); err != nil {
+			return err
+		}
+		tags[t.ID] = true
+		name := strings.ToLower(t.Name)
+		if names[name] {
+			return fmt.Errorf("invalid backup data: duplicate tag name %q", t.Name)
+		}
+		names[name] = true
+	}
+
+	pairs := make(map[string]bool, len(d.ExchangeRates))
+	for _, e := range d.ExchangeRates {
+		if err := add("exchange rate", e.ID); err != nil {
+			return err
+		}
+		key := e.BaseCurrency + "/" + e.QuoteCurrency + "@" + e.Date.Format("2006-01-02")
+		if pairs[key] {
+			return fmt.Errorf("invalid backup data: duplicate exchange rate %s/%s on %s",
+				e.BaseCurrency, e.QuoteCurrency, e.Date.Format("2006-01-02"))
+		}
+		pairs[key] = true
+	}
+
+	checkAccount := func(kind string, id, account uuid.UUID) error {
+		if !accounts[account] {
+			return fmt.Errorf("invalid backup data: %s %s refers to unknown account %s", kind, id, account)
+		}
+		return nil
+	}
+	checkCategory := func(kind string, id uuid.UUID, category *uuid.UUID) error {
+		if category != nil && !categories[*category] {
+			return fmt.Errorf("invalid backup data: %s %s refers to unknown category %s", kind, id, *category)
+		}
+		return nil
+	}
+
+	cycles := make(map[string]bool, len(d.Statements))
+	for _, st := range d.Statements {
+		if err := add("statement", st.ID); err != nil {
+			return err
+		}
+		if err := checkAccount("statement", st.ID, st.AccountID); err != nil {
+			return err
+		}
+		key := st.AccountID.String() + "@" + st.PeriodEnd.Format("2006-01-02")
+		if cycles[key] {
+			return fmt.Errorf("invalid backup data: duplicate statement for account %s closing on %s",
+				st.AccountID, st.PeriodEnd.Format("2006-01-02"))
+		}
+		cycles[key] = true
+	}
+
+	days := make(map[string]bool, len(d.NetWorth))
+	for _, n := range d.NetWorth {
+		if err := add("net worth snapshot", n.ID); err != nil {
+			return err
+		}
+		day := n.Date.Format("2006-01-02")
+		if days[day] {
+			return fmt.Errorf("invalid backup data: duplicate net worth snapshot on %s", day)
+		}
+		days[day] = true
+	}
+
+	recurring := make(map[uuid.UUID]bool, len(d.Recurring))
+	for _, r := range d.Recurring {
+		if err := add("recurring transaction", r.ID); err != nil {
+			return err
+		}
+		recurring[r.ID] = true
+		if err := checkAccount("recurring transaction", r.ID, r.AccountID); err != nil {
+			return err
+		}
+		categoryID := r.CategoryID
+		if err := checkCategory("recurring transaction", r.ID, &categoryID); err != nil {
+			return err
+		}
+		for _, e := range r.Exceptions {
+			if err := add("recurring exception", e.ID); err != nil {
+				return err
+			}
+			if err := checkCategory("recurring exception", e.ID, e.CategoryID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, t := range d.Transactions {
+		if err := add("transaction", t.ID); err != nil {
+			return err
+		}
+		if err := checkAccount("transaction", t.ID, t.AccountID); err != nil {
+			return err
+		}
+		if err := checkCategory("transaction", t.ID, t.CategoryID); err != nil {
+			return err
+		}
+		if t.RecurringID != nil && !recurring[*t.RecurringID] {
+			return fmt.Errorf("invalid backup data: transaction %s refers to unknown recurring transaction %s", t.ID, *t.RecurringID)
+		}
+		for _, s := range t.Splits {
+			if err := add("transaction split", s.ID); err != nil {
+				return err
+			}
+			categoryID := s.CategoryID
+			if err := checkCategory("transaction split", s.ID, &categoryID); err != nil {
+				return err
+			}
+		}
+		for _, tagID := range t.TagIDs {
+			if !tags[tagID] {
+				return fmt.Errorf("invalid backup data: transaction %s refers to unknown tag %s", t.ID, tagID)
+			}
+		}
+	}
+
+	for _, b := range d.Budgets {
+		if err := add("budget", b.ID); err != nil {
+			return err
+		}
+		for _, categoryID := range b.CategoryIDs {
+			categoryID := categoryID
+			if err := checkCategory("budget", b.ID, &categoryID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, p := range d.ImportProfiles {
+		if err := add("import profile", p.ID); err != nil {
+			return err
+		}
+		categoryID := p.DefaultCategoryID
+		if err := checkCategory("import profile", p.ID, &categoryID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +20,23 @@ type Config struct {
 	DBName      string
 	DBSSLMode   string
 	Environment string
+
+	// JWT settings
+	JWTSecret     string
+	JWTExpiration time.Duration
+
+	// Key used to sign and verify backup archives
+	BackupSecret string
+
+	// Login lockout settings
+	MaxLoginAttempts int
+	LockoutDuration  time.Duration
+
+	// Background job settings
+	BalanceCheckInterval time.Duration
+	RecurringInterval    time.Duration
+	StatementInterval    time.Duration
+	NetWorthInterval     time.Duration
 }
 
 // Load loads configuration from environment variables
@@ -34,6 +53,33 @@ func Load() (*Config, error) {
 		DBName:      getEnv("DB_NAME", "expenseTrackerDB"),
 		DBSSLMode:   getEnv("DB_SSLMODE", "disable"),
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		JWTSecret:     getEnv("JWT_SECRET", ""),
+		JWTExpiration: getEnvAsDuration("JWT_EXPIRATION", 24*time.Hour),
+
+		BackupSecret: getEnv("BACKUP_SECRET", ""),
+
+		MaxLoginAttempts: getEnvAsInt("MAX_LOGIN_ATTEMPTS", 5),
+		LockoutDuration:  getEnvAsDuration("LOCKOUT_DURATION", 15*time.Minute),
+
+		BalanceCheckInterval: getEnvAsDuration("BALANCE_CHECK_INTERVAL", 24*time.Hour),
+		RecurringInterval:    getEnvAsDuration("RECURRING_INTERVAL", time.Hour),
+		StatementInterval:    getEnvAsDuration("STATEMENT_INTERVAL", 24*time.Hour),
+		NetWorthInterval:     getEnvAsDuration("NET_WORTH_INTERVAL", 24*time.Hour),
+	}
+
+	if config.JWTSecret == "" {
+		if config.Environment == "production" {
+			return nil, errors.New("JWT_SECRET must be set in production")
+		}
+		config.JWTSecret = "development-secret-change-me"
+	}
+
+	if config.BackupSecret == "" {
+		if config.Environment == "production" {
+			return nil, errors.New("BACKUP_SECRET must be set in production")
+		}
+		config.BackupSecret = "development-backup-secret-change-me"
 	}
 
 	return config, nil
@@ -72,3 +118,13 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return val
 }
+
+// getEnvAsDuration retrieves an environment variable as a duration (e.g. "24h", "15m")
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valStr := getEnv(key, defaultValue.String())
+	val, err := time.ParseDuration(valStr)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -7,8 +7,6 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-
-	"github.com/vasujain275/expense-tracker-api/internal/models"
 )
 
 // Connect establishes a connection to the PostgreSQL database
@@ -41,25 +39,6 @@ func Connect(dsn string) *gorm.DB {
 	return db
 }
 
-// Migrate runs database migrations
-func Migrate(db *gorm.DB) error {
-	log.Println("Running database migrations...")
-
-	err := db.AutoMigrate(
-		&models.User{},
-		&models.Account{},
-		&models.Category{},
-		&models.Transaction{},
-	)
-
-	if err != nil {
-		return err
-	}
-
-	log.Println("Database migrations completed successfully")
-	return nil
-}
-
 // Close closes the database connection
 func Close(db *gorm.DB) error {
 	sqlDB, err := db.DB()
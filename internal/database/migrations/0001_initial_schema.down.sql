@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS transaction_splits;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,89 @@
+-- Initial schema. Statements are idempotent so databases that were previously
+-- managed by gorm AutoMigrate are adopted without losing data.
+
+CREATE TABLE IF NOT EXISTS users (
+    id                    uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    email                 text NOT NULL,
+    name                  text NOT NULL,
+    currency              text NOT NULL DEFAULT 'USD',
+    password_hash         text NOT NULL DEFAULT '',
+    failed_login_attempts bigint NOT NULL DEFAULT 0,
+    locked_until          timestamptz,
+    created_at            timestamptz,
+    updated_at            timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         uuid NOT NULL REFERENCES users (id),
+    name            text NOT NULL,
+    type            text NOT NULL,
+    opening_balance decimal(15,2) NOT NULL DEFAULT 0,
+    balance         decimal(15,2) NOT NULL DEFAULT 0,
+    is_active       boolean NOT NULL DEFAULT true,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       text NOT NULL,
+    type       text NOT NULL,
+    color      text NOT NULL DEFAULT '#007bff',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     uuid NOT NULL REFERENCES users (id),
+    account_id  uuid NOT NULL REFERENCES accounts (id),
+    category_id uuid REFERENCES categories (id),
+    transfer_id uuid,
+    amount      decimal(15,2) NOT NULL,
+    description text NOT NULL,
+    date        timestamptz NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id uuid NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    category_id    uuid NOT NULL REFERENCES categories (id),
+    amount         decimal(15,2) NOT NULL,
+    memo           text NOT NULL DEFAULT '',
+    created_at     timestamptz,
+    updated_at     timestamptz
+);
+
+-- Columns added while the schema was still managed by AutoMigrate
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash text NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_login_attempts bigint NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until timestamptz;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS transfer_id uuid;
+ALTER TABLE transactions ALTER COLUMN category_id DROP NOT NULL;
+
+-- Opening balances are derived from the running balance for accounts created
+-- before they were tracked
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'accounts' AND column_name = 'opening_balance'
+    ) THEN
+        ALTER TABLE accounts ADD COLUMN opening_balance decimal(15,2) NOT NULL DEFAULT 0;
+        UPDATE accounts SET opening_balance = balance - COALESCE(
+            (SELECT SUM(amount) FROM transactions WHERE transactions.account_id = accounts.id), 0);
+    END IF;
+END
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions (date);
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_date ON transactions (account_id, date);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_transaction_id ON transaction_splits (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_category_id ON transaction_splits (category_id);
@@ -0,0 +1,2 @@
+ALTER TABLE transaction_splits DROP CONSTRAINT IF EXISTS chk_transaction_splits_amount_nonzero;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_amount_nonzero;
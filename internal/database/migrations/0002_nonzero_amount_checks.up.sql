@@ -0,0 +1,7 @@
+-- Zero-amount rows have no effect on balances and are rejected by the services;
+-- enforce the same rule in the database.
+ALTER TABLE transactions
+    ADD CONSTRAINT chk_transactions_amount_nonzero CHECK (amount <> 0);
+
+ALTER TABLE transaction_splits
+    ADD CONSTRAINT chk_transaction_splits_amount_nonzero CHECK (amount <> 0);
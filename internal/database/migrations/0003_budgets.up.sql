@@ -0,0 +1,22 @@
+CREATE TABLE budgets (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid NOT NULL REFERENCES users (id),
+    name       text NOT NULL,
+    amount     decimal(15,2) NOT NULL CHECK (amount > 0),
+    period     text NOT NULL CHECK (period IN ('weekly', 'monthly', 'yearly')),
+    rollover   boolean NOT NULL DEFAULT false,
+    start_date timestamptz NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE INDEX idx_budgets_user_id ON budgets (user_id);
+
+-- A budget covers a single category or a group of categories
+CREATE TABLE budget_categories (
+    budget_id   uuid NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    category_id uuid NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (budget_id, category_id)
+);
+
+CREATE INDEX idx_budget_categories_category_id ON budget_categories (category_id);
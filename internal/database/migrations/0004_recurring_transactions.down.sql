@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_recurring_occurrence;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS occurrence_date,
+    DROP COLUMN IF EXISTS recurring_id;
+
+DROP TABLE IF EXISTS recurring_exceptions;
+DROP TABLE IF EXISTS recurring_transactions;
@@ -0,0 +1,41 @@
+CREATE TABLE recurring_transactions (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         uuid NOT NULL REFERENCES users (id),
+    account_id      uuid NOT NULL REFERENCES accounts (id),
+    category_id     uuid NOT NULL REFERENCES categories (id),
+    amount          decimal(15,2) NOT NULL CHECK (amount <> 0),
+    description     text NOT NULL,
+    rrule           text NOT NULL,
+    start_date      timestamptz NOT NULL,
+    next_occurrence timestamptz,
+    is_active       boolean NOT NULL DEFAULT true,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+
+CREATE INDEX idx_recurring_transactions_user_id ON recurring_transactions (user_id);
+CREATE INDEX idx_recurring_transactions_next_occurrence ON recurring_transactions (next_occurrence)
+    WHERE is_active;
+
+-- Per-occurrence overrides: skip an occurrence or change what it generates
+CREATE TABLE recurring_exceptions (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    recurring_id    uuid NOT NULL REFERENCES recurring_transactions (id) ON DELETE CASCADE,
+    occurrence_date timestamptz NOT NULL,
+    action          text NOT NULL CHECK (action IN ('skip', 'modify')),
+    amount          decimal(15,2) CHECK (amount <> 0),
+    description     text,
+    category_id     uuid REFERENCES categories (id),
+    date            timestamptz,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    UNIQUE (recurring_id, occurrence_date)
+);
+
+ALTER TABLE transactions
+    ADD COLUMN recurring_id uuid REFERENCES recurring_transactions (id) ON DELETE SET NULL,
+    ADD COLUMN occurrence_date timestamptz;
+
+-- Each occurrence of a schedule is materialized at most once, even if the
+-- worker restarts between creating the transaction and advancing the schedule
+CREATE UNIQUE INDEX idx_transactions_recurring_occurrence ON transactions (recurring_id, occurrence_date);
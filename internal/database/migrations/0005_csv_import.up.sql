@@ -0,0 +1,28 @@
+CREATE TABLE import_profiles (
+    id                  uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id             uuid NOT NULL REFERENCES users (id),
+    name                text NOT NULL,
+    delimiter           text NOT NULL DEFAULT ',',
+    has_header          boolean NOT NULL DEFAULT true,
+    skip_rows           bigint NOT NULL DEFAULT 0,
+    date_column         text NOT NULL,
+    amount_column       text NOT NULL DEFAULT '',
+    debit_column        text NOT NULL DEFAULT '',
+    credit_column       text NOT NULL DEFAULT '',
+    description_column  text NOT NULL,
+    external_id_column  text NOT NULL DEFAULT '',
+    date_format         text NOT NULL,
+    decimal_separator   text NOT NULL DEFAULT '.',
+    sign_convention     text NOT NULL DEFAULT 'negative_is_debit',
+    default_category_id uuid NOT NULL REFERENCES categories (id),
+    created_at          timestamptz,
+    updated_at          timestamptz
+);
+
+CREATE INDEX idx_import_profiles_user_id ON import_profiles (user_id);
+
+-- Bank-assigned identifiers of imported statement lines; the same line can
+-- only be imported into an account once
+ALTER TABLE transactions ADD COLUMN external_id text;
+
+CREATE UNIQUE INDEX idx_transactions_account_external_id ON transactions (account_id, external_id);
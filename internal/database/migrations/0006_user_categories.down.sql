@@ -0,0 +1,8 @@
+-- Each user's categories remain as separate global categories; the original
+-- shared set cannot be reconstructed
+DROP INDEX IF EXISTS idx_categories_parent_id;
+DROP INDEX IF EXISTS idx_categories_user_id;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS user_id;
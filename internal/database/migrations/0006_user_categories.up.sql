@@ -0,0 +1,57 @@
+-- Categories become owned by a user and can nest below a parent category
+ALTER TABLE categories
+    ADD COLUMN user_id uuid REFERENCES users (id),
+    ADD COLUMN parent_id uuid REFERENCES categories (id);
+
+-- Every user gets a private copy of each global category, and their records
+-- are pointed at the copies
+CREATE TEMPORARY TABLE category_copies ON COMMIT DROP AS
+SELECT categories.id AS old_id, users.id AS user_id, gen_random_uuid() AS new_id
+FROM categories CROSS JOIN users
+WHERE categories.user_id IS NULL;
+
+INSERT INTO categories (id, user_id, name, type, color, created_at, updated_at)
+SELECT category_copies.new_id, category_copies.user_id, categories.name, categories.type,
+       categories.color, categories.created_at, now()
+FROM category_copies
+JOIN categories ON categories.id = category_copies.old_id;
+
+UPDATE transactions SET category_id = category_copies.new_id
+FROM category_copies
+WHERE transactions.category_id = category_copies.old_id
+  AND transactions.user_id = category_copies.user_id;
+
+UPDATE transaction_splits SET category_id = category_copies.new_id
+FROM transactions, category_copies
+WHERE transaction_splits.transaction_id = transactions.id
+  AND transaction_splits.category_id = category_copies.old_id
+  AND transactions.user_id = category_copies.user_id;
+
+UPDATE budget_categories SET category_id = category_copies.new_id
+FROM budgets, category_copies
+WHERE budget_categories.budget_id = budgets.id
+  AND budget_categories.category_id = category_copies.old_id
+  AND budgets.user_id = category_copies.user_id;
+
+UPDATE recurring_transactions SET category_id = category_copies.new_id
+FROM category_copies
+WHERE recurring_transactions.category_id = category_copies.old_id
+  AND recurring_transactions.user_id = category_copies.user_id;
+
+UPDATE recurring_exceptions SET category_id = category_copies.new_id
+FROM recurring_transactions, category_copies
+WHERE recurring_exceptions.recurring_id = recurring_transactions.id
+  AND recurring_exceptions.category_id = category_copies.old_id
+  AND recurring_transactions.user_id = category_copies.user_id;
+
+UPDATE import_profiles SET default_category_id = category_copies.new_id
+FROM category_copies
+WHERE import_profiles.default_category_id = category_copies.old_id
+  AND import_profiles.user_id = category_copies.user_id;
+
+DELETE FROM categories WHERE user_id IS NULL;
+
+ALTER TABLE categories ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX idx_categories_user_id ON categories (user_id);
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
@@ -0,0 +1,19 @@
+CREATE TABLE tags (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid NOT NULL REFERENCES users (id),
+    name       text NOT NULL CHECK (btrim(name) <> ''),
+    color      text NOT NULL DEFAULT '#6c757d',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+-- Tag names are unique per user regardless of case
+CREATE UNIQUE INDEX idx_tags_user_name ON tags (user_id, lower(name));
+
+CREATE TABLE transaction_tags (
+    transaction_id uuid NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    tag_id         uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (transaction_id, tag_id)
+);
+
+CREATE INDEX idx_transaction_tags_tag_id ON transaction_tags (tag_id);
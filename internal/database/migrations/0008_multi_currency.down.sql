@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS chk_transactions_original,
+    DROP COLUMN IF EXISTS original_currency,
+    DROP COLUMN IF EXISTS original_amount;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS currency;
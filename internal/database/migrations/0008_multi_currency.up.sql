@@ -0,0 +1,34 @@
+-- Accounts hold money in their own currency; existing accounts are in the
+-- currency of their owner
+ALTER TABLE accounts ADD COLUMN currency char(3);
+
+UPDATE accounts SET currency = users.currency
+FROM users
+WHERE accounts.user_id = users.id;
+
+ALTER TABLE accounts ALTER COLUMN currency SET NOT NULL;
+
+-- A purchase made in a foreign currency keeps the amount charged in that
+-- currency next to the amount booked on the account
+ALTER TABLE transactions
+    ADD COLUMN original_amount decimal(15,2),
+    ADD COLUMN original_currency char(3),
+    ADD CONSTRAINT chk_transactions_original
+        CHECK ((original_amount IS NULL) = (original_currency IS NULL));
+
+-- Rate is the price of one unit of base_currency in quote_currency on date
+CREATE TABLE exchange_rates (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        uuid NOT NULL REFERENCES users (id),
+    base_currency  char(3) NOT NULL,
+    quote_currency char(3) NOT NULL,
+    rate           decimal(20,10) NOT NULL CHECK (rate > 0),
+    date           date NOT NULL,
+    source         text NOT NULL DEFAULT 'api',
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    CHECK (base_currency <> quote_currency)
+);
+
+CREATE UNIQUE INDEX idx_exchange_rates_pair_date
+    ON exchange_rates (user_id, base_currency, quote_currency, date);
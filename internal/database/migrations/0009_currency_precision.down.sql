@@ -0,0 +1,16 @@
+-- Amounts in currencies with 3 or 4 minor units are rounded to 2 decimals
+ALTER TABLE budgets ALTER COLUMN amount TYPE decimal(15,2);
+
+ALTER TABLE recurring_exceptions ALTER COLUMN amount TYPE decimal(15,2);
+
+ALTER TABLE recurring_transactions ALTER COLUMN amount TYPE decimal(15,2);
+
+ALTER TABLE transaction_splits ALTER COLUMN amount TYPE decimal(15,2);
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE decimal(15,2),
+    ALTER COLUMN original_amount TYPE decimal(15,2);
+
+ALTER TABLE accounts
+    ALTER COLUMN opening_balance TYPE decimal(15,2),
+    ALTER COLUMN balance TYPE decimal(15,2);
@@ -0,0 +1,18 @@
+-- Amounts are kept to the minor units of their currency, which range from 0
+-- (JPY) to 4 (CLF); store them at the widest scale. Services round each
+-- amount to its currency's minor units.
+ALTER TABLE accounts
+    ALTER COLUMN opening_balance TYPE decimal(19,4),
+    ALTER COLUMN balance TYPE decimal(19,4);
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE decimal(19,4),
+    ALTER COLUMN original_amount TYPE decimal(19,4);
+
+ALTER TABLE transaction_splits ALTER COLUMN amount TYPE decimal(19,4);
+
+ALTER TABLE recurring_transactions ALTER COLUMN amount TYPE decimal(19,4);
+
+ALTER TABLE recurring_exceptions ALTER COLUMN amount TYPE decimal(19,4);
+
+ALTER TABLE budgets ALTER COLUMN amount TYPE decimal(19,4);
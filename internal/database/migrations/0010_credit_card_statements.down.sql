@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS credit_card_statements;
+
+ALTER TABLE accounts
+    DROP CONSTRAINT IF EXISTS chk_accounts_billing_cycle,
+    DROP COLUMN IF EXISTS apr,
+    DROP COLUMN IF EXISTS payment_due_day,
+    DROP COLUMN IF EXISTS statement_closing_day,
+    DROP COLUMN IF EXISTS credit_limit;
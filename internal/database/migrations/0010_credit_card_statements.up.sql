@@ -0,0 +1,31 @@
+-- Credit card terms. Day columns are days of the month; months shorter than
+-- the day use their last day. APR is a yearly percentage rate.
+ALTER TABLE accounts
+    ADD COLUMN credit_limit decimal(19,4) CHECK (credit_limit > 0),
+    ADD COLUMN statement_closing_day smallint CHECK (statement_closing_day BETWEEN 1 AND 31),
+    ADD COLUMN payment_due_day smallint CHECK (payment_due_day BETWEEN 1 AND 31),
+    ADD COLUMN apr decimal(7,4) CHECK (apr >= 0 AND apr <= 100),
+    ADD CONSTRAINT chk_accounts_billing_cycle
+        CHECK ((statement_closing_day IS NULL) = (payment_due_day IS NULL));
+
+-- One statement per closed billing cycle. Balances are amounts owed, so they
+-- are positive while the card is in debt.
+CREATE TABLE credit_card_statements (
+    id                uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id        uuid NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    period_start      date NOT NULL,
+    period_end        date NOT NULL,
+    due_date          date NOT NULL,
+    previous_balance  decimal(19,4) NOT NULL,
+    charges           decimal(19,4) NOT NULL CHECK (charges >= 0),
+    credits           decimal(19,4) NOT NULL CHECK (credits >= 0),
+    statement_balance decimal(19,4) NOT NULL,
+    minimum_payment   decimal(19,4) NOT NULL CHECK (minimum_payment >= 0),
+    credit_limit      decimal(19,4),
+    apr               decimal(7,4),
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    CHECK (period_start <= period_end),
+    CHECK (due_date > period_end),
+    UNIQUE (account_id, period_end)
+);
@@ -0,0 +1,16 @@
+-- A user's net worth at the end of a day, in the currency they had then.
+-- Liabilities are amounts owed, so they are positive while in debt.
+CREATE TABLE net_worth_snapshots (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     uuid NOT NULL REFERENCES users (id),
+    date        date NOT NULL,
+    currency    char(3) NOT NULL,
+    assets      decimal(19,4) NOT NULL,
+    liabilities decimal(19,4) NOT NULL,
+    net_worth   decimal(19,4) NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    CHECK (net_worth = assets - liabilities)
+);
+
+CREATE UNIQUE INDEX idx_net_worth_snapshots_user_date ON net_worth_snapshots (user_id, date);
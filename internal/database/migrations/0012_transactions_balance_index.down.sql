@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_date ON transactions (account_id, date);
+
+DROP INDEX IF EXISTS idx_transactions_account_id_date_amount;
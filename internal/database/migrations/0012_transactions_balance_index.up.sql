@@ -0,0 +1,5 @@
+-- Covering the amount lets balance history and other per-account sums over a
+-- date range be answered from the index alone
+CREATE INDEX idx_transactions_account_id_date_amount ON transactions (account_id, date) INCLUDE (amount);
+
+DROP INDEX IF EXISTS idx_transactions_account_id_date;
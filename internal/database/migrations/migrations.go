@@ -0,0 +1,13 @@
+// Package migrations embeds the versioned SQL migration files.
+//
+// Each version has an up and a down file named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+// New files are created with `api migrate create <name>`.
+package migrations
+
+import "embed"
+
+// FS contains every migration file in this directory
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,66 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+)
+
+// flushEvery bounds how many rows are buffered before being sent to the client
+const flushEvery = 500
+
+type csvWriter struct {
+	w       *csv.Writer
+	rows    int
+	started bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(row *Row) error {
+	if !c.started {
+		c.started = true
+		if err := c.w.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	if err := c.w.Write([]string{
+		row.ID.String(),
+		row.Date.Format("2006-01-02"),
+		row.AccountName,
+		row.CategoryName,
+		row.Description,
+		row.Amount.StringFixed(iso4217.MinorUnits(row.Currency)),
+		row.Currency,
+		row.AccountID.String(),
+		optionalID(row.CategoryID),
+		optionalID(row.TransferID),
+		optionalString(row.ExternalID),
+	}); err != nil {
+		return err
+	}
+
+	c.rows++
+	if c.rows%flushEvery == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	if !c.started {
+		if err := c.w.Write(columns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Abort stops the export; rows already sent cannot be taken back
+func (c *csvWriter) Abort() {}
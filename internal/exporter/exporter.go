@@ -0,0 +1,107 @@
+// Package exporter writes transactions to downloadable file formats one row at
+// a time, so exports of any size run in constant memory.
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat validates an export format name
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatXLSX, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, must be csv, xlsx or json", s)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatJSON:
+		return "application/json"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Filename returns a download file name for the format
+func (f Format) Filename(base string) string {
+	return base + "." + string(f)
+}
+
+// Row is a single exported transaction. Split transactions list the names of
+// all their split categories in CategoryName and have no CategoryID.
+type Row struct {
+	ID           uuid.UUID       `json:"id"`
+	Date         time.Time       `json:"date"`
+	AccountID    uuid.UUID       `json:"account_id"`
+	AccountName  string          `json:"account_name"`
+	CategoryID   *uuid.UUID      `json:"category_id,omitempty"`
+	CategoryName string          `json:"category_name"`
+	Description  string          `json:"description"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+	TransferID   *uuid.UUID      `json:"transfer_id,omitempty"`
+	ExternalID   *string         `json:"external_id,omitempty"`
+}
+
+// Writer writes rows to an export file. Close must be called to finish the
+// file, or Abort to release its resources when the export fails.
+type Writer interface {
+	Write(row *Row) error
+	Close() error
+	Abort()
+}
+
+// NewWriter creates a writer for the format
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	case FormatJSON:
+		return newJSONWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// columns are the headings of tabular formats
+var columns = []string{
+	"id", "date", "account", "category", "description", "amount", "currency",
+	"account_id", "category_id", "transfer_id", "external_id",
+}
+
+// optionalID formats an optional identifier as an empty string when missing
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
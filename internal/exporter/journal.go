@@ -0,0 +1,472 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+)
+
+// JournalFormat is a plain-text accounting file format
+type JournalFormat string
+
+const (
+	JournalLedger    JournalFormat = "ledger"
+	JournalHledger   JournalFormat = "hledger"
+	JournalBeancount JournalFormat = "beancount"
+)
+
+// ParseJournalFormat validates a plain-text accounting format name
+func ParseJournalFormat(s string) (JournalFormat, error) {
+	switch f := JournalFormat(s); f {
+	case JournalLedger, JournalHledger, JournalBeancount:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported journal format %q, must be ledger, hledger or beancount", s)
+	}
+}
+
+// Filename returns a download file name for the format
+func (f JournalFormat) Filename(base string) string {
+	switch f {
+	case JournalHledger:
+		return base + ".journal"
+	default:
+		return base + "." + string(f)
+	}
+}
+
+// JournalTransaction is a transaction with one posting per category
+// allocation. Posting amounts are the allocated parts of Amount, signed like
+// the transaction: negative for money leaving the account.
+type JournalTransaction struct {
+	ID          uuid.UUID
+	Date        time.Time
+	Description string
+	AccountID   uuid.UUID
+	TransferID  *uuid.UUID
+	Amount      decimal.Decimal
+	Postings    []JournalPosting
+}
+
+// JournalPosting allocates part of a transaction to a category. A nil
+// CategoryID books the amount as uncategorized.
+type JournalPosting struct {
+	CategoryID *uuid.UUID
+	Amount     decimal.Decimal
+}
+
+// JournalWriter writes a double-entry journal. Accounts become
+// Assets/Liabilities accounts, categories become Income/Expenses accounts,
+// opening balances are booked against equity and the journal ends with a
+// balance assertion for every account. Amounts are in the currency of the
+// account they were booked in; a transfer between currencies prices the
+// received amount at the total sent.
+type JournalWriter struct {
+	format     JournalFormat
+	w          *bufio.Writer
+	currency   string
+	currencies []string
+	accounts   []*models.Account
+	names      map[uuid.UUID]string
+	held       map[uuid.UUID]string
+	categories map[uuid.UUID]string
+	transfers  map[uuid.UUID]*JournalTransaction
+	last       time.Time
+	entries    int
+}
+
+// Equity and fallback accounts every journal declares
+const (
+	journalOpening       = "Equity:Opening Balances"
+	journalTransfers     = "Equity:Transfers"
+	journalUncategorized = "Uncategorized"
+)
+
+// NewJournalWriter writes the journal header, account declarations and
+// opening balances dated start. Transactions must then be written in date
+// order, followed by Close.
+func NewJournalWriter(format JournalFormat, w io.Writer, currency string, accounts []*models.Account, categories []*models.Category, start time.Time) (*JournalWriter, error) {
+	if _, err := ParseJournalFormat(string(format)); err != nil {
+		return nil, err
+	}
+
+	j := &JournalWriter{
+		format:     format,
+		w:          bufio.NewWriter(w),
+		currency:   strings.ToUpper(currency),
+		currencies: journalCurrencies(currency, accounts),
+		accounts:   accounts,
+		names:      make(map[uuid.UUID]string, len(accounts)),
+		held:       make(map[uuid.UUID]string, len(accounts)),
+		categories: make(map[uuid.UUID]string, len(categories)),
+		transfers:  make(map[uuid.UUID]*JournalTransaction),
+		last:       start,
+	}
+
+	// Reserve the fallback accounts so no record is named after them
+	used := map[string]bool{
+		j.name("Expenses:" + journalUncategorized): true,
+		j.name("Income:" + journalUncategorized):   true,
+		j.name(journalOpening):                     true,
+		j.name(journalTransfers):                   true,
+	}
+	for _, account := range accounts {
+		parent := "Assets:Bank"
+		switch account.Type {
+		case models.AccountTypeCash:
+			parent = "Assets:Cash"
+		case models.AccountTypeSavings:
+			parent = "Assets:Savings"
+		case models.AccountTypeInvestment:
+			parent = "Assets:Investments"
+		case models.AccountTypeCreditCard:
+			parent = "Liabilities:Credit Card"
+		case models.AccountTypeLoan:
+			parent = "Liabilities:Loan"
+		}
+		j.names[account.ID] = j.uniqueName(used, parent, account.Name, account.ID)
+		if account.Currency != "" {
+			j.held[account.ID] = strings.ToUpper(account.Currency)
+		}
+	}
+	byID := make(map[uuid.UUID]*models.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	for _, category := range categories {
+		j.categoryAccount(used, byID, category, 0)
+	}
+
+	j.writeHeader(start)
+	j.writeOpeningBalances(start)
+	return j, nil
+}
+
+// WriteTransaction writes a transaction. The two legs of a transfer are
+// combined into a single entry moving money between the accounts.
+func (j *JournalWriter) WriteTransaction(t *JournalTransaction) error {
+	if t.Date.After(j.last) {
+		j.last = t.Date
+	}
+
+	if t.TransferID != nil {
+		other, ok := j.transfers[*t.TransferID]
+		if !ok {
+			j.transfers[*t.TransferID] = t
+			return nil
+		}
+		delete(j.transfers, *t.TransferID)
+
+		// Describe the transfer from the leg that received the money
+		to, from := t, other
+		if to.Amount.IsNegative() {
+			to, from = from, to
+		}
+		received := journalLine{account: j.names[to.AccountID], amount: to.Amount, currency: j.accountCurrency(to.AccountID)}
+		sent := journalLine{account: j.names[from.AccountID], amount: from.Amount, currency: j.accountCurrency(from.AccountID)}
+		if received.currency != sent.currency {
+			received.cost = sent.amount.Abs()
+			received.costCurrency = sent.currency
+		}
+		j.writeEntry(to.Date, to.Description, to.ID, []journalLine{received, sent})
+		return j.flushPeriodically()
+	}
+
+	currency := j.accountCurrency(t.AccountID)
+	lines := make([]journalLine, 0, len(t.Postings)+1)
+	for _, posting := range t.Postings {
+		lines = append(lines, journalLine{account: j.categoryName(posting), amount: posting.Amount.Neg(), currency: currency})
+	}
+	lines = append(lines, journalLine{account: j.names[t.AccountID], amount: t.Amount, currency: currency})
+	j.writeEntry(t.Date, t.Description, t.ID, lines)
+	return j.flushPeriodically()
+}
+
+// Close writes transfer legs whose counterpart was not exported, then a
+// balance assertion for every account, and flushes the output
+func (j *JournalWriter) Close() error {
+	ids := make([]uuid.UUID, 0, len(j.transfers))
+	for id := range j.transfers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool { return ids[a].String() < ids[b].String() })
+	for _, id := range ids {
+		leg := j.transfers[id]
+		currency := j.accountCurrency(leg.AccountID)
+		j.writeEntry(leg.Date, leg.Description, leg.ID, []journalLine{
+			{account: j.names[leg.AccountID], amount: leg.Amount, currency: currency},
+			{account: j.name(journalTransfers), amount: leg.Amount.Neg(), currency: currency},
+		})
+	}
+
+	j.writeBalanceAssertions()
+	return j.w.Flush()
+}
+
+// journalLine is one posting of an entry. A non-empty costCurrency prices
+// the line at the total cost, written as "@@ cost costCurrency".
+type journalLine struct {
+	account      string
+	amount       decimal.Decimal
+	currency     string
+	cost         decimal.Decimal
+	costCurrency string
+}
+
+func (j *JournalWriter) writeHeader(start time.Time) {
+	declared := j.declaredAccounts()
+
+	if j.format == JournalBeancount {
+		fmt.Fprintf(j.w, "; Expense tracker journal exported %s\n\n", time.Now().Format("2006-01-02"))
+		fmt.Fprintf(j.w, "option \"title\" \"Expense tracker\"\n")
+		fmt.Fprintf(j.w, "option \"operating_currency\" \"%s\"\n\n", j.currency)
+		for _, currency := range j.currencies {
+			fmt.Fprintf(j.w, "%s commodity %s\n", start.Format("2006-01-02"), currency)
+		}
+		fmt.Fprintln(j.w)
+		// Only accounts hold a single currency; categories and equity
+		// accounts take whatever currency is booked to them
+		constraints := make(map[string]string, len(j.accounts))
+		for _, account := range j.accounts {
+			constraints[j.names[account.ID]] = " " + j.accountCurrency(account.ID)
+		}
+		for _, name := range declared {
+			fmt.Fprintf(j.w, "%s open %s%s\n", start.Format("2006-01-02"), name, constraints[name])
+		}
+		fmt.Fprintln(j.w)
+		return
+	}
+
+	fmt.Fprintf(j.w, "; Expense tracker journal exported %s\n\n", time.Now().Format("2006-01-02"))
+	for _, currency := range j.currencies {
+		if j.format == JournalHledger {
+			// A sample amount sets the display style in hledger
+			fmt.Fprintf(j.w, "commodity %s\n", j.amount(decimal.NewFromInt(1000), currency))
+		} else {
+			fmt.Fprintf(j.w, "commodity %s\n", currency)
+		}
+	}
+	fmt.Fprintln(j.w)
+	for _, name := range declared {
+		fmt.Fprintf(j.w, "account %s\n", name)
+	}
+	fmt.Fprintln(j.w)
+}
+
+// declaredAccounts lists every account name the journal can use
+func (j *JournalWriter) declaredAccounts() []string {
+	var names []string
+	for _, account := range j.accounts {
+		names = append(names, j.names[account.ID])
+	}
+	for _, name := range j.categories {
+		names = append(names, name)
+	}
+	names = append(names,
+		j.name("Expenses:"+journalUncategorized),
+		j.name("Income:"+journalUncategorized),
+		j.name(journalOpening),
+		j.name(journalTransfers),
+	)
+	sort.Strings(names)
+	return names
+}
+
+func (j *JournalWriter) writeOpeningBalances(start time.Time) {
+	for _, account := range j.accounts {
+		if account.OpeningBalance.IsZero() {
+			continue
+		}
+		currency := j.accountCurrency(account.ID)
+		j.writeEntry(start, "Opening balance", uuid.Nil, []journalLine{
+			{account: j.names[account.ID], amount: account.OpeningBalance, currency: currency},
+			{account: j.name(journalOpening), amount: account.OpeningBalance.Neg(), currency: currency},
+		})
+	}
+}
+
+func (j *JournalWriter) writeEntry(date time.Time, description string, id uuid.UUID, lines []journalLine) {
+	description = strings.Join(strings.Fields(description), " ")
+	if j.format == JournalBeancount {
+		fmt.Fprintf(j.w, "%s * \"%s\"\n", date.Format("2006-01-02"), escapeBeancount(description))
+		if id != uuid.Nil {
+			fmt.Fprintf(j.w, "  id: \"%s\"\n", id)
+		}
+		for _, line := range lines {
+			fmt.Fprintf(j.w, "  %s  %s\n", line.account, j.line(line))
+		}
+	} else {
+		fmt.Fprintf(j.w, "%s * %s\n", date.Format("2006-01-02"), description)
+		if id != uuid.Nil {
+			fmt.Fprintf(j.w, "    ; id: %s\n", id)
+		}
+		for _, line := range lines {
+			fmt.Fprintf(j.w, "    %s  %s\n", line.account, j.line(line))
+		}
+	}
+	fmt.Fprintln(j.w)
+	j.entries++
+}
+
+// writeBalanceAssertions checks every account against its stored balance.
+// Beancount checks balances at the start of the day, so its assertions are
+// dated the day after the last transaction.
+func (j *JournalWriter) writeBalanceAssertions() {
+	if len(j.accounts) == 0 {
+		return
+	}
+
+	if j.format == JournalBeancount {
+		date := j.last.AddDate(0, 0, 1).Format("2006-01-02")
+		for _, account := range j.accounts {
+			fmt.Fprintf(j.w, "%s balance %s  %s\n", date, j.names[account.ID], j.amount(account.Balance, j.accountCurrency(account.ID)))
+		}
+		return
+	}
+
+	fmt.Fprintf(j.w, "%s * Balance assertions\n", j.last.Format("2006-01-02"))
+	for _, account := range j.accounts {
+		currency := j.accountCurrency(account.ID)
+		fmt.Fprintf(j.w, "    %s  %s = %s\n", j.names[account.ID], j.amount(decimal.Zero, currency), j.amount(account.Balance, currency))
+	}
+}
+
+func (j *JournalWriter) flushPeriodically() error {
+	if j.entries%flushEvery == 0 {
+		return j.w.Flush()
+	}
+	return nil
+}
+
+func (j *JournalWriter) amount(amount decimal.Decimal, currency string) string {
+	return amount.StringFixed(iso4217.MinorUnits(currency)) + " " + currency
+}
+
+func (j *JournalWriter) line(line journalLine) string {
+	if line.costCurrency == "" {
+		return j.amount(line.amount, line.currency)
+	}
+	return j.amount(line.amount, line.currency) + " @@ " + j.amount(line.cost, line.costCurrency)
+}
+
+// accountCurrency returns the currency of an exported account, falling back
+// to the journal's currency
+func (j *JournalWriter) accountCurrency(id uuid.UUID) string {
+	if currency, ok := j.held[id]; ok {
+		return currency
+	}
+	return j.currency
+}
+
+// journalCurrencies lists the reporting currency followed by every other
+// account currency, sorted
+func journalCurrencies(currency string, accounts []*models.Account) []string {
+	currency = strings.ToUpper(currency)
+	seen := map[string]bool{currency: true}
+	var others []string
+	for _, account := range accounts {
+		c := strings.ToUpper(account.Currency)
+		if c != "" && !seen[c] {
+			seen[c] = true
+			others = append(others, c)
+		}
+	}
+	sort.Strings(others)
+	return append([]string{currency}, others...)
+}
+
+// categoryAccount names a category's account below its parent category's
+// account, so subcategories nest as in Expenses:Food:Restaurants
+func (j *JournalWriter) categoryAccount(used map[string]bool, byID map[uuid.UUID]*models.Category, category *models.Category, depth int) string {
+	if name, ok := j.categories[category.ID]; ok {
+		return name
+	}
+
+	parent := "Expenses"
+	if category.Type == models.CategoryTypeIncome {
+		parent = "Income"
+	}
+	// The depth bound stops at a parent cycle instead of recursing forever
+	if category.ParentID != nil && depth < len(byID) {
+		if p, ok := byID[*category.ParentID]; ok && p.Type == category.Type {
+			parent = j.categoryAccount(used, byID, p, depth+1)
+		}
+	}
+
+	name := j.uniqueName(used, parent, category.Name, category.ID)
+	j.categories[category.ID] = name
+	return name
+}
+
+// categoryName resolves the account of a posting, falling back to the
+// uncategorized income or expense account
+func (j *JournalWriter) categoryName(posting JournalPosting) string {
+	if posting.CategoryID != nil {
+		if name, ok := j.categories[*posting.CategoryID]; ok {
+			return name
+		}
+	}
+	if posting.Amount.IsPositive() {
+		return j.name("Income:" + journalUncategorized)
+	}
+	return j.name("Expenses:" + journalUncategorized)
+}
+
+// name adapts a full account name to the format's syntax
+func (j *JournalWriter) name(full string) string {
+	parts := strings.Split(full, ":")
+	for i, part := range parts {
+		parts[i] = j.component(part)
+	}
+	return strings.Join(parts, ":")
+}
+
+// component sanitizes one segment of an account name. Ledger and hledger
+// allow single spaces but not colons; beancount segments must start with a
+// capital letter or digit and contain only letters, digits and dashes.
+func (j *JournalWriter) component(s string) string {
+	if j.format != JournalBeancount {
+		s = strings.NewReplacer(":", " ", ";", " ").Replace(s)
+		return strings.Join(strings.Fields(s), " ")
+	}
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "-")
+}
+
+// uniqueName builds a sanitized account name below parent, adding a suffix
+// when two records would otherwise share a name
+func (j *JournalWriter) uniqueName(used map[string]bool, parent, name string, id uuid.UUID) string {
+	leaf := j.component(name)
+	if leaf == "" {
+		leaf = j.component("Unnamed " + id.String()[:8])
+	}
+	full := j.name(parent) + ":" + leaf
+	for n := 2; used[full]; n++ {
+		separator := " "
+		if j.format == JournalBeancount {
+			separator = "-"
+		}
+		full = fmt.Sprintf("%s:%s%s%d", j.name(parent), leaf, separator, n)
+	}
+	used[full] = true
+	return full
+}
+
+func escapeBeancount(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
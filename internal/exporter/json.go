@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter streams rows as a JSON array, one element per line
+type jsonWriter struct {
+	w    *bufio.Writer
+	rows int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (j *jsonWriter) Write(row *Row) error {
+	separator := ",\n"
+	if j.rows == 0 {
+		separator = "[\n"
+	}
+	if _, err := j.w.WriteString(separator); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+
+	j.rows++
+	if j.rows%flushEvery == 0 {
+		return j.w.Flush()
+	}
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	closing := "\n]\n"
+	if j.rows == 0 {
+		closing = "[]\n"
+	}
+	if _, err := j.w.WriteString(closing); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+// Abort stops the export; rows already sent cannot be taken back
+func (j *jsonWriter) Abort() {}
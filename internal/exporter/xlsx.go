@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxXLSXRows is the most rows an XLSX export may hold. A workbook can only be
+// sent once it is complete, so nothing reaches the client while the rows are
+// collected; larger exports must use CSV or JSON, which stream.
+const MaxXLSXRows = 100_000
+
+// ErrTooManyRows is returned when an XLSX export exceeds MaxXLSXRows
+var ErrTooManyRows = fmt.Errorf("xlsx exports are limited to %d rows, narrow the filters or export as csv or json", MaxXLSXRows)
+
+// xlsxWriter streams rows into a worksheet through excelize's stream writer,
+// which spills to a temporary file instead of holding the sheet in memory.
+// The workbook is written out on Close.
+type xlsxWriter struct {
+	out       io.Writer
+	file      *excelize.File
+	sheet     *excelize.StreamWriter
+	rows      int
+	dateStyle int
+	// amtStyles holds the amount number format by currency minor units
+	amtStyles map[int32]int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	x := &xlsxWriter{out: w, file: file, amtStyles: make(map[int32]int)}
+
+	var err error
+	if x.dateStyle, err = file.NewStyle(&excelize.Style{CustomNumFmt: stringPtr("yyyy-mm-dd")}); err != nil {
+		return nil, err
+	}
+	if x.sheet, err = newXLSXSheet(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return x, nil
+}
+
+// newXLSXSheet sets up the transactions worksheet with a header row
+func newXLSXSheet(file *excelize.File) (*excelize.StreamWriter, error) {
+	const name = "Transactions"
+	if err := file.SetSheetName("Sheet1", name); err != nil {
+		return nil, err
+	}
+
+	sheet, err := file.NewStreamWriter(name)
+	if err != nil {
+		return nil, err
+	}
+	for col, width := range []float64{38, 12, 24, 24, 48, 14, 9, 38, 38, 38, 24} {
+		if err := sheet.SetColWidth(col+1, col+1, width); err != nil {
+			return nil, err
+		}
+	}
+	header := make([]interface{}, len(columns))
+	for i, c := range columns {
+		header[i] = c
+	}
+	if err := sheet.SetRow("A1", header); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+func (x *xlsxWriter) Write(row *Row) error {
+	if x.rows == MaxXLSXRows {
+		return ErrTooManyRows
+	}
+	x.rows++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.rows+1)
+	if err != nil {
+		return err
+	}
+	amtStyle, err := x.amountStyle(row.Currency)
+	if err != nil {
+		return err
+	}
+	return x.sheet.SetRow(cell, []interface{}{
+		row.ID.String(),
+		excelize.Cell{StyleID: x.dateStyle, Value: row.Date},
+		row.AccountName,
+		row.CategoryName,
+		row.Description,
+		excelize.Cell{StyleID: amtStyle, Value: row.Amount.InexactFloat64()},
+		row.Currency,
+		row.AccountID.String(),
+		optionalID(row.CategoryID),
+		optionalID(row.TransferID),
+		optionalString(row.ExternalID),
+	})
+}
+
+// amountStyle returns the number format showing the minor units of currency
+func (x *xlsxWriter) amountStyle(currency string) (int, error) {
+	minorUnits := iso4217.MinorUnits(currency)
+	if style, ok := x.amtStyles[minorUnits]; ok {
+		return style, nil
+	}
+	format := "#,##0"
+	if minorUnits > 0 {
+		format += "." + strings.Repeat("0", int(minorUnits))
+	}
+	style, err := x.file.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	if err != nil {
+		return 0, err
+	}
+	x.amtStyles[minorUnits] = style
+	return style, nil
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+// Abort discards the workbook and its temporary files. Nothing has been
+// written to the output yet, so the caller can still report the error.
+func (x *xlsxWriter) Abort() {
+	x.file.Close()
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
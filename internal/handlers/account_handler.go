@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,6 +11,24 @@ import (
 	"github.com/vasujain275/expense-tracker-api/internal/services"
 )
 
+// creditCardRequest holds the credit card terms accepted when creating or
+// updating an account
+type creditCardRequest struct {
+	CreditLimit         *decimal.Decimal `json:"credit_limit"`
+	StatementClosingDay *int             `json:"statement_closing_day"`
+	PaymentDueDay       *int             `json:"payment_due_day"`
+	APR                 *decimal.Decimal `json:"apr"`
+}
+
+func (r creditCardRequest) settings() services.CreditCardSettings {
+	return services.CreditCardSettings{
+		CreditLimit:         r.CreditLimit,
+		StatementClosingDay: r.StatementClosingDay,
+		PaymentDueDay:       r.PaymentDueDay,
+		APR:                 r.APR,
+	}
+}
+
 type accountHandler struct {
 	service services.AccountService
 }
@@ -20,36 +39,39 @@ func NewAccountHandler(service services.AccountService) *accountHandler {
 
 // CreateAccount godoc
 // @Summary      Create a new account
-// @Description  Create a new account for a user
+// @Description  Create a new account for the authenticated user. Currency defaults to the user's currency and cannot be changed later. Credit card accounts may set a credit limit, a statement closing day and payment due day (1-31, together), and an APR in percent.
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        account  body      models.Account  true  "Account object"
 // @Success      201  {object}  models.Account
 // @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts [post]
 func (h *accountHandler) CreateAccount(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 	var req struct {
 		Name           string             `json:"name" binding:"required"`
-		Type           models.AccountType `json:"type" binding:"required,oneof=bank cash credit_card"`
+		Type           models.AccountType `json:"type" binding:"required,oneof=bank cash savings investment credit_card loan"`
+		Currency       string             `json:"currency" binding:"omitempty,len=3"`
 		InitialBalance decimal.Decimal    `json:"initial_balance" binding:"required"`
+		creditCardRequest
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	account, err := h.service.CreateAccount(userID, req.Name, req.Type, req.InitialBalance)
+	account, err := h.service.CreateAccount(userID, req.Name, req.Type, req.Currency, req.InitialBalance, req.settings())
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	formatAccount(account, requestLocale(c))
 	c.JSON(http.StatusCreated, account)
 }
 
@@ -59,6 +81,8 @@ func (h *accountHandler) CreateAccount(c *gin.Context) {
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        id   path      string  true  "Account ID"
 // @Success      200  {object}  models.Account
 // @Failure      400  {object}  ErrorResponse
@@ -66,36 +90,41 @@ func (h *accountHandler) CreateAccount(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts/{id} [get]
 func (h *accountHandler) GetAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
 		return
 	}
-	account, err := h.service.GetAccountByID(id)
+	account, err := h.service.GetAccountByID(userID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	formatAccount(account, requestLocale(c))
 	c.JSON(http.StatusOK, account)
 }
 
 // GetUserAccounts godoc
-// @Summary      Get all accounts for a user
-// @Description  Get all accounts associated with a user
+// @Summary      Get all accounts for the authenticated user
+// @Description  Get all accounts associated with the authenticated user
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
-// @Param        user_id  query     string  true  "User ID"
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        active_only  query     bool  false  "Only return active accounts"
 // @Success      200  {array}   models.Account
 // @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts [get]
 func (h *accountHandler) GetUserAccounts(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 	activeOnly := c.DefaultQuery("active_only", "false") == "true"
@@ -104,23 +133,33 @@ func (h *accountHandler) GetUserAccounts(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	locale := requestLocale(c)
+	for _, account := range accounts {
+		formatAccount(account, locale)
+	}
 	c.JSON(http.StatusOK, accounts)
 }
 
 // UpdateAccount godoc
 // @Summary      Update account
-// @Description  Update account information by its ID
+// @Description  Update account information by its ID. Fields that are left out, including is_active and the credit card terms, are unchanged; an account changed to another type loses its credit card terms.
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        id   path      string  true  "Account ID"
-// @Param        account  body      models.Account  true  "Account object"
+// @Param        account  body      UpdateAccountRequest  true  "Fields to update"
 // @Success      200  {object}  models.Account
 // @Failure      400  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts/{id} [put]
 func (h *accountHandler) UpdateAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -130,17 +169,19 @@ func (h *accountHandler) UpdateAccount(c *gin.Context) {
 	var req struct {
 		Name     string             `json:"name"`
 		Type     models.AccountType `json:"type"`
-		IsActive bool               `json:"is_active"`
+		IsActive *bool              `json:"is_active"`
+		creditCardRequest
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	account, err := h.service.UpdateAccount(id, req.Name, req.Type, req.IsActive)
+	account, err := h.service.UpdateAccount(userID, id, req.Name, req.Type, req.IsActive, req.settings())
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	formatAccount(account, requestLocale(c))
 	c.JSON(http.StatusOK, account)
 }
 
@@ -150,6 +191,7 @@ func (h *accountHandler) UpdateAccount(c *gin.Context) {
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Account ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  ErrorResponse
@@ -157,14 +199,18 @@ func (h *accountHandler) UpdateAccount(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts/{id} [delete]
 func (h *accountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
 		return
 	}
-	if err := h.service.DeleteAccount(id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.service.DeleteAccount(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -176,6 +222,7 @@ func (h *accountHandler) DeleteAccount(c *gin.Context) {
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Account ID"
 // @Success      200  {object}  BalanceResponse
 // @Failure      400  {object}  ErrorResponse
@@ -183,16 +230,135 @@ func (h *accountHandler) DeleteAccount(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /accounts/{id}/balance [get]
 func (h *accountHandler) GetAccountBalance(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
 		return
 	}
-	balance, err := h.service.GetAccountBalance(id)
+	balance, err := h.service.GetAccountBalance(userID, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"balance": balance})
 }
+
+// GetBalanceHistory godoc
+// @Summary      Get account balance history
+// @Description  Get the balance of an account at the end of every day, week or month in a date range, reconstructed from the opening balance and the transactions. Weeks start on Monday; the first point covers the whole period containing from, and the last one ends at to. to defaults to today and from to 30 days, 12 weeks or 12 months before it; the range may span up to ten years. balanced is false when the stored balance has drifted from the transactions, see /accounts/{id}/reconcile.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true   "Account ID"
+// @Param        from      query     string  false  "Start Date (YYYY-MM-DD)"
+// @Param        to        query     string  false  "End Date (YYYY-MM-DD)"
+// @Param        interval  query     string  false  "Interval: day, week or month"  default(day)
+// @Success      200  {object}  services.BalanceHistory
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/balance-history [get]
+func (h *accountHandler) GetBalanceHistory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	var from, to *time.Time
+	if s := c.Query("from"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from format, must be YYYY-MM-DD"})
+			return
+		}
+		from = &t
+	}
+	if s := c.Query("to"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to format, must be YYYY-MM-DD"})
+			return
+		}
+		to = &t
+	}
+	history, err := h.service.GetBalanceHistory(userID, id, from, to, c.Query("interval"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// ReconcileAccount godoc
+// @Summary      Reconcile account balance
+// @Description  Compare the stored balance with the opening balance plus the sum of transactions
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {object}  repositories.AccountReconciliation
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/reconcile [get]
+func (h *accountHandler) ReconcileAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	reconciliation, err := h.service.ReconcileAccount(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reconciliation)
+}
+
+// RecomputeBalance godoc
+// @Summary      Recompute account balance
+// @Description  Reset the stored balance to the opening balance plus the sum of transactions
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {object}  services.BalanceRecomputeResult
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/recompute [post]
+func (h *accountHandler) RecomputeBalance(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	result, err := h.service.RecomputeBalance(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
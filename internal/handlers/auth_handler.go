@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type authHandler struct {
+	service     services.AuthService
+	userService services.UserService
+}
+
+func NewAuthHandler(service services.AuthService, userService services.UserService) *authHandler {
+	return &authHandler{service: service, userService: userService}
+}
+
+// Register godoc
+// @Summary      Register a new user
+// @Description  Create a new user with a password and issue an access token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        user  body      RegisterRequest  true  "Registration details"
+// @Success      201  {object}  AuthResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/register [post]
+func (h *authHandler) Register(c *gin.Context) {
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.userService.CreateUser(req.Email, req.Name, req.Currency, req.Password)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	token, expiresAt, err := h.service.GenerateToken(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, AuthResponse{
+		TokenResponse: TokenResponse{Token: token, ExpiresAt: expiresAt},
+		User:          user,
+	})
+}
+
+// Login godoc
+// @Summary      Log in
+// @Description  Verify email and password and issue an access token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      LoginRequest  true  "Login credentials"
+// @Success      200  {object}  AuthResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      423  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/login [post]
+func (h *authHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.service.Login(req.Email, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAccountLocked):
+			c.JSON(http.StatusLocked, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	token, expiresAt, err := h.service.GenerateToken(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, AuthResponse{
+		TokenResponse: TokenResponse{Token: token, ExpiresAt: expiresAt},
+		User:          user,
+	})
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Change the authenticated user's password
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        passwords  body      ChangePasswordRequest  true  "Current and new password"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/password [put]
+func (h *authHandler) ChangePassword(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.ChangePassword(userID, req.CurrentPassword, req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RefreshToken godoc
+// @Summary      Refresh access token
+// @Description  Issue a new access token for the authenticated user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  TokenResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/refresh [post]
+func (h *authHandler) RefreshToken(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	token, expiresAt, err := h.service.GenerateToken(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, TokenResponse{Token: token, ExpiresAt: expiresAt})
+}
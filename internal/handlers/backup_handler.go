@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// maxBackupSize is the largest backup archive accepted for restore
+const maxBackupSize = 100 << 20
+
+type backupHandler struct {
+	service services.BackupService
+}
+
+func NewBackupHandler(service services.BackupService) *backupHandler {
+	return &backupHandler{service: service}
+}
+
+// BackupUser godoc
+// @Summary      Back up a user
+// @Description  Download a JSON archive of the user and everything they own: accounts, categories, transactions with splits, recurring transactions, budgets and import profiles. The archive records the schema version it was taken at and is signed by the server. It does not contain the password.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /users/{id}/backup [get]
+func (h *backupHandler) BackupUser(c *gin.Context) {
+	id, ok := ownUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	filename := "expense-tracker-backup-" + time.Now().Format("20060102") + ".json"
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if err := h.service.Backup(id, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// The download has started, so the error can only end it early
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Header("Content-Disposition", "")
+		c.Header("Content-Type", "")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
+
+// RestoreUser godoc
+// @Summary      Restore a user from a backup
+// @Description  Re-create the user and all of their data from a backup archive in a single database transaction. Only archives downloaded from this server are accepted. Archives do not contain the password, so the restored user logs in with the password sent alongside the archive. Records get new IDs unless preserve_ids is true. Archives from older schema versions are upgraded; archives from a newer schema are rejected.
+// @Tags         users
+// @Accept       mpfd
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file          formData  file    true   "Backup archive"
+// @Param        password      formData  string  true   "Password of the restored user"
+// @Param        preserve_ids  query     bool    false  "Keep the IDs from the archive"
+// @Success      201  {object}  models.User
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /users/restore [post]
+func (h *backupHandler) RestoreUser(c *gin.Context) {
+	preserveIDs := c.DefaultQuery("preserve_ids", "false") == "true"
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBackupSize+1<<20)
+
+	file, ok := backupFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	user, err := h.service.Restore(file, c.PostForm("password"), preserveIDs)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "backup archive must be at most 100 MB"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+// backupFile opens the uploaded "file" form field, writing an error response
+// if it is missing or too large
+func backupFile(c *gin.Context) (io.ReadCloser, bool) {
+	header, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || (err == nil && header.Size > maxBackupSize) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "backup archive must be at most 100 MB"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a backup file is required"})
+		return nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return file, true
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type budgetHandler struct {
+	service services.BudgetService
+}
+
+func NewBudgetHandler(service services.BudgetService) *budgetHandler {
+	return &budgetHandler{service: service}
+}
+
+// CreateBudget godoc
+// @Summary      Create a budget
+// @Description  Create a spending budget for a category or group of categories
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        budget  body      CreateBudgetRequest  true  "Budget object"
+// @Success      201  {object}  models.Budget
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets [post]
+func (h *budgetHandler) CreateBudget(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq := services.BudgetCreateRequest{
+		UserID:      userID,
+		Name:        req.Name,
+		CategoryIDs: req.CategoryIDs,
+		Amount:      req.Amount,
+		Period:      req.Period,
+		Rollover:    req.Rollover,
+	}
+	if req.StartDate != "" {
+		t, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.StartDate = &t
+	}
+	budget, err := h.service.CreateBudget(serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, budget)
+}
+
+// GetBudgets godoc
+// @Summary      Get budgets
+// @Description  Get all budgets of the authenticated user
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.Budget
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets [get]
+func (h *budgetHandler) GetBudgets(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	budgets, err := h.service.GetUserBudgets(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, budgets)
+}
+
+// GetBudget godoc
+// @Summary      Get budget by ID
+// @Description  Get budget details by its ID
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Budget ID"
+// @Success      200  {object}  models.Budget
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets/{id} [get]
+func (h *budgetHandler) GetBudget(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid budget id"})
+		return
+	}
+	budget, err := h.service.GetBudget(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, budget)
+}
+
+// UpdateBudget godoc
+// @Summary      Update budget
+// @Description  Update a budget by its ID
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string               true  "Budget ID"
+// @Param        budget  body      UpdateBudgetRequest  true  "Fields to update"
+// @Success      200  {object}  models.Budget
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets/{id} [put]
+func (h *budgetHandler) UpdateBudget(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid budget id"})
+		return
+	}
+	var req UpdateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq := services.BudgetUpdateRequest{
+		Name:        req.Name,
+		CategoryIDs: req.CategoryIDs,
+		Amount:      req.Amount,
+		Period:      req.Period,
+		Rollover:    req.Rollover,
+	}
+	if req.StartDate != nil {
+		t, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.StartDate = &t
+	}
+	budget, err := h.service.UpdateBudget(userID, id, serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, budget)
+}
+
+// DeleteBudget godoc
+// @Summary      Delete budget
+// @Description  Delete a budget by its ID
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Budget ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets/{id} [delete]
+func (h *budgetHandler) DeleteBudget(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid budget id"})
+		return
+	}
+	if err := h.service.DeleteBudget(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudgetStatus godoc
+// @Summary      Get budget status
+// @Description  Get spent, remaining and percent used for each budget in the current and past periods
+// @Tags         budgets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        periods  query     int  false  "Number of periods including the current one (1-36, default 3)"
+// @Success      200  {array}   services.BudgetStatus
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /budgets/status [get]
+func (h *budgetHandler) GetBudgetStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	periods := 0
+	if s := c.Query("periods"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid periods, must be a number"})
+			return
+		}
+		periods = n
+	}
+	statuses, err := h.service.GetBudgetStatus(userID, periods)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, statuses)
+}
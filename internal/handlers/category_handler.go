@@ -19,28 +19,30 @@ func NewCategoryHandler(service services.CategoryService) *categoryHandler {
 
 // CreateCategory godoc
 // @Summary      Create a new category
-// @Description  Create a new transaction category
+// @Description  Create a new transaction category for the current user, optionally below a parent category of the same type
 // @Tags         categories
 // @Accept       json
 // @Produce      json
-// @Param        category  body      models.Category  true  "Category object"
+// @Security     BearerAuth
+// @Param        category  body      CreateCategoryRequest  true  "Category details"
 // @Success      201  {object}  models.Category
 // @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories [post]
 func (h *categoryHandler) CreateCategory(c *gin.Context) {
-	var req struct {
-		Name  string              `json:"name" binding:"required"`
-		Type  models.CategoryType `json:"type" binding:"required,oneof=income expense"`
-		Color string              `json:"color" binding:"required"`
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
 	}
+	var req CreateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	category, err := h.service.CreateCategory(req.Name, req.Type, req.Color)
+	category, err := h.service.CreateCategory(userID, req.Name, req.Type, req.Color, req.ParentID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, category)
@@ -52,6 +54,7 @@ func (h *categoryHandler) CreateCategory(c *gin.Context) {
 // @Tags         categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Category ID"
 // @Success      200  {object}  models.Category
 // @Failure      400  {object}  ErrorResponse
@@ -59,13 +62,17 @@ func (h *categoryHandler) CreateCategory(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories/{id} [get]
 func (h *categoryHandler) GetCategory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
 		return
 	}
-	category, err := h.service.GetCategoryByID(id)
+	category, err := h.service.GetCategoryByID(userID, id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -75,15 +82,20 @@ func (h *categoryHandler) GetCategory(c *gin.Context) {
 
 // GetAllCategories godoc
 // @Summary      Get all categories
-// @Description  Get all transaction categories
+// @Description  Get all of the current user's categories. Subcategories reference their parent through parent_id.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Success      200  {array}   models.Category
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories [get]
 func (h *categoryHandler) GetAllCategories(c *gin.Context) {
-	categories, err := h.service.GetAllCategories()
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	categories, err := h.service.GetAllCategories(userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -97,15 +109,20 @@ func (h *categoryHandler) GetAllCategories(c *gin.Context) {
 // @Tags         categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        type   path      string  true  "Category Type (income/expense)"
 // @Success      200  {array}   models.Category
 // @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories/type/{type} [get]
 func (h *categoryHandler) GetCategoriesByType(c *gin.Context) {
-	typeStr := c.Query("type")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	typeStr := c.Param("type")
 	categoryType := models.CategoryType(typeStr)
-	categories, err := h.service.GetCategoriesByType(categoryType)
+	categories, err := h.service.GetCategoriesByType(userID, categoryType)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -115,35 +132,42 @@ func (h *categoryHandler) GetCategoriesByType(c *gin.Context) {
 
 // UpdateCategory godoc
 // @Summary      Update category
-// @Description  Update category information by its ID
+// @Description  Update category information by its ID. Setting parent_id moves the category, with its subcategories, below another category of the same type; remove_parent makes it top level.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Category ID"
-// @Param        category  body      models.Category  true  "Category object"
+// @Param        category  body      UpdateCategoryRequest  true  "Fields to update"
 // @Success      200  {object}  models.Category
 // @Failure      400  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories/{id} [put]
 func (h *categoryHandler) UpdateCategory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
 		return
 	}
-	var req struct {
-		Name  string `json:"name"`
-		Color string `json:"color"`
-	}
+	var req UpdateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	category, err := h.service.UpdateCategory(id, req.Name, req.Color)
+	category, err := h.service.UpdateCategory(userID, id, services.CategoryUpdateRequest{
+		Name:         req.Name,
+		Color:        req.Color,
+		ParentID:     req.ParentID,
+		RemoveParent: req.RemoveParent,
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, category)
@@ -151,26 +175,87 @@ func (h *categoryHandler) UpdateCategory(c *gin.Context) {
 
 // DeleteCategory godoc
 // @Summary      Delete category
-// @Description  Delete a category by its ID
+// @Description  Delete a category by its ID. A category still used by transactions, budgets, recurring transactions, import profiles or subcategories is refused unless reassign_to names a category of the same type to move them to; the response then reports how many records moved.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Category ID"
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Category ID"
+// @Param        reassign_to  query     string  false  "Category to move the deleted category's records to"
+// @Success      200  {object}  services.CategoryMergeResult
 // @Success      204  "No Content"
 // @Failure      400  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /categories/{id} [delete]
 func (h *categoryHandler) DeleteCategory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
 		return
 	}
-	if err := h.service.DeleteCategory(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	var reassignTo *uuid.UUID
+	if s := c.Query("reassign_to"); s != "" {
+		targetID, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reassign_to category id"})
+			return
+		}
+		reassignTo = &targetID
+	}
+
+	result, err := h.service.DeleteCategory(userID, id, reassignTo)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if result != nil {
+		c.JSON(http.StatusOK, result)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// MergeCategory godoc
+// @Summary      Merge categories
+// @Description  Move every transaction, split, budget, recurring transaction, import profile and subcategory of a category to a target category of the same type, then delete it. Everything moves in one database transaction, and the response reports how many records moved.
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                true  "Category to merge and delete"
+// @Param        request  body      MergeCategoryRequest  true  "Target category"
+// @Success      200  {object}  services.CategoryMergeResult
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /categories/{id}/merge [post]
+func (h *categoryHandler) MergeCategory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	var req MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.MergeCategory(userID, id, req.TargetID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -1,5 +1,11 @@
 package handlers
 
+import (
+	"time"
+
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+)
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error" example:"Error message"`
@@ -13,11 +19,70 @@ type BalanceResponse struct {
 // TransactionSummary represents a transaction summary response
 type TransactionSummary struct {
 	CategoryID   string `json:"category_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	CategoryName string `json:"category_name" example:"Groceries"`
-	Total        string `json:"total" example:"500.00"`
+	CategoryName string `json:"category_name" example:"Food"`
+	ParentID     string `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174001"`
+	TotalAmount  string `json:"total_amount" example:"500.00"`
+	OwnAmount    string `json:"own_amount" example:"120.00"`
+	Count        int64  `json:"count" example:"14"`
+}
+
+// CurrencyConversion represents an exchange rate a report used to convert
+// amounts from another currency
+type CurrencyConversion struct {
+	FromCurrency string `json:"from_currency" example:"EUR"`
+	ToCurrency   string `json:"to_currency" example:"USD"`
+	Rate         string `json:"rate" example:"1.0850000000"`
+	RateDate     string `json:"rate_date" example:"2026-03-31T00:00:00Z"`
+	FirstDate    string `json:"first_date" example:"2026-04-01T00:00:00Z"`
+	LastDate     string `json:"last_date" example:"2026-04-03T00:00:00Z"`
+	Count        int64  `json:"count" example:"6"`
+}
+
+// CategorySummaryResponse represents the summary by category in the user's currency
+type CategorySummaryResponse struct {
+	Currency    string               `json:"currency" example:"USD"`
+	Categories  []TransactionSummary `json:"categories"`
+	Conversions []CurrencyConversion `json:"conversions"`
+}
+
+// TagSummaryResponse represents the summary by tag in the user's currency
+type TagSummaryResponse struct {
+	Currency    string               `json:"currency" example:"USD"`
+	Tags        []TagSummary         `json:"tags"`
+	Conversions []CurrencyConversion `json:"conversions"`
+}
+
+// TagSummary represents a tag summary response
+type TagSummary struct {
+	TagID       string `json:"tag_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TagName     string `json:"tag_name" example:"vacation-2026"`
+	TotalAmount string `json:"total_amount" example:"850.00"`
+	Income      string `json:"income" example:"50.00"`
+	Expenses    string `json:"expenses" example:"800.00"`
+	Count       int64  `json:"count" example:"9"`
+}
+
+// TagTransactionsResponse reports how many transactions a bulk tag operation changed
+type TagTransactionsResponse struct {
+	Updated int64 `json:"updated" example:"12"`
 }
 
 // MonthlyTotalResponse represents a monthly total response
 type MonthlyTotalResponse struct {
-	Total string `json:"total" example:"1500.00"`
+	Currency       string               `json:"currency" example:"USD"`
+	Total          string               `json:"total" example:"1500.00"`
+	FormattedTotal string               `json:"formatted_total" example:"$1,500.00"`
+	Conversions    []CurrencyConversion `json:"conversions"`
+}
+
+// TokenResponse represents an issued access token
+type TokenResponse struct {
+	Token     string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-01-02T15:04:05Z"`
+}
+
+// AuthResponse represents a user together with an issued access token
+type AuthResponse struct {
+	TokenResponse
+	User *models.User `json:"user"`
 }
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/middleware"
+)
+
+// currentUserID returns the authenticated user's ID, writing a 401 response if it is missing
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type creditCardHandler struct {
+	service services.CreditCardService
+}
+
+func NewCreditCardHandler(service services.CreditCardService) *creditCardHandler {
+	return &creditCardHandler{service: service}
+}
+
+// GetStatements godoc
+// @Summary      Get credit card statements
+// @Description  Get the statements of a credit card account, newest first. A statement is generated for every billing cycle that closed on the statement closing day, with the balance owed, a minimum payment of 2% of the balance (at least a floor of about 25 US dollars in the card currency, such as 25 EUR, 3500 JPY or 8 KWD, and at most the balance) and the next due date. Payments credited after the closing date up to the due date count towards the statement, giving its status: paid, minimum_paid, due or overdue. Interest is not charged by statements; record interest charges as transactions.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   models.CreditCardStatement
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/statements [get]
+func (h *creditCardHandler) GetStatements(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	statements, err := h.service.GetStatements(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	locale := requestLocale(c)
+	for _, statement := range statements {
+		formatStatement(statement, locale)
+	}
+	c.JSON(http.StatusOK, statements)
+}
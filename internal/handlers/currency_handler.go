@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+)
+
+type currencyHandler struct{}
+
+func NewCurrencyHandler() *currencyHandler {
+	return &currencyHandler{}
+}
+
+// GetCurrencies godoc
+// @Summary      List supported currencies
+// @Description  List the ISO 4217 currencies users and accounts can be in, with the number of minor units amounts are rounded to and the symbol used when formatting them
+// @Tags         currencies
+// @Produce      json
+// @Success      200  {array}   iso4217.Currency
+// @Router       /currencies [get]
+func (h *currencyHandler) GetCurrencies(c *gin.Context) {
+	c.JSON(http.StatusOK, iso4217.All())
+}
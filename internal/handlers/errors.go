@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// errorStatus maps a service error to an HTTP status code, treating missing
+// records as 404, records blocked by references and duplicate names as 409
+// and everything else as a bad request
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, repositories.ErrUserNotFound),
+		errors.Is(err, repositories.ErrAccountNotFound),
+		errors.Is(err, repositories.ErrCategoryNotFound),
+		errors.Is(err, repositories.ErrTransactionNotFound),
+		errors.Is(err, repositories.ErrBudgetNotFound),
+		errors.Is(err, repositories.ErrRecurringNotFound),
+		errors.Is(err, repositories.ErrImportProfileNotFound),
+		errors.Is(err, repositories.ErrTagNotFound),
+		errors.Is(err, repositories.ErrExchangeRateNotFound),
+		errors.Is(err, repositories.ErrStatementNotFound),
+		errors.Is(err, services.ErrTransferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCategoryInUse),
+		errors.Is(err, repositories.ErrDuplicateTag):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
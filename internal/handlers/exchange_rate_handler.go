@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// maxExchangeRateFileSize caps the size of uploaded exchange rate files
+const maxExchangeRateFileSize = 2 << 20
+
+type exchangeRateHandler struct {
+	service services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(service services.ExchangeRateService) *exchangeRateHandler {
+	return &exchangeRateHandler{service: service}
+}
+
+// LoadExchangeRates godoc
+// @Summary      Load exchange rates
+// @Description  Store exchange rates used to convert amounts in reports. A rate is the price of one unit of base_currency in quote_currency on date; reports use the latest rate on or before each transaction's date, and the inverse of a rate quoted the other way round. A rate already stored for the same pair and date is replaced. Up to 10000 rates per request.
+// @Tags         exchange-rates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        rates  body      LoadExchangeRatesRequest  true  "Exchange rates"
+// @Success      200  {object}  services.ExchangeRateLoadResult
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /exchange-rates [post]
+func (h *exchangeRateHandler) LoadExchangeRates(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req LoadExchangeRatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rates := make([]services.ExchangeRateRequest, 0, len(req.Rates))
+	for _, rate := range req.Rates {
+		date, err := time.Parse("2006-01-02", rate.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format, must be YYYY-MM-DD"})
+			return
+		}
+		rates = append(rates, services.ExchangeRateRequest{
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Rate:          rate.Rate,
+			Date:          date,
+		})
+	}
+	result, err := h.service.LoadRates(userID, rates)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ImportExchangeRates godoc
+// @Summary      Import exchange rates from a file
+// @Description  Store exchange rates from a CSV file with a header row naming the date (YYYY-MM-DD), base_currency, quote_currency and rate columns. Other columns are ignored. Any invalid line rejects the whole file.
+// @Tags         exchange-rates
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "CSV file of exchange rates"
+// @Success      200  {object}  services.ExchangeRateLoadResult
+// @Failure      400  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /exchange-rates/import [post]
+func (h *exchangeRateHandler) ImportExchangeRates(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxExchangeRateFileSize+1<<20)
+	header, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || (err == nil && header.Size > maxExchangeRateFileSize) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "exchange rate file must be at most 2 MB"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "an exchange rate file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportRatesCSV(userID, file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetExchangeRates godoc
+// @Summary      List exchange rates
+// @Description  List the current user's exchange rates, newest first
+// @Tags         exchange-rates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        base_currency   query     string  false  "Base currency"
+// @Param        quote_currency  query     string  false  "Quote currency"
+// @Param        start_date      query     string  false  "Start Date (YYYY-MM-DD)"
+// @Param        end_date        query     string  false  "End Date (YYYY-MM-DD)"
+// @Param        limit           query     int     false  "Limit"  default(100)
+// @Param        offset          query     int     false  "Offset"
+// @Success      200  {array}   models.ExchangeRate
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /exchange-rates [get]
+func (h *exchangeRateHandler) GetExchangeRates(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		BaseCurrency  string  `form:"base_currency"`
+		QuoteCurrency string  `form:"quote_currency"`
+		StartDate     *string `form:"start_date"`
+		EndDate       *string `form:"end_date"`
+		Limit         int     `form:"limit,default=100" binding:"min=1,max=1000"`
+		Offset        int     `form:"offset,default=0" binding:"min=0"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq := services.ExchangeRateListRequest{
+		UserID:        userID,
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	}
+	if req.StartDate != nil {
+		t, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.StartDate = &t
+	}
+	if req.EndDate != nil {
+		t, err := time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.EndDate = &t
+	}
+	rates, err := h.service.GetRates(serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rates)
+}
+
+// DeleteExchangeRate godoc
+// @Summary      Delete exchange rate
+// @Description  Delete an exchange rate by its ID
+// @Tags         exchange-rates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Exchange rate ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /exchange-rates/{id} [delete]
+func (h *exchangeRateHandler) DeleteExchangeRate(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid exchange rate id"})
+		return
+	}
+	if err := h.service.DeleteRate(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/exporter"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type exportHandler struct {
+	service services.ExportService
+}
+
+func NewExportHandler(service services.ExportService) *exportHandler {
+	return &exportHandler{service: service}
+}
+
+// ExportJournal godoc
+// @Summary      Export a plain-text accounting journal
+// @Description  Download all accounts, categories and transactions as a ledger-cli, hledger or beancount journal. Bank, cash, savings and investment accounts become Assets, credit cards and loans Liabilities, and categories Income or Expenses. The user's currency is the commodity, opening balances are booked against equity, and every account ends with a balance assertion.
+// @Tags         export
+// @Produce      plain
+// @Security     BearerAuth
+// @Param        format  query     string  true  "Journal format"  Enums(ledger, hledger, beancount)
+// @Success      200  {file}    file
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /export/journal [get]
+func (h *exportHandler) ExportJournal(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	format, err := exporter.ParseJournalFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := format.Filename("expenses-" + time.Now().Format("20060102"))
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if err := h.service.ExportJournal(userID, format, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// The download has started, so the error can only end it early
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Header("Content-Disposition", "")
+		c.Header("Content-Type", "")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// requestLocale returns the locale response amounts are formatted in,
+// negotiated from the Accept-Language header
+func requestLocale(c *gin.Context) string {
+	return iso4217.PreferredLocale(c.GetHeader("Accept-Language"))
+}
+
+// formatAccount fills in the display strings of an account's balances
+func formatAccount(account *models.Account, locale string) {
+	account.FormattedOpeningBalance = iso4217.Format(account.OpeningBalance, account.Currency, locale)
+	account.FormattedBalance = iso4217.Format(account.Balance, account.Currency, locale)
+	if account.CreditLimit != nil {
+		account.FormattedCreditLimit = iso4217.Format(*account.CreditLimit, account.Currency, locale)
+	}
+	if account.AvailableCredit != nil {
+		account.FormattedAvailableCredit = iso4217.Format(*account.AvailableCredit, account.Currency, locale)
+	}
+}
+
+// formatStatement fills in the display strings of a credit card statement
+func formatStatement(statement *models.CreditCardStatement, locale string) {
+	statement.FormattedStatementBalance = iso4217.Format(statement.StatementBalance, statement.Currency, locale)
+	statement.FormattedMinimumPayment = iso4217.Format(statement.MinimumPayment, statement.Currency, locale)
+}
+
+// formatNetWorth fills in the display strings of a net worth's totals
+func formatNetWorth(netWorth *services.NetWorth, locale string) {
+	netWorth.FormattedAssets = iso4217.Format(netWorth.Assets, netWorth.Currency, locale)
+	netWorth.FormattedLiabilities = iso4217.Format(netWorth.Liabilities, netWorth.Currency, locale)
+	netWorth.FormattedNetWorth = iso4217.Format(netWorth.NetWorth, netWorth.Currency, locale)
+}
+
+// formatSnapshot fills in the display string of a net worth snapshot
+func formatSnapshot(snapshot *models.NetWorthSnapshot, locale string) {
+	snapshot.FormattedNetWorth = iso4217.Format(snapshot.NetWorth, snapshot.Currency, locale)
+}
+
+// formatTransaction fills in the display strings of a transaction's amounts.
+// The amount is only formatted when the account, which holds its currency,
+// was loaded with it.
+func formatTransaction(transaction *models.Transaction, locale string) {
+	if transaction.Account.Currency != "" {
+		formatAccount(&transaction.Account, locale)
+		transaction.FormattedAmount = iso4217.Format(transaction.Amount, transaction.Account.Currency, locale)
+	}
+	if transaction.OriginalAmount != nil && transaction.OriginalCurrency != nil {
+		transaction.FormattedOriginalAmount = iso4217.Format(*transaction.OriginalAmount, *transaction.OriginalCurrency, locale)
+	}
+}
+
+// formatTransfer fills in the display strings of a transfer and its legs
+func formatTransfer(transfer *services.Transfer, locale string) {
+	formatTransaction(transfer.From, locale)
+	formatTransaction(transfer.To, locale)
+	if transfer.From.Account.Currency != "" && transfer.To.Account.Currency != "" {
+		transfer.FormattedAmount = iso4217.Format(transfer.Amount, transfer.From.Account.Currency, locale)
+		transfer.FormattedToAmount = iso4217.Format(transfer.ToAmount, transfer.To.Account.Currency, locale)
+	}
+}
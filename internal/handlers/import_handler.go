@@ -0,0 +1,399 @@
+package handlers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// maxStatementSize caps the size of uploaded statement files
+const maxStatementSize = 10 << 20
+
+type importHandler struct {
+	service services.ImportService
+}
+
+func NewImportHandler(service services.ImportService) *importHandler {
+	return &importHandler{service: service}
+}
+
+// CreateImportProfile godoc
+// @Summary      Create an import profile
+// @Description  Save a CSV column mapping used to import bank statements
+// @Tags         imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        profile  body      ImportProfileRequest  true  "Import profile"
+// @Success      201  {object}  models.ImportProfile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /import-profiles [post]
+func (h *importHandler) CreateImportProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req ImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	profile, err := h.service.CreateProfile(toServiceImportProfile(userID, req))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, profile)
+}
+
+// GetImportProfiles godoc
+// @Summary      Get import profiles
+// @Description  Get all CSV import profiles of the authenticated user
+// @Tags         imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.ImportProfile
+// @Failure      500  {object}  ErrorResponse
+// @Router       /import-profiles [get]
+func (h *importHandler) GetImportProfiles(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	profiles, err := h.service.GetUserProfiles(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, profiles)
+}
+
+// GetImportProfile godoc
+// @Summary      Get import profile by ID
+// @Description  Get a CSV import profile by its ID
+// @Tags         imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Import profile ID"
+// @Success      200  {object}  models.ImportProfile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /import-profiles/{id} [get]
+func (h *importHandler) GetImportProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import profile id"})
+		return
+	}
+	profile, err := h.service.GetProfile(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateImportProfile godoc
+// @Summary      Update import profile
+// @Description  Replace the mapping of a CSV import profile
+// @Tags         imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                true  "Import profile ID"
+// @Param        profile  body      ImportProfileRequest  true  "Import profile"
+// @Success      200  {object}  models.ImportProfile
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /import-profiles/{id} [put]
+func (h *importHandler) UpdateImportProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import profile id"})
+		return
+	}
+	var req ImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	profile, err := h.service.UpdateProfile(userID, id, toServiceImportProfile(userID, req))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteImportProfile godoc
+// @Summary      Delete import profile
+// @Description  Delete a CSV import profile by its ID
+// @Tags         imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Import profile ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /import-profiles/{id} [delete]
+func (h *importHandler) DeleteImportProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import profile id"})
+		return
+	}
+	if err := h.service.DeleteProfile(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ImportCSV godoc
+// @Summary      Import a CSV statement
+// @Description  Parse a CSV bank statement with a saved import profile and flag rows that already exist on the account. With commit=true the new rows are imported atomically with a single balance adjustment; duplicates are skipped.
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Account ID"
+// @Param        file         formData  file    true   "CSV statement"
+// @Param        profile_id   formData  string  true   "Import profile ID"
+// @Param        category_id  formData  string  false  "Category for imported rows (defaults to the profile's category)"
+// @Param        commit       formData  bool    false  "Import the rows instead of previewing them"
+// @Success      200  {object}  services.ImportResult  "Preview"
+// @Success      201  {object}  services.ImportResult  "Committed"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/import/csv [post]
+func (h *importHandler) ImportCSV(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	// Leave room for the other form fields on top of the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementSize+1<<20)
+	profileID, err := uuid.Parse(c.PostForm("profile_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid profile_id"})
+		return
+	}
+	req := services.CSVImportRequest{
+		UserID:    userID,
+		AccountID: accountID,
+		ProfileID: profileID,
+		Commit:    c.PostForm("commit") == "true",
+	}
+	if s := c.PostForm("category_id"); s != "" {
+		categoryID, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category_id"})
+			return
+		}
+		req.CategoryID = &categoryID
+	}
+
+	file, ok := statementFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	req.File = file
+
+	result, err := h.service.ImportCSV(req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if result.Committed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+// ImportOFX godoc
+// @Summary      Import an OFX/QFX statement
+// @Description  Parse an OFX 1.x (SGML) or 2.x (XML) statement and flag transactions that already exist on the account by FITID. The statement's ledger balance is reconciled against the account balance. With commit=true the new transactions are imported atomically with a single balance adjustment.
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Account ID"
+// @Param        file         formData  file    true   "OFX or QFX statement"
+// @Param        category_id  formData  string  true   "Category for imported transactions"
+// @Param        commit       formData  bool    false  "Import the transactions instead of previewing them"
+// @Success      200  {object}  services.ImportResult  "Preview"
+// @Success      201  {object}  services.ImportResult  "Committed"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/import/ofx [post]
+func (h *importHandler) ImportOFX(c *gin.Context) {
+	h.importStatementFile(c, h.service.ImportOFX)
+}
+
+// ImportCAMT053 godoc
+// @Summary      Import a camt.053 statement
+// @Description  Parse an ISO 20022 camt.053 statement and flag entries that already exist on the account by bank reference. The opening and closing booked balances are reconciled against the account balance. With commit=true the new entries are imported atomically with a single balance adjustment; importing the same file again changes nothing.
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Account ID"
+// @Param        file         formData  file    true   "camt.053 XML statement"
+// @Param        category_id  formData  string  true   "Category for imported transactions"
+// @Param        commit       formData  bool    false  "Import the transactions instead of previewing them"
+// @Success      200  {object}  services.ImportResult  "Preview"
+// @Success      201  {object}  services.ImportResult  "Committed"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/import/camt053 [post]
+func (h *importHandler) ImportCAMT053(c *gin.Context) {
+	h.importStatementFile(c, h.service.ImportCAMT053)
+}
+
+// ImportMT940 godoc
+// @Summary      Import an MT940 statement
+// @Description  Parse a SWIFT MT940 statement and flag lines that already exist on the account by bank reference. The opening and closing balances are reconciled against the account balance. With commit=true the new lines are imported atomically with a single balance adjustment; importing the same file again changes nothing.
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "Account ID"
+// @Param        file         formData  file    true   "MT940 statement"
+// @Param        category_id  formData  string  true   "Category for imported transactions"
+// @Param        commit       formData  bool    false  "Import the transactions instead of previewing them"
+// @Success      200  {object}  services.ImportResult  "Preview"
+// @Success      201  {object}  services.ImportResult  "Committed"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/import/mt940 [post]
+func (h *importHandler) ImportMT940(c *gin.Context) {
+	h.importStatementFile(c, h.service.ImportMT940)
+}
+
+// importStatementFile handles the upload of a self-describing statement file
+// and responds with the import result
+func (h *importHandler) importStatementFile(c *gin.Context, importFn func(services.StatementImportRequest) (*services.ImportResult, error)) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementSize+1<<20)
+	categoryID, err := uuid.Parse(c.PostForm("category_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category_id"})
+		return
+	}
+
+	file, ok := statementFile(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	result, err := importFn(services.StatementImportRequest{
+		UserID:     userID,
+		AccountID:  accountID,
+		CategoryID: categoryID,
+		File:       file,
+		Commit:     c.PostForm("commit") == "true",
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if result.Committed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+// statementFile opens the uploaded "file" form field, writing an error
+// response if it is missing or too large
+func statementFile(c *gin.Context) (multipart.File, bool) {
+	header, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "statement file must be at most 10 MB"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a statement file is required"})
+		return nil, false
+	}
+	if header.Size > maxStatementSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "statement file must be at most 10 MB"})
+		return nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return file, true
+}
+
+// toServiceImportProfile converts an import profile request body to a service request
+func toServiceImportProfile(userID uuid.UUID, req ImportProfileRequest) services.ImportProfileRequest {
+	return services.ImportProfileRequest{
+		UserID:            userID,
+		Name:              req.Name,
+		Delimiter:         req.Delimiter,
+		HasHeader:         req.HasHeader,
+		SkipRows:          req.SkipRows,
+		DateColumn:        req.DateColumn,
+		AmountColumn:      req.AmountColumn,
+		DebitColumn:       req.DebitColumn,
+		CreditColumn:      req.CreditColumn,
+		DescriptionColumn: req.DescriptionColumn,
+		ExternalIDColumn:  req.ExternalIDColumn,
+		DateFormat:        req.DateFormat,
+		DecimalSeparator:  req.DecimalSeparator,
+		SignConvention:    req.SignConvention,
+		DefaultCategoryID: req.DefaultCategoryID,
+	}
+}
@@ -7,14 +7,32 @@ import (
 	"github.com/vasujain275/expense-tracker-api/internal/models"
 )
 
+// AuthHandler interface defines methods for authentication-related HTTP handlers
+type AuthHandler interface {
+	Register(c *gin.Context)
+	Login(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	RefreshToken(c *gin.Context)
+}
+
+// CurrencyHandler interface defines methods for the currency registry HTTP handlers
+type CurrencyHandler interface {
+	GetCurrencies(c *gin.Context)
+}
+
 // UserHandler interface defines methods for user-related HTTP handlers
 type UserHandler interface {
-	CreateUser(c *gin.Context)
 	GetUser(c *gin.Context)
 	UpdateUser(c *gin.Context)
 	DeleteUser(c *gin.Context)
 }
 
+// NetWorthHandler interface defines methods for net worth HTTP handlers
+type NetWorthHandler interface {
+	GetNetWorth(c *gin.Context)
+	GetNetWorthHistory(c *gin.Context)
+}
+
 // AccountHandler interface defines methods for account-related HTTP handlers
 type AccountHandler interface {
 	CreateAccount(c *gin.Context)
@@ -23,6 +41,14 @@ type AccountHandler interface {
 	UpdateAccount(c *gin.Context)
 	DeleteAccount(c *gin.Context)
 	GetAccountBalance(c *gin.Context)
+	GetBalanceHistory(c *gin.Context)
+	ReconcileAccount(c *gin.Context)
+	RecomputeBalance(c *gin.Context)
+}
+
+// CreditCardHandler interface defines methods for credit card HTTP handlers
+type CreditCardHandler interface {
+	GetStatements(c *gin.Context)
 }
 
 // CategoryHandler interface defines methods for category-related HTTP handlers
@@ -33,6 +59,7 @@ type CategoryHandler interface {
 	GetCategoriesByType(c *gin.Context)
 	UpdateCategory(c *gin.Context)
 	DeleteCategory(c *gin.Context)
+	MergeCategory(c *gin.Context)
 }
 
 // TransactionHandler interface defines methods for transaction-related HTTP handlers
@@ -40,48 +67,189 @@ type TransactionHandler interface {
 	CreateTransaction(c *gin.Context)
 	GetTransaction(c *gin.Context)
 	GetTransactions(c *gin.Context)
+	ExportTransactions(c *gin.Context)
 	UpdateTransaction(c *gin.Context)
 	DeleteTransaction(c *gin.Context)
 	GetTransactionSummary(c *gin.Context)
+	GetTagSummary(c *gin.Context)
 	GetMonthlyTotal(c *gin.Context)
 }
 
+// TagHandler interface defines methods for tag-related HTTP handlers
+type TagHandler interface {
+	CreateTag(c *gin.Context)
+	GetTag(c *gin.Context)
+	GetTags(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+	TagTransactions(c *gin.Context)
+	UntagTransactions(c *gin.Context)
+}
+
+// ExchangeRateHandler interface defines methods for exchange rate HTTP handlers
+type ExchangeRateHandler interface {
+	LoadExchangeRates(c *gin.Context)
+	ImportExchangeRates(c *gin.Context)
+	GetExchangeRates(c *gin.Context)
+	DeleteExchangeRate(c *gin.Context)
+}
+
+// TransferHandler interface defines methods for transfer-related HTTP handlers
+type TransferHandler interface {
+	CreateTransfer(c *gin.Context)
+	GetTransfer(c *gin.Context)
+	DeleteTransfer(c *gin.Context)
+}
+
+// BudgetHandler interface defines methods for budget-related HTTP handlers
+type BudgetHandler interface {
+	CreateBudget(c *gin.Context)
+	GetBudget(c *gin.Context)
+	GetBudgets(c *gin.Context)
+	UpdateBudget(c *gin.Context)
+	DeleteBudget(c *gin.Context)
+	GetBudgetStatus(c *gin.Context)
+}
+
+// RecurringHandler interface defines methods for recurring transaction HTTP handlers
+type RecurringHandler interface {
+	CreateRecurring(c *gin.Context)
+	GetRecurring(c *gin.Context)
+	GetRecurringTransactions(c *gin.Context)
+	UpdateRecurring(c *gin.Context)
+	DeleteRecurring(c *gin.Context)
+	PreviewOccurrences(c *gin.Context)
+	SkipOccurrence(c *gin.Context)
+	ModifyOccurrence(c *gin.Context)
+	RestoreOccurrence(c *gin.Context)
+}
+
+// ImportHandler interface defines methods for statement import HTTP handlers
+type ImportHandler interface {
+	CreateImportProfile(c *gin.Context)
+	GetImportProfile(c *gin.Context)
+	GetImportProfiles(c *gin.Context)
+	UpdateImportProfile(c *gin.Context)
+	DeleteImportProfile(c *gin.Context)
+	ImportCSV(c *gin.Context)
+	ImportOFX(c *gin.Context)
+	ImportCAMT053(c *gin.Context)
+	ImportMT940(c *gin.Context)
+}
+
+// ExportHandler interface defines methods for plain-text accounting export HTTP handlers
+type ExportHandler interface {
+	ExportJournal(c *gin.Context)
+}
+
+// BackupHandler interface defines methods for user backup and restore HTTP handlers
+type BackupHandler interface {
+	BackupUser(c *gin.Context)
+	RestoreUser(c *gin.Context)
+}
+
 // Request/Response structs for handlers
 
-// CreateUserRequest represents a request to create a user
-type CreateUserRequest struct {
+// RegisterRequest represents a request to register a user with a password
+type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Name     string `json:"name" binding:"required"`
-	Currency string `json:"currency" binding:"required,oneof=USD EUR GBP JPY"`
+	Currency string `json:"currency" binding:"required,len=3"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// LoginRequest represents a request to log in with email and password
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ChangePasswordRequest represents a request to change the current user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
 }
 
 // CreateAccountRequest represents a request to create an account
 type CreateAccountRequest struct {
 	Name           string             `json:"name" binding:"required"`
-	Type           models.AccountType `json:"type" binding:"required,oneof=bank cash credit_card"`
+	Type           models.AccountType `json:"type" binding:"required,oneof=bank cash savings investment credit_card loan"`
+	Currency       string             `json:"currency,omitempty" binding:"omitempty,len=3" example:"EUR"`
 	InitialBalance decimal.Decimal    `json:"initial_balance" binding:"required"`
+
+	// Credit card terms, only allowed on credit card accounts
+	CreditLimit         *decimal.Decimal `json:"credit_limit,omitempty" example:"5000"`
+	StatementClosingDay *int             `json:"statement_closing_day,omitempty" example:"25"`
+	PaymentDueDay       *int             `json:"payment_due_day,omitempty" example:"20"`
+	APR                 *decimal.Decimal `json:"apr,omitempty" example:"19.99"`
 }
 
 // UpdateAccountRequest represents a request to update an account
 type UpdateAccountRequest struct {
 	Name     string             `json:"name,omitempty"`
-	Type     models.AccountType `json:"type,omitempty" binding:"omitempty,oneof=bank cash credit_card"`
-	IsActive bool               `json:"is_active,omitempty"`
+	Type     models.AccountType `json:"type,omitempty" binding:"omitempty,oneof=bank cash savings investment credit_card loan"`
+	IsActive *bool              `json:"is_active,omitempty"`
+
+	// Credit card terms, unchanged when left out
+	CreditLimit         *decimal.Decimal `json:"credit_limit,omitempty" example:"5000"`
+	StatementClosingDay *int             `json:"statement_closing_day,omitempty" example:"25"`
+	PaymentDueDay       *int             `json:"payment_due_day,omitempty" example:"20"`
+	APR                 *decimal.Decimal `json:"apr,omitempty" example:"19.99"`
 }
 
 // CreateCategoryRequest represents a request to create a category
 type CreateCategoryRequest struct {
-	Name  string              `json:"name" binding:"required"`
-	Type  models.CategoryType `json:"type" binding:"required,oneof=income expense"`
-	Color string              `json:"color" binding:"required,hexcolor"`
+	Name     string              `json:"name" binding:"required"`
+	Type     models.CategoryType `json:"type" binding:"required,oneof=income expense"`
+	Color    string              `json:"color" binding:"required,hexcolor"`
+	ParentID *uuid.UUID          `json:"parent_id,omitempty"`
 }
 
 // UpdateCategoryRequest represents a request to update a category
 type UpdateCategoryRequest struct {
-	Name  string `json:"name,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Color        string     `json:"color,omitempty" binding:"omitempty,hexcolor"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	RemoveParent bool       `json:"remove_parent,omitempty"`
+}
+
+// MergeCategoryRequest represents a request to merge a category into another
+type MergeCategoryRequest struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+}
+
+// CreateTagRequest represents a request to create a tag
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50" example:"vacation-2026"`
 	Color string `json:"color,omitempty" binding:"omitempty,hexcolor"`
 }
 
+// UpdateTagRequest represents a request to rename or recolor a tag
+type UpdateTagRequest struct {
+	Name  string `json:"name,omitempty" binding:"omitempty,max=50"`
+	Color string `json:"color,omitempty" binding:"omitempty,hexcolor"`
+}
+
+// TagTransactionsRequest represents a request to add a tag to, or remove it
+// from, transactions
+type TagTransactionsRequest struct {
+	TransactionIDs []uuid.UUID `json:"transaction_ids" binding:"required,min=1,max=1000"`
+}
+
+// ExchangeRateRequest represents one exchange rate: one unit of BaseCurrency
+// costs Rate units of QuoteCurrency on Date
+type ExchangeRateRequest struct {
+	BaseCurrency  string          `json:"base_currency" binding:"required,len=3" example:"EUR"`
+	QuoteCurrency string          `json:"quote_currency" binding:"required,len=3" example:"USD"`
+	Rate          decimal.Decimal `json:"rate" binding:"required" swaggertype:"string" example:"1.085"`
+	Date          string          `json:"date" binding:"required,datetime=2006-01-02" example:"2026-03-31"`
+}
+
+// LoadExchangeRatesRequest represents a request to store exchange rates
+type LoadExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates" binding:"required,min=1,max=10000,dive"`
+}
+
 // CreateTransactionRequest represents a request to create a transaction
 type CreateTransactionRequest struct {
 	AccountID   uuid.UUID       `json:"account_id" binding:"required"`
@@ -111,3 +279,88 @@ type TransactionListRequest struct {
 	Limit      int              `json:"limit" binding:"min=1,max=100"`
 	Offset     int              `json:"offset" binding:"min=0"`
 }
+
+// CreateTransferRequest represents a request to move money between two accounts
+type CreateTransferRequest struct {
+	FromAccountID uuid.UUID        `json:"from_account_id" binding:"required"`
+	ToAccountID   uuid.UUID        `json:"to_account_id" binding:"required"`
+	Amount        decimal.Decimal  `json:"amount" binding:"required"`
+	ToAmount      *decimal.Decimal `json:"to_amount,omitempty"`
+	Description   string           `json:"description" binding:"required"`
+	Date          string           `json:"date" binding:"required"`
+}
+
+// TransactionSplitRequest represents one category allocation of a split transaction
+type TransactionSplitRequest struct {
+	CategoryID uuid.UUID       `json:"category_id" binding:"required"`
+	Amount     decimal.Decimal `json:"amount" binding:"required"`
+	Memo       string          `json:"memo"`
+}
+
+// CreateBudgetRequest represents a request to create a budget
+type CreateBudgetRequest struct {
+	Name        string              `json:"name"`
+	CategoryIDs []uuid.UUID         `json:"category_ids" binding:"required,min=1"`
+	Amount      decimal.Decimal     `json:"amount" binding:"required"`
+	Period      models.BudgetPeriod `json:"period" binding:"required,oneof=weekly monthly yearly"`
+	Rollover    bool                `json:"rollover"`
+	StartDate   string              `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateBudgetRequest represents a request to update a budget
+type UpdateBudgetRequest struct {
+	Name        *string              `json:"name,omitempty"`
+	CategoryIDs *[]uuid.UUID         `json:"category_ids,omitempty" binding:"omitempty,min=1"`
+	Amount      *decimal.Decimal     `json:"amount,omitempty"`
+	Period      *models.BudgetPeriod `json:"period,omitempty" binding:"omitempty,oneof=weekly monthly yearly"`
+	Rollover    *bool                `json:"rollover,omitempty"`
+	StartDate   *string              `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// CreateRecurringRequest represents a request to create a recurring transaction
+type CreateRecurringRequest struct {
+	AccountID   uuid.UUID       `json:"account_id" binding:"required"`
+	CategoryID  uuid.UUID       `json:"category_id" binding:"required"`
+	Amount      decimal.Decimal `json:"amount" binding:"required"`
+	Description string          `json:"description" binding:"required"`
+	RRule       string          `json:"rrule" binding:"required" example:"FREQ=MONTHLY;BYMONTHDAY=1"`
+	StartDate   string          `json:"start_date" binding:"required,datetime=2006-01-02"`
+}
+
+// UpdateRecurringRequest represents a request to update a recurring transaction
+type UpdateRecurringRequest struct {
+	AccountID   *uuid.UUID       `json:"account_id,omitempty"`
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
+	Amount      *decimal.Decimal `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	RRule       *string          `json:"rrule,omitempty"`
+	StartDate   *string          `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	IsActive    *bool            `json:"is_active,omitempty"`
+}
+
+// ModifyOccurrenceRequest represents a request to override a single occurrence
+type ModifyOccurrenceRequest struct {
+	Amount      *decimal.Decimal `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
+	Date        *string          `json:"date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ImportProfileRequest represents a request to create or replace a CSV import
+// profile. Columns are header names, or 1-based positions without a header row.
+type ImportProfileRequest struct {
+	Name              string    `json:"name" binding:"required"`
+	Delimiter         string    `json:"delimiter" example:","`
+	HasHeader         bool      `json:"has_header"`
+	SkipRows          int       `json:"skip_rows" binding:"min=0"`
+	DateColumn        string    `json:"date_column" binding:"required" example:"Date"`
+	AmountColumn      string    `json:"amount_column" example:"Amount"`
+	DebitColumn       string    `json:"debit_column"`
+	CreditColumn      string    `json:"credit_column"`
+	DescriptionColumn string    `json:"description_column" binding:"required" example:"Description"`
+	ExternalIDColumn  string    `json:"external_id_column"`
+	DateFormat        string    `json:"date_format" binding:"required" example:"DD/MM/YYYY"`
+	DecimalSeparator  string    `json:"decimal_separator" binding:"omitempty,oneof=. ," example:"."`
+	SignConvention    string    `json:"sign_convention" binding:"omitempty,oneof=negative_is_debit positive_is_debit"`
+	DefaultCategoryID uuid.UUID `json:"default_category_id" binding:"required"`
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type netWorthHandler struct {
+	service services.NetWorthService
+}
+
+func NewNetWorthHandler(service services.NetWorthService) *netWorthHandler {
+	return &netWorthHandler{service: service}
+}
+
+// GetNetWorth godoc
+// @Summary      Get net worth
+// @Description  Get the user's current net worth over their active accounts, in the user's currency. Bank, cash, savings and investment accounts are assets; credit cards and loans are liabilities, reported as the amount owed. Accounts in another currency are converted with the latest exchange rate, and the request fails when one is missing.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  services.NetWorth
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /users/{id}/net-worth [get]
+func (h *netWorthHandler) GetNetWorth(c *gin.Context) {
+	id, ok := ownUserIDParam(c)
+	if !ok {
+		return
+	}
+	netWorth, err := h.service.GetNetWorth(id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	formatNetWorth(netWorth, requestLocale(c))
+	c.JSON(http.StatusOK, netWorth)
+}
+
+// GetNetWorthHistory godoc
+// @Summary      Get net worth history
+// @Description  Get the user's daily net worth snapshots, oldest first, for charting. A snapshot is taken every day for users with an active account, in the currency the user had that day.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        id          path      string  true   "User ID"
+// @Param        start_date  query     string  false  "Start Date (YYYY-MM-DD)"
+// @Param        end_date    query     string  false  "End Date (YYYY-MM-DD)"
+// @Success      200  {array}   models.NetWorthSnapshot
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /users/{id}/net-worth/history [get]
+func (h *netWorthHandler) GetNetWorthHistory(c *gin.Context) {
+	id, ok := ownUserIDParam(c)
+	if !ok {
+		return
+	}
+	var startDate, endDate *time.Time
+	if s := c.Query("start_date"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		startDate = &t
+	}
+	if s := c.Query("end_date"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, must be YYYY-MM-DD"})
+			return
+		}
+		endDate = &t
+	}
+	snapshots, err := h.service.GetHistory(id, startDate, endDate)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	locale := requestLocale(c)
+	for _, snapshot := range snapshots {
+		formatSnapshot(snapshot, locale)
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
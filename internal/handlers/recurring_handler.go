@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type recurringHandler struct {
+	service services.RecurringService
+}
+
+func NewRecurringHandler(service services.RecurringService) *recurringHandler {
+	return &recurringHandler{service: service}
+}
+
+// CreateRecurring godoc
+// @Summary      Create a recurring transaction
+// @Description  Create a transaction template generated on every occurrence of an RRULE schedule (FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYSETPOS, COUNT, UNTIL). A past start date backfills the missed occurrences.
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        recurring  body      CreateRecurringRequest  true  "Recurring transaction object"
+// @Success      201  {object}  models.RecurringTransaction
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions [post]
+func (h *recurringHandler) CreateRecurring(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateRecurringRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+		return
+	}
+	recurring, err := h.service.CreateRecurring(services.RecurringCreateRequest{
+		UserID:      userID,
+		AccountID:   req.AccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		RRule:       req.RRule,
+		StartDate:   startDate,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, recurring)
+}
+
+// GetRecurringTransactions godoc
+// @Summary      Get recurring transactions
+// @Description  Get all recurring transactions of the authenticated user
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.RecurringTransaction
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions [get]
+func (h *recurringHandler) GetRecurringTransactions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	recurring, err := h.service.GetUserRecurring(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, recurring)
+}
+
+// GetRecurring godoc
+// @Summary      Get recurring transaction by ID
+// @Description  Get a recurring transaction and its occurrence exceptions
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Recurring transaction ID"
+// @Success      200  {object}  models.RecurringTransaction
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id} [get]
+func (h *recurringHandler) GetRecurring(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	recurring, err := h.service.GetRecurring(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, recurring)
+}
+
+// UpdateRecurring godoc
+// @Summary      Update recurring transaction
+// @Description  Update a recurring transaction. Schedule changes only affect occurrences that have not been generated yet.
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string                  true  "Recurring transaction ID"
+// @Param        recurring  body      UpdateRecurringRequest  true  "Fields to update"
+// @Success      200  {object}  models.RecurringTransaction
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id} [put]
+func (h *recurringHandler) UpdateRecurring(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	var req UpdateRecurringRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq := services.RecurringUpdateRequest{
+		AccountID:   req.AccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		RRule:       req.RRule,
+		IsActive:    req.IsActive,
+	}
+	if req.StartDate != nil {
+		t, err := time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.StartDate = &t
+	}
+	recurring, err := h.service.UpdateRecurring(userID, id, serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, recurring)
+}
+
+// DeleteRecurring godoc
+// @Summary      Delete recurring transaction
+// @Description  Delete a recurring transaction; transactions it already generated are kept
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Recurring transaction ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id} [delete]
+func (h *recurringHandler) DeleteRecurring(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteRecurring(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewOccurrences godoc
+// @Summary      Preview upcoming occurrences
+// @Description  List the next occurrences that have not been generated yet, with skips and modifications applied
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Recurring transaction ID"
+// @Param        count  query     int     false  "Number of occurrences (1-100, default 10)"
+// @Success      200  {array}   services.Occurrence
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id}/occurrences [get]
+func (h *recurringHandler) PreviewOccurrences(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	count := 0
+	if s := c.Query("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid count, must be a number"})
+			return
+		}
+		count = n
+	}
+	occurrences, err := h.service.PreviewOccurrences(userID, id, count)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, occurrences)
+}
+
+// SkipOccurrence godoc
+// @Summary      Skip an occurrence
+// @Description  Prevent a single upcoming occurrence from generating a transaction
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string  true  "Recurring transaction ID"
+// @Param        date  path      string  true  "Occurrence date (YYYY-MM-DD)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id}/occurrences/{date}/skip [post]
+func (h *recurringHandler) SkipOccurrence(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	date, ok := occurrenceDateParam(c)
+	if !ok {
+		return
+	}
+	if err := h.service.SkipOccurrence(userID, id, date); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ModifyOccurrence godoc
+// @Summary      Modify an occurrence
+// @Description  Change the amount, description, category or date of a single upcoming occurrence
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string                   true  "Recurring transaction ID"
+// @Param        date        path      string                   true  "Occurrence date (YYYY-MM-DD)"
+// @Param        occurrence  body      ModifyOccurrenceRequest  true  "Fields to override"
+// @Success      200  {object}  models.RecurringException
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id}/occurrences/{date} [put]
+func (h *recurringHandler) ModifyOccurrence(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	date, ok := occurrenceDateParam(c)
+	if !ok {
+		return
+	}
+	var req ModifyOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq := services.OccurrenceModifyRequest{
+		Amount:      req.Amount,
+		Description: req.Description,
+		CategoryID:  req.CategoryID,
+	}
+	if req.Date != nil {
+		t, err := time.Parse("2006-01-02", *req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format, must be YYYY-MM-DD"})
+			return
+		}
+		serviceReq.Date = &t
+	}
+	exception, err := h.service.ModifyOccurrence(userID, id, date, serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, exception)
+}
+
+// RestoreOccurrence godoc
+// @Summary      Restore an occurrence
+// @Description  Remove a skip or modification from a single upcoming occurrence
+// @Tags         recurring-transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string  true  "Recurring transaction ID"
+// @Param        date  path      string  true  "Occurrence date (YYYY-MM-DD)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /recurring-transactions/{id}/occurrences/{date} [delete]
+func (h *recurringHandler) RestoreOccurrence(c *gin.Context) {
+	userID, id, ok := recurringParams(c)
+	if !ok {
+		return
+	}
+	date, ok := occurrenceDateParam(c)
+	if !ok {
+		return
+	}
+	if err := h.service.RestoreOccurrence(userID, id, date); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// recurringParams returns the authenticated user and the recurring transaction
+// ID from the path, writing an error response if either is missing or invalid
+func recurringParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurring transaction id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+// occurrenceDateParam parses the occurrence date from the path
+func occurrenceDateParam(c *gin.Context) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid occurrence date, must be YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return date, true
+}
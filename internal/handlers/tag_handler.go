@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type tagHandler struct {
+	service services.TagService
+}
+
+func NewTagHandler(service services.TagService) *tagHandler {
+	return &tagHandler{service: service}
+}
+
+// CreateTag godoc
+// @Summary      Create a new tag
+// @Description  Create a tag for the current user. Tag names are unique per user regardless of case and cannot contain commas.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tag  body      CreateTagRequest  true  "Tag details"
+// @Success      201  {object}  models.Tag
+// @Failure      400  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags [post]
+func (h *tagHandler) CreateTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tag, err := h.service.CreateTag(userID, req.Name, req.Color)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, tag)
+}
+
+// GetTag godoc
+// @Summary      Get tag by ID
+// @Description  Get tag details by its ID
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Tag ID"
+// @Success      200  {object}  models.Tag
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags/{id} [get]
+func (h *tagHandler) GetTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+	tag, err := h.service.GetTagByID(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tag)
+}
+
+// GetTags godoc
+// @Summary      Get all tags
+// @Description  Get all of the current user's tags, ordered by name
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.Tag
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags [get]
+func (h *tagHandler) GetTags(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	tags, err := h.service.GetUserTags(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+// UpdateTag godoc
+// @Summary      Update tag
+// @Description  Rename or recolor a tag. The change applies to every transaction carrying the tag.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string            true  "Tag ID"
+// @Param        tag  body      UpdateTagRequest  true  "Fields to update"
+// @Success      200  {object}  models.Tag
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags/{id} [put]
+func (h *tagHandler) UpdateTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tag, err := h.service.UpdateTag(userID, id, req.Name, req.Color)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag godoc
+// @Summary      Delete tag
+// @Description  Delete a tag and remove it from every transaction. The transactions themselves are kept.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Tag ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags/{id} [delete]
+func (h *tagHandler) DeleteTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+	if err := h.service.DeleteTag(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TagTransactions godoc
+// @Summary      Tag transactions
+// @Description  Add a tag to up to 1000 transactions at once. Transactions that already carry the tag are left as they are; updated counts the newly tagged ones.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                  true  "Tag ID"
+// @Param        request  body      TagTransactionsRequest  true  "Transactions to tag"
+// @Success      200  {object}  TagTransactionsResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags/{id}/transactions [post]
+func (h *tagHandler) TagTransactions(c *gin.Context) {
+	h.bulkTag(c, h.service.TagTransactions)
+}
+
+// UntagTransactions godoc
+// @Summary      Untag transactions
+// @Description  Remove a tag from up to 1000 transactions at once; updated counts the transactions that carried it
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                  true  "Tag ID"
+// @Param        request  body      TagTransactionsRequest  true  "Transactions to untag"
+// @Success      200  {object}  TagTransactionsResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /tags/{id}/transactions [delete]
+func (h *tagHandler) UntagTransactions(c *gin.Context) {
+	h.bulkTag(c, h.service.UntagTransactions)
+}
+
+// bulkTag binds a bulk tag request and applies op to it
+func (h *tagHandler) bulkTag(c *gin.Context, op func(userID, id uuid.UUID, transactionIDs []uuid.UUID) (int64, error)) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+	var req TagTransactionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := op(userID, id, req.TransactionIDs)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, TagTransactionsResponse{Updated: updated})
+}
@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/exporter"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 	"github.com/vasujain275/expense-tracker-api/internal/services"
 )
 
@@ -24,19 +28,28 @@ func NewTransactionHandler(service services.TransactionService) *transactionHand
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        transaction  body      models.Transaction  true  "Transaction object"
 // @Success      201  {object}  models.Transaction
 // @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions [post]
 func (h *transactionHandler) CreateTransaction(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		UserID      uuid.UUID       `json:"user_id" binding:"required"`
-		AccountID   uuid.UUID       `json:"account_id" binding:"required"`
-		CategoryID  uuid.UUID       `json:"category_id" binding:"required"`
-		Amount      decimal.Decimal `json:"amount" binding:"required"`
-		Description string          `json:"description" binding:"required"`
-		Date        string          `json:"date" binding:"required"`
+		AccountID   uuid.UUID                 `json:"account_id" binding:"required"`
+		CategoryID  uuid.UUID                 `json:"category_id"`
+		Amount      decimal.Decimal           `json:"amount" binding:"required"`
+		Description string                    `json:"description" binding:"required"`
+		Date        string                    `json:"date" binding:"required"`
+		Splits      []TransactionSplitRequest `json:"splits" binding:"omitempty,dive"`
+
+		OriginalAmount   *decimal.Decimal `json:"original_amount"`
+		OriginalCurrency *string          `json:"original_currency"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -48,18 +61,23 @@ func (h *transactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 	serviceReq := services.TransactionCreateRequest{
-		UserID:      req.UserID,
+		UserID:      userID,
 		AccountID:   req.AccountID,
 		CategoryID:  req.CategoryID,
 		Amount:      req.Amount,
 		Description: req.Description,
 		Date:        parsedDate,
+		Splits:      toServiceSplits(req.Splits),
+
+		OriginalAmount:   req.OriginalAmount,
+		OriginalCurrency: req.OriginalCurrency,
 	}
 	transaction, err := h.service.CreateTransaction(serviceReq)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	formatTransaction(transaction, requestLocale(c))
 	c.JSON(http.StatusCreated, transaction)
 }
 
@@ -69,6 +87,8 @@ func (h *transactionHandler) CreateTransaction(c *gin.Context) {
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        id   path      string  true  "Transaction ID"
 // @Success      200  {object}  models.Transaction
 // @Failure      400  {object}  ErrorResponse
@@ -76,17 +96,22 @@ func (h *transactionHandler) CreateTransaction(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions/{id} [get]
 func (h *transactionHandler) GetTransaction(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
 		return
 	}
-	transaction, err := h.service.GetTransactionByID(id)
+	transaction, err := h.service.GetTransactionByID(userID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	formatTransaction(transaction, requestLocale(c))
 	c.JSON(http.StatusOK, transaction)
 }
 
@@ -96,13 +121,16 @@ func (h *transactionHandler) GetTransaction(c *gin.Context) {
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
-// @Param        user_id     query     string  false  "User ID"
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        account_id  query     string  false  "Account ID"
 // @Param        category_id query     string  false  "Category ID"
 // @Param        start_date  query     string  false  "Start Date (YYYY-MM-DD)"
 // @Param        end_date    query     string  false  "End Date (YYYY-MM-DD)"
 // @Param        min_amount  query     number  false  "Minimum Amount"
 // @Param        max_amount  query     number  false  "Maximum Amount"
+// @Param        tags        query     string  false  "Comma-separated tag names"
+// @Param        tag_mode    query     string  false  "Match transactions with any or all of the tags"  Enums(any, all)  default(any)
 // @Param        limit       query     int     false  "Limit"
 // @Param        offset      query     int     false  "Offset"
 // @Success      200  {array}   models.Transaction
@@ -110,27 +138,107 @@ func (h *transactionHandler) GetTransaction(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions [get]
 func (h *transactionHandler) GetTransactions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	serviceReq, ok := bindTransactionFilters(c, userID, 20)
+	if !ok {
+		return
+	}
+	transactions, count, err := h.service.GetTransactions(serviceReq)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	locale := requestLocale(c)
+	for _, transaction := range transactions {
+		formatTransaction(transaction, locale)
+	}
+	c.JSON(http.StatusOK, gin.H{"transactions": transactions, "count": count})
+}
+
+// ExportTransactions godoc
+// @Summary      Export transactions
+// @Description  Download every transaction matching the same filters as the list endpoint as CSV, XLSX or JSON, including account and category names. CSV and JSON rows are streamed, so those exports are not capped. An XLSX workbook is only sent once complete and is limited to 100,000 rows; larger XLSX exports fail with 400 and should use CSV or JSON. Limit and offset are optional.
+// @Tags         transactions
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        format      query     string  false  "Export format"  Enums(csv, xlsx, json)  default(csv)
+// @Param        account_id  query     string  false  "Account ID"
+// @Param        category_id query     string  false  "Category ID"
+// @Param        start_date  query     string  false  "Start Date (YYYY-MM-DD)"
+// @Param        end_date    query     string  false  "End Date (YYYY-MM-DD)"
+// @Param        min_amount  query     number  false  "Minimum Amount"
+// @Param        max_amount  query     number  false  "Maximum Amount"
+// @Param        tags        query     string  false  "Comma-separated tag names"
+// @Param        tag_mode    query     string  false  "Match transactions with any or all of the tags"  Enums(any, all)  default(any)
+// @Param        limit       query     int     false  "Limit"
+// @Param        offset      query     int     false  "Offset"
+// @Success      200  {file}    file
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transactions/export [get]
+func (h *transactionHandler) ExportTransactions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	format, err := exporter.ParseFormat(c.DefaultQuery("format", string(exporter.FormatCSV)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	serviceReq, ok := bindTransactionFilters(c, userID, 0)
+	if !ok {
+		return
+	}
+
+	filename := format.Filename("transactions-" + time.Now().Format("20060102"))
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if err := h.service.ExportTransactions(serviceReq, format, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// The download has started, so the error can only end it early
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Header("Content-Disposition", "")
+		c.Header("Content-Type", "")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
+
+// bindTransactionFilters reads the transaction filter query parameters shared by
+// listing and exporting, writing an error response if they are invalid
+func bindTransactionFilters(c *gin.Context, userID uuid.UUID, defaultLimit int) (services.TransactionListRequest, bool) {
 	var req struct {
-		UserID     uuid.UUID        `form:"user_id" binding:"required"`
 		AccountID  *uuid.UUID       `form:"account_id"`
 		CategoryID *uuid.UUID       `form:"category_id"`
 		StartDate  *string          `form:"start_date"`
 		EndDate    *string          `form:"end_date"`
 		MinAmount  *decimal.Decimal `form:"min_amount"`
 		MaxAmount  *decimal.Decimal `form:"max_amount"`
-		Limit      int              `form:"limit,default=20"`
+		Tags       string           `form:"tags"`
+		TagMode    string           `form:"tag_mode"`
+		Limit      *int             `form:"limit"`
 		Offset     int              `form:"offset,default=0"`
 	}
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+		return services.TransactionListRequest{}, false
 	}
 	var startDate, endDate *time.Time
 	if req.StartDate != nil {
 		t, err := time.Parse("2006-01-02", *req.StartDate)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
-			return
+			return services.TransactionListRequest{}, false
 		}
 		startDate = &t
 	}
@@ -138,27 +246,31 @@ func (h *transactionHandler) GetTransactions(c *gin.Context) {
 		t, err := time.Parse("2006-01-02", *req.EndDate)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, must be YYYY-MM-DD"})
-			return
+			return services.TransactionListRequest{}, false
 		}
 		endDate = &t
 	}
-	serviceReq := services.TransactionListRequest{
-		UserID:     req.UserID,
+	limit := defaultLimit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+	var tags []string
+	if req.Tags != "" {
+		tags = strings.Split(req.Tags, ",")
+	}
+	return services.TransactionListRequest{
+		UserID:     userID,
 		AccountID:  req.AccountID,
 		CategoryID: req.CategoryID,
 		StartDate:  startDate,
 		EndDate:    endDate,
 		MinAmount:  req.MinAmount,
 		MaxAmount:  req.MaxAmount,
-		Limit:      req.Limit,
+		Tags:       tags,
+		TagMatch:   repositories.TagMatch(req.TagMode),
+		Limit:      limit,
 		Offset:     req.Offset,
-	}
-	transactions, count, err := h.service.GetTransactions(serviceReq)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"transactions": transactions, "count": count})
+	}, true
 }
 
 // UpdateTransaction godoc
@@ -167,6 +279,8 @@ func (h *transactionHandler) GetTransactions(c *gin.Context) {
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        id   path      string  true  "Transaction ID"
 // @Param        transaction  body      models.Transaction  true  "Transaction object"
 // @Success      200  {object}  models.Transaction
@@ -175,6 +289,10 @@ func (h *transactionHandler) GetTransactions(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions/{id} [put]
 func (h *transactionHandler) UpdateTransaction(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -182,11 +300,16 @@ func (h *transactionHandler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 	var req struct {
-		AccountID   *uuid.UUID       `json:"account_id"`
-		CategoryID  *uuid.UUID       `json:"category_id"`
-		Amount      *decimal.Decimal `json:"amount"`
-		Description *string          `json:"description"`
-		Date        *string          `json:"date"`
+		AccountID   *uuid.UUID                 `json:"account_id"`
+		CategoryID  *uuid.UUID                 `json:"category_id"`
+		Amount      *decimal.Decimal           `json:"amount"`
+		Description *string                    `json:"description"`
+		Date        *string                    `json:"date"`
+		Splits      *[]TransactionSplitRequest `json:"splits" binding:"omitempty,dive"`
+
+		OriginalAmount   *decimal.Decimal `json:"original_amount"`
+		OriginalCurrency *string          `json:"original_currency"`
+		RemoveOriginal   bool             `json:"remove_original"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -207,12 +330,21 @@ func (h *transactionHandler) UpdateTransaction(c *gin.Context) {
 		Amount:      req.Amount,
 		Description: req.Description,
 		Date:        parsedDate,
+
+		OriginalAmount:   req.OriginalAmount,
+		OriginalCurrency: req.OriginalCurrency,
+		RemoveOriginal:   req.RemoveOriginal,
 	}
-	transaction, err := h.service.UpdateTransaction(id, serviceReq)
+	if req.Splits != nil {
+		splits := toServiceSplits(*req.Splits)
+		serviceReq.Splits = &splits
+	}
+	transaction, err := h.service.UpdateTransaction(userID, id, serviceReq)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	formatTransaction(transaction, requestLocale(c))
 	c.JSON(http.StatusOK, transaction)
 }
 
@@ -222,6 +354,7 @@ func (h *transactionHandler) UpdateTransaction(c *gin.Context) {
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "Transaction ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  ErrorResponse
@@ -229,14 +362,18 @@ func (h *transactionHandler) UpdateTransaction(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions/{id} [delete]
 func (h *transactionHandler) DeleteTransaction(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
 		return
 	}
-	if err := h.service.DeleteTransaction(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := h.service.DeleteTransaction(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -244,22 +381,20 @@ func (h *transactionHandler) DeleteTransaction(c *gin.Context) {
 
 // GetTransactionSummary godoc
 // @Summary      Get transaction summary
-// @Description  Get spending summary by category for a date range
+// @Description  Get spending summary by category for a date range in the user's currency. A category's total_amount and count include all of its subcategories; own_amount is the part assigned to the category itself. Amounts in other currencies are converted with the latest exchange rate on or before each transaction's date, and conversions lists the rates used; the request fails when a rate is missing.
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
-// @Param        user_id    query     string  true  "User ID"
+// @Security     BearerAuth
 // @Param        start_date query     string  false  "Start Date (YYYY-MM-DD)"
 // @Param        end_date   query     string  false  "End Date (YYYY-MM-DD)"
-// @Success      200  {array}   TransactionSummary
+// @Success      200  {object}  CategorySummaryResponse
 // @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions/summary [get]
 func (h *transactionHandler) GetTransactionSummary(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 	var startDate, endDate *time.Time
@@ -287,13 +422,57 @@ func (h *transactionHandler) GetTransactionSummary(c *gin.Context) {
 	c.JSON(http.StatusOK, summary)
 }
 
+// GetTagSummary godoc
+// @Summary      Get tag summary
+// @Description  Get income and spending totals by tag for a date range in the user's currency. total_amount is the sum of absolute amounts; a transaction with several tags counts towards each of them. Transfers are excluded. Conversions lists the exchange rates used.
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        start_date query     string  false  "Start Date (YYYY-MM-DD)"
+// @Param        end_date   query     string  false  "End Date (YYYY-MM-DD)"
+// @Success      200  {object}  TagSummaryResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transactions/summary/tags [get]
+func (h *transactionHandler) GetTagSummary(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var startDate, endDate *time.Time
+	if s := c.Query("start_date"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, must be YYYY-MM-DD"})
+			return
+		}
+		startDate = &t
+	}
+	if s := c.Query("end_date"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, must be YYYY-MM-DD"})
+			return
+		}
+		endDate = &t
+	}
+	summary, err := h.service.GetTagSummary(userID, startDate, endDate)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
 // GetMonthlyTotal godoc
 // @Summary      Get monthly total
-// @Description  Get total transactions for a specific month
+// @Description  Get total transactions for a specific month in the user's currency, with the exchange rates used to convert amounts in other currencies
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
-// @Param        user_id query     string  true  "User ID"
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
 // @Param        year    query     int     true  "Year"
 // @Param        month   query     int     true  "Month (1-12)"
 // @Success      200  {object}  MonthlyTotalResponse
@@ -301,12 +480,14 @@ func (h *transactionHandler) GetTransactionSummary(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /transactions/monthly-total [get]
 func (h *transactionHandler) GetMonthlyTotal(c *gin.Context) {
-	userIDStr := c.Query("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	year, yearErr := c.GetQuery("year")
 	month, monthErr := c.GetQuery("month")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil || !yearErr || !monthErr {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id, year, and month are required"})
+	if !yearErr || !monthErr {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "year and month are required"})
 		return
 	}
 	y, err := time.Parse("2006", year)
@@ -326,5 +507,19 @@ func (h *transactionHandler) GetMonthlyTotal(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	total.FormattedTotal = iso4217.Format(total.Total, total.Currency, requestLocale(c))
+	c.JSON(http.StatusOK, total)
+}
+
+// toServiceSplits converts split allocations from the request body to service requests
+func toServiceSplits(splits []TransactionSplitRequest) []services.TransactionSplitRequest {
+	result := make([]services.TransactionSplitRequest, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, services.TransactionSplitRequest{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Memo:       split.Memo,
+		})
+	}
+	return result
 }
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+type transferHandler struct {
+	service services.TransferService
+}
+
+func NewTransferHandler(service services.TransferService) *transferHandler {
+	return &transferHandler{service: service}
+}
+
+// CreateTransfer godoc
+// @Summary      Create a transfer
+// @Description  Move money between two accounts as a linked debit/credit pair. Between accounts in different currencies, to_amount is the amount credited to the destination account.
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        transfer  body      CreateTransferRequest  true  "Transfer object"
+// @Success      201  {object}  services.Transfer
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transfers [post]
+func (h *transferHandler) CreateTransfer(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	parsedDate, err := time.Parse(time.RFC3339, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format, must be RFC3339"})
+		return
+	}
+	serviceReq := services.TransferCreateRequest{
+		UserID:        userID,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		ToAmount:      req.ToAmount,
+		Description:   req.Description,
+		Date:          parsedDate,
+	}
+	transfer, err := h.service.CreateTransfer(serviceReq)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	formatTransfer(transfer, requestLocale(c))
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// GetTransfer godoc
+// @Summary      Get transfer by ID
+// @Description  Get both legs of a transfer by its ID
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Accept-Language  header    string  false  "Locale to format amounts in, e.g. de-DE"
+// @Param        id   path      string  true  "Transfer ID"
+// @Success      200  {object}  services.Transfer
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transfers/{id} [get]
+func (h *transferHandler) GetTransfer(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer id"})
+		return
+	}
+	transfer, err := h.service.GetTransfer(userID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	formatTransfer(transfer, requestLocale(c))
+	c.JSON(http.StatusOK, transfer)
+}
+
+// DeleteTransfer godoc
+// @Summary      Delete transfer
+// @Description  Delete both legs of a transfer and reverse the balance changes
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Transfer ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transfers/{id} [delete]
+func (h *transferHandler) DeleteTransfer(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer id"})
+		return
+	}
+	if err := h.service.DeleteTransfer(userID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 	"github.com/vasujain275/expense-tracker-api/internal/services"
 )
 
@@ -16,41 +17,13 @@ func NewUserHandler(service services.UserService) *userHandler {
 	return &userHandler{service: service}
 }
 
-// CreateUser godoc
-// @Summary      Create a new user
-// @Description  Create a new user with the provided information
-// @Tags         users
-// @Accept       json
-// @Produce      json
-// @Param        user  body      models.User  true  "User object"
-// @Success      201  {object}  models.User
-// @Failure      400  {object}  ErrorResponse
-// @Failure      500  {object}  ErrorResponse
-// @Router       /users [post]
-func (h *userHandler) CreateUser(c *gin.Context) {
-	var req struct {
-		Email    string `json:"email" binding:"required,email"`
-		Name     string `json:"name" binding:"required"`
-		Currency string `json:"currency" binding:"required,len=3"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	user, err := h.service.CreateUser(req.Email, req.Name, req.Currency)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusCreated, user)
-}
-
 // GetUser godoc
 // @Summary      Get user by ID
 // @Description  Get user details by their ID
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "User ID"
 // @Success      200  {object}  models.User
 // @Failure      400  {object}  ErrorResponse
@@ -58,13 +31,15 @@ func (h *userHandler) CreateUser(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /users/{id} [get]
 func (h *userHandler) GetUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
-	user, err := h.service.GetUserByID(id)
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := h.service.GetUserByID(userID, id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -78,6 +53,7 @@ func (h *userHandler) GetUser(c *gin.Context) {
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "User ID"
 // @Param        user  body      models.User  true  "User object"
 // @Success      200  {object}  models.User
@@ -86,10 +62,12 @@ func (h *userHandler) GetUser(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /users/{id} [put]
 func (h *userHandler) UpdateUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
 	var req struct {
@@ -100,9 +78,9 @@ func (h *userHandler) UpdateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	user, err := h.service.UpdateUser(id, req.Name, req.Currency)
+	user, err := h.service.UpdateUser(userID, id, req.Name, req.Currency)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, user)
@@ -114,6 +92,7 @@ func (h *userHandler) UpdateUser(c *gin.Context) {
 // @Tags         users
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      string  true  "User ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  ErrorResponse
@@ -121,15 +100,45 @@ func (h *userHandler) UpdateUser(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /users/{id} [delete]
 func (h *userHandler) DeleteUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
-	if err := h.service.DeleteUser(id); err != nil {
+	if err := h.service.DeleteUser(userID, id); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// userIDParam parses the :id path parameter as a user ID
+func userIDParam(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// ownUserIDParam parses the :id path parameter and ensures it is the authenticated
+// user. Other users' IDs are reported as not found so their existence is not revealed.
+func ownUserIDParam(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	if id != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": repositories.ErrUserNotFound.Error()})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,242 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// camtDocument is the subset of an ISO 20022 camt.053 BankToCustomerStatement
+// needed to build rows. Element names are matched without their namespace, so
+// every camt.053 version is accepted.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus holds the entry status, which is plain text up to camt.053.001.07
+// and a nested Cd element from version 8
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtBalance struct {
+	Code   string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount camtAmount `xml:"Amt"`
+	Sign   string     `xml:"CdtDbtInd"`
+	Date   camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount        `xml:"Amt"`
+	Sign           string            `xml:"CdtDbtInd"`
+	Status         camtStatus        `xml:"Sts"`
+	BookingDate    camtDate          `xml:"BookgDt"`
+	ValueDate      camtDate          `xml:"ValDt"`
+	ServicerRef    string            `xml:"AcctSvcrRef"`
+	Details        []camtTransaction `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string            `xml:"AddtlNtryInf"`
+}
+
+type camtTransaction struct {
+	ServicerRef  string   `xml:"Refs>AcctSvcrRef"`
+	EndToEndID   string   `xml:"Refs>EndToEndId"`
+	CreditorName string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorPty  string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	DebtorName   string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorPty    string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Unstructured []string `xml:"RmtInf>Ustrd"`
+}
+
+// ParseCAMT053 reads an ISO 20022 camt.053 statement. Each booked entry
+// becomes a row whose external ID is the bank's reference (AcctSvcrRef of the
+// entry, or of its only transaction). NtryRef is not used because many banks
+// restart it with every statement. The opening (OPBD, or PRCD) and closing
+// (CLBD) booked balances are kept for reconciliation. Pending entries are
+// skipped, and files holding more than one statement are rejected.
+func ParseCAMT053(r io.Reader) (*Statement, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 file: %w", err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, errors.New("the file contains no camt.053 statement")
+	}
+	if len(doc.Statements) > 1 {
+		return nil, fmt.Errorf("the file contains %d statements; import them one at a time", len(doc.Statements))
+	}
+	stmt := doc.Statements[0]
+
+	statement := &Statement{Rows: []Row{}, Errors: []RowError{}}
+
+	for _, bal := range stmt.Balances {
+		amount, err := camtSigned(bal.Amount.Value, bal.Sign)
+		if err != nil {
+			statement.addError(0, fmt.Sprintf("%s balance: %s", bal.Code, err))
+			continue
+		}
+		switch strings.ToUpper(bal.Code) {
+		case "OPBD", "PRCD":
+			if statement.OpeningBalance == nil {
+				statement.OpeningBalance = &amount
+			}
+		case "CLBD":
+			statement.ClosingBalance = &amount
+			if date, err := bal.Date.parse(); err == nil {
+				statement.BalanceDate = &date
+			}
+		}
+		if statement.Currency == "" {
+			statement.Currency = strings.ToUpper(bal.Amount.Currency)
+		}
+	}
+
+	for i, entry := range stmt.Entries {
+		// Entries are numbered from 1 since XML offers no useful line numbers
+		line := i + 1
+
+		status := strings.ToUpper(strings.TrimSpace(entry.Status.Code + entry.Status.Text))
+		if status != "" && status != "BOOK" {
+			continue
+		}
+
+		row, err := entry.row()
+		if err != nil {
+			statement.addError(line, err.Error())
+			continue
+		}
+		row.Line = line
+		if statement.Currency == "" {
+			statement.Currency = strings.ToUpper(entry.Amount.Currency)
+		}
+		statement.Rows = append(statement.Rows, row)
+	}
+
+	return statement, nil
+}
+
+func (e camtEntry) row() (Row, error) {
+	var row Row
+
+	date, err := e.BookingDate.parse()
+	if err != nil {
+		if date, err = e.ValueDate.parse(); err != nil {
+			return row, errors.New("entry has no booking or value date")
+		}
+	}
+	row.Date = date
+
+	amount, err := camtSigned(e.Amount.Value, e.Sign)
+	if err != nil {
+		return row, err
+	}
+	if amount.IsZero() {
+		return row, errors.New("amount is zero")
+	}
+	row.Amount = amount
+
+	row.ExternalID = firstReference(e.ServicerRef)
+	if row.ExternalID == "" && len(e.Details) == 1 {
+		row.ExternalID = firstReference(e.Details[0].ServicerRef, e.Details[0].EndToEndID)
+	}
+
+	row.Description = e.description(amount.IsNegative())
+	if row.Description == "" {
+		return row, errors.New("entry has no description")
+	}
+
+	return row, nil
+}
+
+// description combines the counterparty name and the remittance information,
+// falling back to the additional entry information
+func (e camtEntry) description(debit bool) string {
+	var parts []string
+	if len(e.Details) == 1 {
+		tx := e.Details[0]
+		counterparty := firstNonEmpty(tx.DebtorName, tx.DebtorPty)
+		if debit {
+			counterparty = firstNonEmpty(tx.CreditorName, tx.CreditorPty)
+		}
+		if counterparty != "" {
+			parts = append(parts, counterparty)
+		}
+		if remittance := strings.Join(tx.Unstructured, " "); strings.TrimSpace(remittance) != "" {
+			parts = append(parts, remittance)
+		}
+	}
+	if len(parts) == 0 && e.AdditionalInfo != "" {
+		parts = append(parts, e.AdditionalInfo)
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " - ")), " ")
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if s := strings.TrimSpace(d.Date); s != "" {
+		return time.Parse("2006-01-02", s)
+	}
+	if s := strings.TrimSpace(d.DateTime); len(s) >= 10 {
+		return time.Parse("2006-01-02", s[:10])
+	}
+	return time.Time{}, errors.New("missing date")
+}
+
+// camtSigned applies a CRDT/DBIT indicator to an unsigned camt amount. For
+// reversals the indicator already gives the direction of the reversing entry.
+func camtSigned(value, indicator string) (decimal.Decimal, error) {
+	amount, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", value)
+	}
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "CRDT":
+	case "DBIT":
+		amount = amount.Neg()
+	default:
+		return decimal.Zero, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+	}
+	return amount, nil
+}
+
+// firstReference returns the first usable bank reference, ignoring the
+// placeholders banks use when no reference exists
+func firstReference(refs ...string) string {
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		switch strings.ToUpper(ref) {
+		case "", "NONREF", "NOTPROVIDED":
+			continue
+		}
+		return ref
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCAMT053(t *testing.T) {
+	statement := parseFixture(t, "camt053.xml", ParseCAMT053)
+
+	// The pending entry 3 is skipped. Entry 2 is identified by its end-to-end
+	// ID because its bank reference is NONREF, and entry 4 has no usable
+	// reference at all.
+	checkStatement(t, statement, Statement{
+		Currency:       "EUR",
+		OpeningBalance: ptr(amount("1000")),
+		ClosingBalance: ptr(amount("3490.01")),
+		BalanceDate:    ptr(day(2025, time.January, 31)),
+		Rows: []Row{
+			{Line: 1, Date: day(2025, time.January, 3), Amount: amount("-42.17"),
+				Description: "Stadtwerke München - Stromabschlag Januar 2025", ExternalID: "2025010300001"},
+			{Line: 2, Date: day(2025, time.January, 15), Amount: amount("2500"),
+				Description: "ACME GmbH - Gehalt Januar", ExternalID: "PAYROLL-2025-01"},
+			{Line: 4, Date: day(2025, time.January, 20), Amount: amount("-9.99"),
+				Description: "Streaming Service Monatsabo"},
+			{Line: 5, Date: day(2025, time.January, 22), Amount: amount("42.17"),
+				Description: "Ruecklastschrift Stadtwerke", ExternalID: "2025012200005"},
+		},
+	})
+}
+
+func TestParseCAMT053Entries(t *testing.T) {
+	entry := func(body string) string {
+		return `<Document><BkToCstmrStmt><Stmt><Ntry>` + body + `</Ntry></Stmt></BkToCstmrStmt></Document>`
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    Row
+		err     bool
+	}{
+		{
+			name: "camt.053.001.08 status code",
+			content: entry(`<Amt Ccy="EUR">1.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>
+				<BookgDt><Dt>2025-03-01</Dt></BookgDt><AcctSvcrRef>R1</AcctSvcrRef><AddtlNtryInf>Fee</AddtlNtryInf>`),
+			want: Row{Line: 1, Date: day(2025, time.March, 1), Amount: amount("-1.50"), Description: "Fee", ExternalID: "R1"},
+		},
+		{
+			name: "value date when booking date is missing",
+			content: entry(`<Amt Ccy="EUR">3</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+				<ValDt><Dt>2025-03-02</Dt></ValDt><AddtlNtryInf>Interest</AddtlNtryInf>`),
+			want: Row{Line: 1, Date: day(2025, time.March, 2), Amount: amount("3"), Description: "Interest"},
+		},
+		{
+			name: "party name in a Pty element",
+			content: entry(`<Amt Ccy="EUR">20</Amt><CdtDbtInd>DBIT</CdtDbtInd><BookgDt><Dt>2025-03-03</Dt></BookgDt>
+				<NtryDtls><TxDtls><RltdPties><Cdtr><Pty><Nm>Bakery</Nm></Pty></Cdtr></RltdPties></TxDtls></NtryDtls>`),
+			want: Row{Line: 1, Date: day(2025, time.March, 3), Amount: amount("-20"), Description: "Bakery"},
+		},
+		{
+			name: "invalid credit/debit indicator",
+			content: entry(`<Amt Ccy="EUR">20</Amt><CdtDbtInd>X</CdtDbtInd><BookgDt><Dt>2025-03-03</Dt></BookgDt>
+				<AddtlNtryInf>Unknown</AddtlNtryInf>`),
+			err: true,
+		},
+		{
+			name:    "no date",
+			content: entry(`<Amt Ccy="EUR">20</Amt><CdtDbtInd>DBIT</CdtDbtInd><AddtlNtryInf>Undated</AddtlNtryInf>`),
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := ParseCAMT053(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if tt.err {
+				if len(statement.Errors) != 1 || len(statement.Rows) != 0 {
+					t.Fatalf("rows %+v, errors %+v; want one error", statement.Rows, statement.Errors)
+				}
+				return
+			}
+			checkStatement(t, statement, Statement{Currency: "EUR", Rows: []Row{tt.want}})
+		})
+	}
+}
+
+func TestParseCAMT053RejectsMultipleStatements(t *testing.T) {
+	content := `<Document><BkToCstmrStmt><Stmt></Stmt><Stmt></Stmt></BkToCstmrStmt></Document>`
+	if _, err := ParseCAMT053(strings.NewReader(content)); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,302 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SignConvention describes how a single amount column is signed
+type SignConvention string
+
+const (
+	// SignNegativeIsDebit means money leaving the account is negative, as on
+	// most bank statements
+	SignNegativeIsDebit SignConvention = "negative_is_debit"
+	// SignPositiveIsDebit means money leaving the account is positive, as on
+	// many credit card statements
+	SignPositiveIsDebit SignConvention = "positive_is_debit"
+)
+
+// CSVMapping describes how to read a CSV statement. Columns are referenced by
+// header name, or by 1-based position when the file has no header row. Either
+// AmountColumn or at least one of DebitColumn and CreditColumn must be set.
+type CSVMapping struct {
+	Delimiter         rune
+	HasHeader         bool
+	SkipRows          int
+	DateColumn        string
+	AmountColumn      string
+	DebitColumn       string
+	CreditColumn      string
+	DescriptionColumn string
+	ExternalIDColumn  string
+	DateFormat        string
+	DecimalSeparator  string
+	SignConvention    SignConvention
+}
+
+// Validate checks that the mapping is complete
+func (m CSVMapping) Validate() error {
+	if m.DateColumn == "" {
+		return errors.New("date column is required")
+	}
+	if m.DescriptionColumn == "" {
+		return errors.New("description column is required")
+	}
+	if m.AmountColumn == "" && m.DebitColumn == "" && m.CreditColumn == "" {
+		return errors.New("an amount column or debit/credit columns are required")
+	}
+	if m.AmountColumn != "" && (m.DebitColumn != "" || m.CreditColumn != "") {
+		return errors.New("use either an amount column or debit/credit columns, not both")
+	}
+	if m.DateFormat == "" {
+		return errors.New("date format is required")
+	}
+	if m.DecimalSeparator != "." && m.DecimalSeparator != "," {
+		return errors.New("decimal separator must be \".\" or \",\"")
+	}
+	switch m.SignConvention {
+	case SignNegativeIsDebit, SignPositiveIsDebit:
+	default:
+		return errors.New("sign convention must be negative_is_debit or positive_is_debit")
+	}
+	if m.SkipRows < 0 {
+		return errors.New("skip rows cannot be negative")
+	}
+	return nil
+}
+
+// ParseCSV reads a CSV statement. Lines that cannot be parsed are reported in
+// the statement's Errors instead of failing the whole file.
+func ParseCSV(r io.Reader, mapping CSVMapping) (*Statement, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = mapping.Delimiter
+	if reader.Comma == 0 {
+		reader.Comma = ','
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	layout := DateLayout(mapping.DateFormat)
+	statement := &Statement{Rows: []Row{}, Errors: []RowError{}}
+
+	var columns *csvColumns
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				statement.addError(line, parseErr.Err.Error())
+				continue
+			}
+			return nil, err
+		}
+
+		if line <= mapping.SkipRows || isBlank(record) {
+			continue
+		}
+
+		if columns == nil {
+			var header []string
+			if mapping.HasHeader {
+				header = record
+			}
+			columns, err = resolveColumns(mapping, header)
+			if err != nil {
+				return nil, err
+			}
+			if mapping.HasHeader {
+				continue
+			}
+		}
+
+		row, err := columns.parse(record, layout, mapping)
+		if err != nil {
+			statement.addError(line, err.Error())
+			continue
+		}
+		row.Line = line
+		statement.Rows = append(statement.Rows, row)
+	}
+
+	if columns == nil {
+		return nil, errors.New("the file contains no rows")
+	}
+
+	return statement, nil
+}
+
+// csvColumns holds the resolved 0-based column positions; -1 means unused
+type csvColumns struct {
+	date, amount, debit, credit, description, externalID int
+}
+
+func resolveColumns(mapping CSVMapping, header []string) (*csvColumns, error) {
+	resolve := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		if n, err := strconv.Atoi(name); err == nil {
+			if n < 1 {
+				return -1, fmt.Errorf("column position %d must be at least 1", n)
+			}
+			return n - 1, nil
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), strings.TrimSpace(name)) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("column %q not found in the header row", name)
+	}
+
+	columns := &csvColumns{}
+	var err error
+	for _, c := range []struct {
+		target *int
+		name   string
+	}{
+		{&columns.date, mapping.DateColumn},
+		{&columns.amount, mapping.AmountColumn},
+		{&columns.debit, mapping.DebitColumn},
+		{&columns.credit, mapping.CreditColumn},
+		{&columns.description, mapping.DescriptionColumn},
+		{&columns.externalID, mapping.ExternalIDColumn},
+	} {
+		if *c.target, err = resolve(c.name); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+func (c *csvColumns) parse(record []string, layout string, mapping CSVMapping) (Row, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var row Row
+
+	dateStr := field(c.date)
+	date, err := time.Parse(layout, dateStr)
+	if err != nil {
+		return row, fmt.Errorf("invalid date %q, expected format %s", dateStr, mapping.DateFormat)
+	}
+	row.Date = date
+
+	if c.amount >= 0 {
+		amount, err := ParseAmount(field(c.amount), mapping.DecimalSeparator)
+		if err != nil {
+			return row, err
+		}
+		if mapping.SignConvention == SignPositiveIsDebit {
+			amount = amount.Neg()
+		}
+		row.Amount = amount
+	} else {
+		debit, credit := decimal.Zero, decimal.Zero
+		if s := field(c.debit); s != "" {
+			if debit, err = ParseAmount(s, mapping.DecimalSeparator); err != nil {
+				return row, err
+			}
+		}
+		if s := field(c.credit); s != "" {
+			if credit, err = ParseAmount(s, mapping.DecimalSeparator); err != nil {
+				return row, err
+			}
+		}
+		row.Amount = credit.Abs().Sub(debit.Abs())
+	}
+	if row.Amount.IsZero() {
+		return row, errors.New("amount is zero or missing")
+	}
+
+	row.Description = strings.Join(strings.Fields(field(c.description)), " ")
+	if row.Description == "" {
+		return row, errors.New("description is empty")
+	}
+
+	row.ExternalID = field(c.externalID)
+	return row, nil
+}
+
+// DateLayout converts a date format written with YYYY, YY, MM and DD tokens
+// (for example DD/MM/YYYY) into a Go time layout. Formats that are already Go
+// layouts are returned unchanged.
+func DateLayout(format string) string {
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	return replacer.Replace(format)
+}
+
+// ParseAmount parses a statement amount, tolerating currency symbols,
+// thousands separators, a leading or trailing sign and accounting-style
+// parentheses for negative numbers
+func ParseAmount(s, decimalSeparator string) (decimal.Decimal, error) {
+	original := s
+	s = strings.TrimSpace(s)
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if strings.HasSuffix(s, "-") {
+		negative = !negative
+		s = strings.TrimSuffix(s, "-")
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-':
+			negative = !negative
+		case string(r) == decimalSeparator:
+			b.WriteRune('.')
+		}
+		// Currency symbols, spaces, '+' and thousands separators are dropped
+	}
+
+	if b.Len() == 0 {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", original)
+	}
+
+	amount, err := decimal.NewFromString(b.String())
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", original)
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
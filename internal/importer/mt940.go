@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	mt940TagPattern     = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	mt940BalancePattern = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)$`)
+	// :61: value date, optional entry date, mark, optional funds code, amount,
+	// transaction type, customer reference, optional //bank reference and
+	// supplementary details on the following line
+	mt940LinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?(?:\n((?s:.*)))?$`)
+	// Structured :86: subfields such as ?20 (remittance) and ?32 (name)
+	mt940SubfieldPattern = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// mt940Field is a tagged field with its continuation lines
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// ParseMT940 reads a SWIFT MT940 statement. Each :61: line becomes a row whose
+// external ID is the bank reference after "//" (or the customer reference when
+// there is none), described by the following :86: field. A file may hold
+// consecutive statements for one account; the first :60F: and last :62F:
+// balances are kept for reconciliation.
+func ParseMT940(r io.Reader) (*Statement, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{Rows: []Row{}, Errors: []RowError{}}
+	var account string
+	var pending *Row
+	flush := func() {
+		if pending != nil {
+			if pending.Description == "" {
+				statement.addError(pending.Line, "transaction has no description")
+			} else {
+				statement.Rows = append(statement.Rows, *pending)
+			}
+			pending = nil
+		}
+	}
+
+	for _, f := range fields {
+		switch f.tag {
+		case "25":
+			id := strings.TrimSpace(f.value)
+			if account != "" && id != account {
+				return nil, fmt.Errorf("the file contains statements for accounts %s and %s; import them one at a time", account, id)
+			}
+			account = id
+		case "60F", "60M":
+			flush()
+			if statement.OpeningBalance != nil {
+				continue
+			}
+			balance, currency, _, err := parseMT940Balance(f.value)
+			if err != nil {
+				statement.addError(f.line, "opening balance: "+err.Error())
+				continue
+			}
+			statement.OpeningBalance = &balance
+			statement.Currency = currency
+		case "62F", "62M":
+			flush()
+			balance, currency, date, err := parseMT940Balance(f.value)
+			if err != nil {
+				statement.addError(f.line, "closing balance: "+err.Error())
+				continue
+			}
+			statement.ClosingBalance = &balance
+			statement.BalanceDate = &date
+			if statement.Currency == "" {
+				statement.Currency = currency
+			}
+		case "61":
+			flush()
+			row, err := parseMT940Line(f.value)
+			if err != nil {
+				statement.addError(f.line, err.Error())
+				continue
+			}
+			row.Line = f.line
+			pending = &row
+		case "86":
+			if pending != nil {
+				if description := mt940Description(f.value); description != "" {
+					pending.Description = description
+				}
+				flush()
+			}
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	if account == "" && len(statement.Rows) == 0 && statement.ClosingBalance == nil {
+		return nil, errors.New("not an MT940 file: no :25:, :61: or :62F: fields found")
+	}
+
+	return statement, nil
+}
+
+// readMT940Fields splits the message text into tagged fields, dropping SWIFT
+// block headers and message trailers
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var fields []mt940Field
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r ")
+
+		// {1:...}{2:...}{4: opens the text block; "-}" closes it
+		if i := strings.Index(text, "{4:"); i >= 0 {
+			text = text[i+3:]
+		}
+		if text == "" || text == "-" || strings.HasPrefix(text, "-}") || strings.HasPrefix(text, "{") {
+			continue
+		}
+
+		if m := mt940TagPattern.FindStringSubmatch(text); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], value: m[2], line: line})
+			continue
+		}
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// parseMT940Balance reads a balance such as C240131EUR1234,56
+func parseMT940Balance(value string) (decimal.Decimal, string, time.Time, error) {
+	m := mt940BalancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return decimal.Zero, "", time.Time{}, fmt.Errorf("invalid balance %q", value)
+	}
+	date, err := time.Parse("060102", m[2])
+	if err != nil {
+		return decimal.Zero, "", time.Time{}, fmt.Errorf("invalid balance date %q", m[2])
+	}
+	amount, err := ParseAmount(m[4], ",")
+	if err != nil {
+		return decimal.Zero, "", time.Time{}, err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, m[3], date, nil
+}
+
+// parseMT940Line reads a :61: statement line
+func parseMT940Line(value string) (Row, error) {
+	var row Row
+
+	m := mt940LinePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return row, fmt.Errorf("invalid statement line %q", firstLine(value))
+	}
+
+	date, err := time.Parse("060102", m[1])
+	if err != nil {
+		return row, fmt.Errorf("invalid value date %q", m[1])
+	}
+	row.Date = date
+
+	amount, err := ParseAmount(m[5], ",")
+	if err != nil {
+		return row, err
+	}
+	if amount.IsZero() {
+		return row, errors.New("amount is zero")
+	}
+	// A reversal of a credit (RC) takes money out, a reversal of a debit (RD) puts it back
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+	row.Amount = amount
+
+	// Only the bank reference identifies the entry. The customer reference is
+	// chosen by the account owner and is often repeated, such as on every
+	// payment of a standing order.
+	row.ExternalID = firstReference(m[8])
+	row.Description = strings.Join(strings.Fields(m[9]), " ")
+	return row, nil
+}
+
+// mt940Description flattens a :86: field. Structured fields (as used by
+// German banks) keep only the counterparty name (?32, ?33) and the remittance
+// lines (?20 to ?29); free text is joined onto a single line.
+func mt940Description(value string) string {
+	if !mt940SubfieldPattern.MatchString(value) {
+		return strings.Join(strings.Fields(value), " ")
+	}
+	// Structured subfields are wrapped at a fixed width, often mid-word
+	value = strings.ReplaceAll(value, "\n", "")
+
+	var name, remittance []string
+	matches := mt940SubfieldPattern.FindAllStringSubmatchIndex(value, -1)
+	for i, m := range matches {
+		end := len(value)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		code, text := value[m[2]:m[3]], strings.TrimSpace(value[m[1]:end])
+		switch {
+		case code == "32" || code == "33":
+			name = append(name, text)
+		case code >= "20" && code <= "29":
+			remittance = append(remittance, text)
+		}
+	}
+
+	parts := []string{}
+	if n := strings.Join(name, ""); n != "" {
+		parts = append(parts, n)
+	}
+	if r := strings.Join(remittance, ""); r != "" {
+		parts = append(parts, r)
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " - ")), " ")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMT940(t *testing.T) {
+	statement := parseFixture(t, "mt940.sta", ParseMT940)
+
+	// Line 6 has a structured :86: wrapped mid-word over three lines; line 13
+	// has NONREF and no bank reference; lines 16 and 18 reverse a debit (RD)
+	// and a credit (RC)
+	checkStatement(t, statement, Statement{
+		Currency:       "EUR",
+		OpeningBalance: ptr(amount("1000")),
+		ClosingBalance: ptr(amount("3457.84")),
+		BalanceDate:    ptr(day(2025, time.January, 31)),
+		Rows: []Row{
+			{Line: 6, Date: day(2025, time.January, 3), Amount: amount("-42.17"),
+				Description: "STADTWERKE MUENCHEN - EREF+STROM-2501 Stromabschlag Januar 2025", ExternalID: "BREF0001"},
+			{Line: 10, Date: day(2025, time.January, 15), Amount: amount("2500"),
+				Description: "ACME GMBH GEHALT JANUAR 2025", ExternalID: "BREF0002"},
+			{Line: 13, Date: day(2025, time.January, 20), Amount: amount("-9.99"),
+				Description: "STREAMING SERVICE MONATSABO"},
+			{Line: 16, Date: day(2025, time.January, 22), Amount: amount("15"),
+				Description: "RUECKBUCHUNG LASTSCHRIFT", ExternalID: "BREF0004"},
+			{Line: 18, Date: day(2025, time.January, 25), Amount: amount("-5"),
+				Description: "STORNO GUTSCHRIFT", ExternalID: "BREF0005"},
+		},
+	})
+}
+
+func TestParseMT940Lines(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  Row
+		err   bool
+	}{
+		{"credit", "250301C10,NTRFNONREF", Row{Date: day(2025, time.March, 1), Amount: amount("10")}, false},
+		{"debit", "250301D10,5NTRFNONREF", Row{Date: day(2025, time.March, 1), Amount: amount("-10.5")}, false},
+		{"reversal of credit", "250301RC1,00NTRFNONREF", Row{Date: day(2025, time.March, 1), Amount: amount("-1")}, false},
+		{"reversal of debit", "250301RD1,00NTRFNONREF", Row{Date: day(2025, time.March, 1), Amount: amount("1")}, false},
+		{"funds code", "2503010301CR7,25NTRFINV-7//B7", Row{Date: day(2025, time.March, 1), Amount: amount("7.25"), ExternalID: "B7"}, false},
+		{"customer reference", "250301D2,00NCHKCHEQUE 42", Row{Date: day(2025, time.March, 1), Amount: amount("-2")}, false},
+		{"supplementary details", "250301D2,00NMSCNONREF//B8\nCARD FEE", Row{Date: day(2025, time.March, 1), Amount: amount("-2"), ExternalID: "B8", Description: "CARD FEE"}, false},
+		{"zero amount", "250301D0,00NTRFNONREF", Row{}, true},
+		{"missing mark", "25030110,00NTRFNONREF", Row{}, true},
+		{"invalid date", "251301D1,00NTRFNONREF", Row{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, err := parseMT940Line(tt.value)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", row)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !row.Date.Equal(tt.want.Date) || !row.Amount.Equal(tt.want.Amount) ||
+				row.ExternalID != tt.want.ExternalID || row.Description != tt.want.Description {
+				t.Errorf("got {%s %s %q %q}, want {%s %s %q %q}",
+					row.Date.Format("2006-01-02"), row.Amount, row.ExternalID, row.Description,
+					tt.want.Date.Format("2006-01-02"), tt.want.Amount, tt.want.ExternalID, tt.want.Description)
+			}
+		})
+	}
+}
+
+func TestParseMT940RejectsMixedAccounts(t *testing.T) {
+	content := ":20:A\n:25:111\n:60F:C250101EUR0,00\n:62F:C250101EUR0,00\n" +
+		":20:B\n:25:222\n:60F:C250101EUR0,00\n:62F:C250101EUR0,00\n"
+	if _, err := ParseMT940(strings.NewReader(content)); err == nil {
+		t.Fatal("expected an error")
+	}
+}
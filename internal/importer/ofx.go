@@ -0,0 +1,251 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ParseOFX reads an OFX or QFX statement. Both OFX 1.x (SGML, where leaf
+// elements are not closed) and OFX 2.x (XML) are accepted. Each STMTTRN entry
+// becomes a row with its FITID as the external ID, and the LEDGERBAL amount
+// becomes the closing balance. Files holding more than one account statement
+// are rejected.
+func ParseOFX(r io.Reader) (*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, errors.New("not an OFX file: missing <OFX> element")
+	}
+
+	p := &ofxParser{
+		content:   content,
+		pos:       start,
+		line:      strings.Count(content[:start], "\n") + 1,
+		statement: &Statement{Rows: []Row{}, Errors: []RowError{}},
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	if p.statements == 0 {
+		return nil, errors.New("the file contains no bank or credit card statement")
+	}
+	if p.statements > 1 {
+		return nil, fmt.Errorf("the file contains %d account statements; export one account at a time", p.statements)
+	}
+
+	return p.statement, nil
+}
+
+// ofxTransaction collects the fields of a STMTTRN aggregate
+type ofxTransaction struct {
+	line                  int
+	fitID, posted, amount string
+	name, memo, payeeName string
+}
+
+type ofxParser struct {
+	content    string
+	pos        int
+	line       int
+	stack      []string
+	statements int
+	statement  *Statement
+	current    *ofxTransaction
+}
+
+func (p *ofxParser) parse() error {
+	for {
+		open := strings.IndexByte(p.content[p.pos:], '<')
+		if open < 0 {
+			return nil
+		}
+		p.advance(p.pos + open)
+
+		end := strings.IndexByte(p.content[p.pos:], '>')
+		if end < 0 {
+			return fmt.Errorf("line %d: unterminated tag", p.line)
+		}
+		tag := strings.TrimSpace(p.content[p.pos+1 : p.pos+end])
+		p.advance(p.pos + end + 1)
+
+		next := strings.IndexByte(p.content[p.pos:], '<')
+		if next < 0 {
+			next = len(p.content) - p.pos
+		}
+		text := strings.TrimSpace(p.content[p.pos : p.pos+next])
+
+		switch {
+		case tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") || strings.HasSuffix(tag, "/"):
+			// Processing instructions, comments and empty elements carry no data
+		case strings.HasPrefix(tag, "/"):
+			p.close(strings.ToUpper(tag[1:]))
+		case text == "":
+			p.open(strings.ToUpper(tag))
+		default:
+			p.leaf(strings.ToUpper(tag), html.UnescapeString(text))
+		}
+	}
+}
+
+// advance moves to pos, keeping track of the current line number
+func (p *ofxParser) advance(pos int) {
+	p.line += strings.Count(p.content[p.pos:pos], "\n")
+	p.pos = pos
+}
+
+func (p *ofxParser) open(name string) {
+	p.stack = append(p.stack, name)
+	switch name {
+	case "STMTRS", "CCSTMTRS":
+		p.statements++
+	case "STMTTRN":
+		p.current = &ofxTransaction{line: p.line}
+	}
+}
+
+// close pops aggregates up to and including name. SGML files may leave empty
+// leaf elements unclosed, and those were pushed as aggregates by open.
+func (p *ofxParser) close(name string) {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		if p.stack[i] != name {
+			continue
+		}
+		for j := len(p.stack) - 1; j >= i; j-- {
+			if p.stack[j] == "STMTTRN" {
+				p.finishTransaction()
+			}
+		}
+		p.stack = p.stack[:i]
+		return
+	}
+	// A closing tag for a leaf element (OFX 2.x) has nothing to pop
+}
+
+func (p *ofxParser) leaf(name, value string) {
+	parent := ""
+	if len(p.stack) > 0 {
+		parent = p.stack[len(p.stack)-1]
+	}
+
+	if p.current != nil {
+		switch {
+		case name == "FITID":
+			p.current.fitID = value
+		case name == "DTPOSTED":
+			p.current.posted = value
+		case name == "TRNAMT":
+			p.current.amount = value
+		case name == "NAME" && parent == "PAYEE":
+			p.current.payeeName = value
+		case name == "NAME":
+			p.current.name = value
+		case name == "MEMO":
+			p.current.memo = value
+		}
+		return
+	}
+
+	switch {
+	case name == "CURDEF":
+		p.statement.Currency = strings.ToUpper(value)
+	case parent == "LEDGERBAL" && name == "BALAMT":
+		amount, err := parseOFXAmount(value)
+		if err != nil {
+			p.statement.addError(p.line, "ledger balance: "+err.Error())
+			return
+		}
+		p.statement.ClosingBalance = &amount
+	case parent == "LEDGERBAL" && name == "DTASOF":
+		if date, err := parseOFXDate(value); err == nil {
+			p.statement.BalanceDate = &date
+		}
+	}
+}
+
+// finishTransaction converts the current STMTTRN into a row
+func (p *ofxParser) finishTransaction() {
+	t := p.current
+	p.current = nil
+	if t == nil {
+		return
+	}
+
+	date, err := parseOFXDate(t.posted)
+	if err != nil {
+		p.statement.addError(t.line, err.Error())
+		return
+	}
+
+	amount, err := parseOFXAmount(t.amount)
+	if err != nil {
+		p.statement.addError(t.line, err.Error())
+		return
+	}
+	if amount.IsZero() {
+		p.statement.addError(t.line, "amount is zero")
+		return
+	}
+
+	if t.fitID == "" {
+		p.statement.addError(t.line, "transaction has no FITID")
+		return
+	}
+
+	name := t.name
+	if name == "" {
+		name = t.payeeName
+	}
+	description := name
+	if description == "" {
+		description = t.memo
+	} else if t.memo != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(t.memo)) {
+		description = name + " - " + t.memo
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		p.statement.addError(t.line, "transaction has no NAME or MEMO")
+		return
+	}
+
+	p.statement.Rows = append(p.statement.Rows, Row{
+		Line:        t.line,
+		Date:        date,
+		Amount:      amount,
+		Description: description,
+		ExternalID:  t.fitID,
+	})
+}
+
+// parseOFXDate reads the calendar date of an OFX datetime such as
+// 20240131, 20240131120000 or 20240131120000.000[-5:EST]
+func parseOFXDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	date, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return date, nil
+}
+
+// parseOFXAmount reads an OFX amount, which may use a comma as the decimal
+// separator
+func parseOFXAmount(s string) (decimal.Decimal, error) {
+	separator := "."
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		separator = ","
+	}
+	return ParseAmount(s, separator)
+}
@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// parseFixture parses a statement file from testdata
+func parseFixture(t *testing.T, name string, parse func(io.Reader) (*Statement, error)) *Statement {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	statement, err := parse(f)
+	if err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+	return statement
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func amount(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// checkStatement compares a parsed statement with the expected rows and balances
+func checkStatement(t *testing.T, got *Statement, want Statement) {
+	t.Helper()
+	if len(got.Errors) > 0 {
+		t.Errorf("unexpected errors: %+v", got.Errors)
+	}
+	if got.Currency != want.Currency {
+		t.Errorf("currency = %q, want %q", got.Currency, want.Currency)
+	}
+	checkBalance(t, "opening balance", got.OpeningBalance, want.OpeningBalance)
+	checkBalance(t, "closing balance", got.ClosingBalance, want.ClosingBalance)
+	switch {
+	case want.BalanceDate == nil && got.BalanceDate != nil:
+		t.Errorf("balance date = %s, want none", got.BalanceDate)
+	case want.BalanceDate != nil && (got.BalanceDate == nil || !got.BalanceDate.Equal(*want.BalanceDate)):
+		t.Errorf("balance date = %v, want %s", got.BalanceDate, want.BalanceDate)
+	}
+
+	if len(got.Rows) != len(want.Rows) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got.Rows), len(want.Rows), got.Rows)
+	}
+	for i, w := range want.Rows {
+		g := got.Rows[i]
+		if g.Line != w.Line || !g.Date.Equal(w.Date) || !g.Amount.Equal(w.Amount) ||
+			g.Description != w.Description || g.ExternalID != w.ExternalID {
+			t.Errorf("row %d = {%d %s %s %q %q}, want {%d %s %s %q %q}", i,
+				g.Line, g.Date.Format("2006-01-02"), g.Amount, g.Description, g.ExternalID,
+				w.Line, w.Date.Format("2006-01-02"), w.Amount, w.Description, w.ExternalID)
+		}
+	}
+}
+
+func checkBalance(t *testing.T, name string, got, want *decimal.Decimal) {
+	t.Helper()
+	switch {
+	case want == nil && got != nil:
+		t.Errorf("%s = %s, want none", name, got)
+	case want != nil && (got == nil || !got.Equal(*want)):
+		t.Errorf("%s = %v, want %s", name, got, want)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    Statement
+	}{
+		{
+			// OFX 1.x: unclosed leaf elements, an empty MEMO, entities and a
+			// timezone suffix on the posted date
+			fixture: "ofx1_sgml.ofx",
+			want: Statement{
+				Currency:       "USD",
+				ClosingBalance: ptr(amount("3447.84")),
+				BalanceDate:    ptr(day(2025, time.January, 31)),
+				Rows: []Row{
+					{Line: 39, Date: day(2025, time.January, 3), Amount: amount("-42.17"),
+						Description: "GROCERY MART #221 - POS PURCHASE", ExternalID: "202501030001"},
+					{Line: 47, Date: day(2025, time.January, 15), Amount: amount("2500"),
+						Description: "ACME CORP PAYROLL", ExternalID: "202501150002"},
+					{Line: 54, Date: day(2025, time.January, 20), Amount: amount("-9.99"),
+						Description: "STREAMING & CO", ExternalID: "202501200003"},
+				},
+			},
+		},
+		{
+			// OFX 2.x: a credit card statement with PAYEE aggregates, a comma
+			// decimal separator and a MEMO repeating the NAME
+			fixture: "ofx2_xml.ofx",
+			want: Statement{
+				Currency:       "EUR",
+				ClosingBalance: ptr(amount("-113.50")),
+				BalanceDate:    ptr(day(2025, time.February, 28)),
+				Rows: []Row{
+					{Line: 29, Date: day(2025, time.February, 5), Amount: amount("-18.50"),
+						Description: "Café Lumière - Dinner", ExternalID: "CC-0205-01"},
+					{Line: 42, Date: day(2025, time.February, 12), Amount: amount("25"),
+						Description: "ONLINE STORE", ExternalID: "CC-0212-07"},
+					{Line: 50, Date: day(2025, time.February, 28), Amount: amount("-120"),
+						Description: "Annual card fee", ExternalID: "CC-0228-99"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			statement := parseFixture(t, tt.fixture, ParseOFX)
+			checkStatement(t, statement, tt.want)
+		})
+	}
+}
+
+func TestParseOFXRejectsInvalidFiles(t *testing.T) {
+	twoStatements := "<OFX><BANKMSGSRSV1>" +
+		"<STMTTRNRS><STMTRS><CURDEF>USD</STMTRS></STMTTRNRS>" +
+		"<STMTTRNRS><STMTRS><CURDEF>USD</STMTRS></STMTTRNRS>" +
+		"</BANKMSGSRSV1></OFX>"
+
+	tests := map[string]string{
+		"not OFX":        "Date,Amount\n2025-01-01,10\n",
+		"no statement":   "<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>",
+		"two statements": twoStatements,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseOFX(strings.NewReader(content)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseOFXReportsBadTransactions(t *testing.T) {
+	content := `<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>2025<TRNAMT>-1.00<FITID>1<NAME>Bad date</STMTTRN>
+<STMTTRN><DTPOSTED>20250101<TRNAMT>0.00<FITID>2<NAME>Zero</STMTTRN>
+<STMTTRN><DTPOSTED>20250101<TRNAMT>-3.00<NAME>No FITID</STMTTRN>
+<STMTTRN><DTPOSTED>20250101<TRNAMT>-4.00<FITID>4<NAME>Good</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	statement, err := ParseOFX(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(statement.Rows) != 1 || statement.Rows[0].ExternalID != "4" {
+		t.Errorf("rows = %+v, want only FITID 4", statement.Rows)
+	}
+	wantLines := []int{3, 4, 5}
+	if len(statement.Errors) != len(wantLines) {
+		t.Fatalf("errors = %+v, want one for each of lines %v", statement.Errors, wantLines)
+	}
+	for i, line := range wantLines {
+		if statement.Errors[i].Line != line {
+			t.Errorf("error %d on line %d, want %d", i, statement.Errors[i].Line, line)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Package importer parses bank statement files into a common set of rows that
+// can be previewed and committed as transactions.
+package importer
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Row is a single statement line. Amount is signed from the account holder's
+// point of view: negative for money leaving the account.
+type Row struct {
+	Line        int             `json:"line"`
+	Date        time.Time       `json:"date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+	ExternalID  string          `json:"external_id,omitempty"`
+}
+
+// RowError reports a statement line that could not be parsed
+type RowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Statement is the parsed content of a statement file. Currency, balances and
+// the balance date are only set by formats that carry them.
+type Statement struct {
+	Rows           []Row            `json:"rows"`
+	Errors         []RowError       `json:"errors"`
+	Currency       string           `json:"currency,omitempty"`
+	OpeningBalance *decimal.Decimal `json:"opening_balance,omitempty"`
+	ClosingBalance *decimal.Decimal `json:"closing_balance,omitempty"`
+	BalanceDate    *time.Time       `json:"balance_date,omitempty"`
+}
+
+// addError records a parse error for a line
+func (s *Statement) addError(line int, message string) {
+	s.Errors = append(s.Errors, RowError{Line: line, Message: message})
+}
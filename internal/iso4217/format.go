@@ -0,0 +1,170 @@
+package iso4217
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultLocale is the locale amounts are formatted in when a client asks
+// for none of the supported ones
+const DefaultLocale = "en"
+
+// numberFormat describes how a locale writes amounts of money
+type numberFormat struct {
+	decimal     string
+	group       string
+	symbolAfter bool
+	// spaced puts a non-breaking space between the symbol and the number
+	spaced bool
+}
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+// locales maps language tags, in lower case, to their number format. A
+// region-specific tag only needs an entry when it differs from its language.
+var locales = map[string]numberFormat{
+	"en":    {decimal: ".", group: ","},
+	"ja":    {decimal: ".", group: ","},
+	"ko":    {decimal: ".", group: ","},
+	"zh":    {decimal: ".", group: ","},
+	"th":    {decimal: ".", group: ","},
+	"he":    {decimal: ".", group: ",", symbolAfter: true, spaced: true},
+	"de":    {decimal: ",", group: ".", symbolAfter: true, spaced: true},
+	"de-ch": {decimal: ".", group: "’", spaced: true},
+	"es":    {decimal: ",", group: ".", symbolAfter: true, spaced: true},
+	"es-mx": {decimal: ".", group: ","},
+	"it":    {decimal: ",", group: ".", symbolAfter: true, spaced: true},
+	"pt":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"pt-br": {decimal: ",", group: ".", spaced: true},
+	"nl":    {decimal: ",", group: ".", spaced: true},
+	"id":    {decimal: ",", group: "."},
+	"tr":    {decimal: ",", group: "."},
+	"da":    {decimal: ",", group: ".", symbolAfter: true, spaced: true},
+	"fr":    {decimal: ",", group: narrowNbsp, symbolAfter: true, spaced: true},
+	"fr-ch": {decimal: ",", group: narrowNbsp, symbolAfter: true, spaced: true},
+	"sv":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"nb":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"fi":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"pl":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"cs":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"ru":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+	"uk":    {decimal: ",", group: nbsp, symbolAfter: true, spaced: true},
+}
+
+// Format writes an amount in the currency the way the locale does, with the
+// currency's symbol and minor units: "$1,234.56" in en, "1.234,56 €" in de
+// and "¥1,235" for yen. Unsupported locales fall back to DefaultLocale.
+func (c Currency) Format(amount decimal.Decimal, locale string) string {
+	format := lookupFormat(locale)
+
+	digits := c.Round(amount).Abs().StringFixed(c.MinorUnits)
+	whole, fraction, _ := strings.Cut(digits, ".")
+	number := groupThousands(whole, format.group)
+	if fraction != "" {
+		number += format.decimal + fraction
+	}
+
+	separator := ""
+	if format.spaced {
+		separator = nbsp
+	}
+	formatted := c.Symbol + separator + number
+	if format.symbolAfter {
+		formatted = number + separator + c.Symbol
+	}
+	if c.Round(amount).IsNegative() {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// Format formats an amount in the currency with the given code. Codes not in
+// the registry use the code as their symbol.
+func Format(amount decimal.Decimal, code, locale string) string {
+	c, err := Lookup(code)
+	if err != nil {
+		c = Currency{Code: code, MinorUnits: DefaultMinorUnits, Symbol: strings.ToUpper(code)}
+	}
+	return c.Format(amount, locale)
+}
+
+// PreferredLocale picks the supported locale a client prefers from an
+// Accept-Language header such as "fr-CH, fr;q=0.9, en;q=0.8"
+func PreferredLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if tag = strings.TrimSpace(tag); tag != "" && quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+
+	for _, candidate := range candidates {
+		if tag, ok := supportedTag(candidate.tag); ok {
+			return tag
+		}
+	}
+	return DefaultLocale
+}
+
+// supportedTag returns the most specific supported locale matching a
+// language tag
+func supportedTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	language, rest, _ := strings.Cut(tag, "-")
+	if rest != "" {
+		region, _, _ := strings.Cut(rest, "-")
+		if _, ok := locales[language+"-"+region]; ok {
+			return language + "-" + region, true
+		}
+	}
+	if _, ok := locales[language]; ok {
+		return language, true
+	}
+	return "", false
+}
+
+func lookupFormat(locale string) numberFormat {
+	if tag, ok := supportedTag(locale); ok {
+		return locales[tag]
+	}
+	return locales[DefaultLocale]
+}
+
+// groupThousands inserts separator between groups of three digits
+func groupThousands(digits, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
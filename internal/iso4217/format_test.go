@@ -0,0 +1,35 @@
+package iso4217
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount string
+		code   string
+		locale string
+		want   string
+	}{
+		{"1234.5", "JPY", "en", "¥1,235"},
+		{"-1234.4", "JPY", "en", "-¥1,234"},
+		{"0.4", "JPY", "en", "¥0"},
+		{"1234.5678", "KWD", "en", "KWD1,234.568"},
+		{"1234.5", "KWD", "en", "KWD1,234.500"},
+		{"-0.0004", "KWD", "en", "KWD0.000"},
+		{"1234.56", "USD", "en", "$1,234.56"},
+		{"1234.56", "EUR", "de", "1.234,56\u00a0€"},
+		{"1234.5678", "KWD", "de", "1.234,568\u00a0KWD"},
+		{"1234", "JPY", "de", "1.234\u00a0¥"},
+		{"5", "ABC", "en", "ABC5.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code+" "+tt.amount+" "+tt.locale, func(t *testing.T) {
+			if got := Format(decimal.RequireFromString(tt.amount), tt.code, tt.locale); got != tt.want {
+				t.Errorf("Format(%s, %s, %s) = %q, want %q", tt.amount, tt.code, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
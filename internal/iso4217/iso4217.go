@@ -0,0 +1,246 @@
+// Package iso4217 is a registry of ISO 4217 currencies with their minor units
+// and display symbols. It validates currency codes, rounds amounts to the
+// precision a currency is kept in and formats amounts for display.
+package iso4217
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Currency is an ISO 4217 currency. MinorUnits is the number of decimal
+// places amounts in the currency have: 2 for USD, 0 for JPY, 3 for KWD.
+type Currency struct {
+	Code       string `json:"code" example:"USD"`
+	Number     string `json:"number" example:"840"`
+	Name       string `json:"name" example:"US Dollar"`
+	MinorUnits int32  `json:"minor_units" example:"2"`
+	Symbol     string `json:"symbol" example:"$"`
+}
+
+// MaxMinorUnits is the largest number of minor units of any currency, and the
+// scale amounts are stored at
+const MaxMinorUnits = 4
+
+// DefaultMinorUnits is used for codes missing from the registry, such as
+// those of currencies withdrawn since an amount was recorded
+const DefaultMinorUnits = 2
+
+// Lookup returns the currency with the given code, ignoring case and
+// surrounding space
+func Lookup(code string) (Currency, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	c, ok := registry[normalized]
+	if !ok {
+		if len(normalized) != 3 {
+			return Currency{}, fmt.Errorf("currency must be a 3-letter ISO 4217 code (e.g., USD, EUR), got %q", code)
+		}
+		return Currency{}, fmt.Errorf("unknown currency code %q", code)
+	}
+	return c, nil
+}
+
+// All returns every currency in the registry ordered by code
+func All() []Currency {
+	currencies := make([]Currency, 0, len(registry))
+	for _, c := range registry {
+		currencies = append(currencies, c)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i].Code < currencies[j].Code })
+	return currencies
+}
+
+// MinorUnits returns the minor units of the currency with the given code,
+// or DefaultMinorUnits when it is not in the registry
+func MinorUnits(code string) int32 {
+	if c, ok := registry[strings.ToUpper(strings.TrimSpace(code))]; ok {
+		return c.MinorUnits
+	}
+	return DefaultMinorUnits
+}
+
+// Round rounds an amount in the currency with the given code to its minor
+// units, half away from zero
+func Round(amount decimal.Decimal, code string) decimal.Decimal {
+	return amount.Round(MinorUnits(code))
+}
+
+// Round rounds an amount to the currency's minor units, half away from zero
+func (c Currency) Round(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(c.MinorUnits)
+}
+
+// registry holds the active ISO 4217 currencies. Fund codes, precious metals
+// and other codes without minor units are left out, as amounts in them are
+// not bookable.
+var registry = buildRegistry([]Currency{
+	{"AED", "784", "UAE Dirham", 2, "AED"},
+	{"AFN", "971", "Afghani", 2, "؋"},
+	{"ALL", "008", "Lek", 2, "L"},
+	{"AMD", "051", "Armenian Dram", 2, "֏"},
+	{"AOA", "973", "Kwanza", 2, "Kz"},
+	{"ARS", "032", "Argentine Peso", 2, "ARS"},
+	{"AUD", "036", "Australian Dollar", 2, "A$"},
+	{"AWG", "533", "Aruban Florin", 2, "Afl."},
+	{"AZN", "944", "Azerbaijan Manat", 2, "₼"},
+	{"BAM", "977", "Convertible Mark", 2, "KM"},
+	{"BBD", "052", "Barbados Dollar", 2, "Bds$"},
+	{"BDT", "050", "Taka", 2, "৳"},
+	{"BGN", "975", "Bulgarian Lev", 2, "лв."},
+	{"BHD", "048", "Bahraini Dinar", 3, "BHD"},
+	{"BIF", "108", "Burundi Franc", 0, "FBu"},
+	{"BMD", "060", "Bermudian Dollar", 2, "BD$"},
+	{"BND", "096", "Brunei Dollar", 2, "B$"},
+	{"BOB", "068", "Boliviano", 2, "Bs"},
+	{"BRL", "986", "Brazilian Real", 2, "R$"},
+	{"BSD", "044", "Bahamian Dollar", 2, "B$"},
+	{"BTN", "064", "Ngultrum", 2, "Nu."},
+	{"BWP", "072", "Pula", 2, "P"},
+	{"BYN", "933", "Belarusian Ruble", 2, "Br"},
+	{"BZD", "084", "Belize Dollar", 2, "BZ$"},
+	{"CAD", "124", "Canadian Dollar", 2, "CA$"},
+	{"CDF", "976", "Congolese Franc", 2, "FC"},
+	{"CHF", "756", "Swiss Franc", 2, "CHF"},
+	{"CLF", "990", "Unidad de Fomento", 4, "UF"},
+	{"CLP", "152", "Chilean Peso", 0, "CLP"},
+	{"CNY", "156", "Yuan Renminbi", 2, "CN¥"},
+	{"COP", "170", "Colombian Peso", 2, "COP"},
+	{"CRC", "188", "Costa Rican Colon", 2, "₡"},
+	{"CUP", "192", "Cuban Peso", 2, "CUP"},
+	{"CVE", "132", "Cabo Verde Escudo", 2, "Esc"},
+	{"CZK", "203", "Czech Koruna", 2, "Kč"},
+	{"DJF", "262", "Djibouti Franc", 0, "Fdj"},
+	{"DKK", "208", "Danish Krone", 2, "kr."},
+	{"DOP", "214", "Dominican Peso", 2, "RD$"},
+	{"DZD", "012", "Algerian Dinar", 2, "DA"},
+	{"EGP", "818", "Egyptian Pound", 2, "E£"},
+	{"ERN", "232", "Nakfa", 2, "Nfk"},
+	{"ETB", "230", "Ethiopian Birr", 2, "Br"},
+	{"EUR", "978", "Euro", 2, "€"},
+	{"FJD", "242", "Fiji Dollar", 2, "FJ$"},
+	{"FKP", "238", "Falkland Islands Pound", 2, "FK£"},
+	{"GBP", "826", "Pound Sterling", 2, "£"},
+	{"GEL", "981", "Lari", 2, "₾"},
+	{"GHS", "936", "Ghana Cedi", 2, "GH₵"},
+	{"GIP", "292", "Gibraltar Pound", 2, "£"},
+	{"GMD", "270", "Dalasi", 2, "D"},
+	{"GNF", "324", "Guinean Franc", 0, "FG"},
+	{"GTQ", "320", "Quetzal", 2, "Q"},
+	{"GYD", "328", "Guyana Dollar", 2, "GY$"},
+	{"HKD", "344", "Hong Kong Dollar", 2, "HK$"},
+	{"HNL", "340", "Lempira", 2, "L"},
+	{"HTG", "332", "Gourde", 2, "G"},
+	{"HUF", "348", "Forint", 2, "Ft"},
+	{"IDR", "360", "Rupiah", 2, "Rp"},
+	{"ILS", "376", "New Israeli Sheqel", 2, "₪"},
+	{"INR", "356", "Indian Rupee", 2, "₹"},
+	{"IQD", "368", "Iraqi Dinar", 3, "IQD"},
+	{"IRR", "364", "Iranian Rial", 2, "IRR"},
+	{"ISK", "352", "Iceland Krona", 0, "kr"},
+	{"JMD", "388", "Jamaican Dollar", 2, "J$"},
+	{"JOD", "400", "Jordanian Dinar", 3, "JOD"},
+	{"JPY", "392", "Yen", 0, "¥"},
+	{"KES", "404", "Kenyan Shilling", 2, "KSh"},
+	{"KGS", "417", "Som", 2, "сом"},
+	{"KHR", "116", "Riel", 2, "៛"},
+	{"KMF", "174", "Comorian Franc", 0, "CF"},
+	{"KPW", "408", "North Korean Won", 2, "KPW"},
+	{"KRW", "410", "Won", 0, "₩"},
+	{"KWD", "414", "Kuwaiti Dinar", 3, "KWD"},
+	{"KYD", "136", "Cayman Islands Dollar", 2, "CI$"},
+	{"KZT", "398", "Tenge", 2, "₸"},
+	{"LAK", "418", "Lao Kip", 2, "₭"},
+	{"LBP", "422", "Lebanese Pound", 2, "LBP"},
+	{"LKR", "144", "Sri Lanka Rupee", 2, "Rs"},
+	{"LRD", "430", "Liberian Dollar", 2, "L$"},
+	{"LSL", "426", "Loti", 2, "L"},
+	{"LYD", "434", "Libyan Dinar", 3, "LD"},
+	{"MAD", "504", "Moroccan Dirham", 2, "MAD"},
+	{"MDL", "498", "Moldovan Leu", 2, "L"},
+	{"MGA", "969", "Malagasy Ariary", 2, "Ar"},
+	{"MKD", "807", "Denar", 2, "ден"},
+	{"MMK", "104", "Kyat", 2, "K"},
+	{"MNT", "496", "Tugrik", 2, "₮"},
+	{"MOP", "446", "Pataca", 2, "MOP$"},
+	{"MRU", "929", "Ouguiya", 2, "UM"},
+	{"MUR", "480", "Mauritius Rupee", 2, "Rs"},
+	{"MVR", "462", "Rufiyaa", 2, "Rf"},
+	{"MWK", "454", "Malawi Kwacha", 2, "MK"},
+	{"MXN", "484", "Mexican Peso", 2, "MX$"},
+	{"MYR", "458", "Malaysian Ringgit", 2, "RM"},
+	{"MZN", "943", "Mozambique Metical", 2, "MT"},
+	{"NAD", "516", "Namibia Dollar", 2, "N$"},
+	{"NGN", "566", "Naira", 2, "₦"},
+	{"NIO", "558", "Cordoba Oro", 2, "C$"},
+	{"NOK", "578", "Norwegian Krone", 2, "kr"},
+	{"NPR", "524", "Nepalese Rupee", 2, "Rs"},
+	{"NZD", "554", "New Zealand Dollar", 2, "NZ$"},
+	{"OMR", "512", "Rial Omani", 3, "OMR"},
+	{"PAB", "590", "Balboa", 2, "B/."},
+	{"PEN", "604", "Sol", 2, "S/"},
+	{"PGK", "598", "Kina", 2, "K"},
+	{"PHP", "608", "Philippine Peso", 2, "₱"},
+	{"PKR", "586", "Pakistan Rupee", 2, "Rs"},
+	{"PLN", "985", "Zloty", 2, "zł"},
+	{"PYG", "600", "Guarani", 0, "₲"},
+	{"QAR", "634", "Qatari Rial", 2, "QAR"},
+	{"RON", "946", "Romanian Leu", 2, "lei"},
+	{"RSD", "941", "Serbian Dinar", 2, "RSD"},
+	{"RUB", "643", "Russian Ruble", 2, "₽"},
+	{"RWF", "646", "Rwanda Franc", 0, "FRw"},
+	{"SAR", "682", "Saudi Riyal", 2, "SAR"},
+	{"SBD", "090", "Solomon Islands Dollar", 2, "SI$"},
+	{"SCR", "690", "Seychelles Rupee", 2, "SR"},
+	{"SDG", "938", "Sudanese Pound", 2, "SDG"},
+	{"SEK", "752", "Swedish Krona", 2, "kr"},
+	{"SGD", "702", "Singapore Dollar", 2, "S$"},
+	{"SHP", "654", "Saint Helena Pound", 2, "£"},
+	{"SLE", "925", "Leone", 2, "Le"},
+	{"SOS", "706", "Somali Shilling", 2, "Sh"},
+	{"SRD", "968", "Surinam Dollar", 2, "Sr$"},
+	{"SSP", "728", "South Sudanese Pound", 2, "SSP"},
+	{"STN", "930", "Dobra", 2, "Db"},
+	{"SVC", "222", "El Salvador Colon", 2, "₡"},
+	{"SYP", "760", "Syrian Pound", 2, "SYP"},
+	{"SZL", "748", "Lilangeni", 2, "E"},
+	{"THB", "764", "Baht", 2, "฿"},
+	{"TJS", "972", "Somoni", 2, "SM"},
+	{"TMT", "934", "Turkmenistan New Manat", 2, "m"},
+	{"TND", "788", "Tunisian Dinar", 3, "DT"},
+	{"TOP", "776", "Pa’anga", 2, "T$"},
+	{"TRY", "949", "Turkish Lira", 2, "₺"},
+	{"TTD", "780", "Trinidad and Tobago Dollar", 2, "TT$"},
+	{"TWD", "901", "New Taiwan Dollar", 2, "NT$"},
+	{"TZS", "834", "Tanzanian Shilling", 2, "TSh"},
+	{"UAH", "980", "Hryvnia", 2, "₴"},
+	{"UGX", "800", "Uganda Shilling", 0, "USh"},
+	{"USD", "840", "US Dollar", 2, "$"},
+	{"UYU", "858", "Peso Uruguayo", 2, "$U"},
+	{"UYW", "927", "Unidad Previsional", 4, "UYW"},
+	{"UZS", "860", "Uzbekistan Sum", 2, "UZS"},
+	{"VED", "926", "Bolívar Soberano", 2, "Bs.D"},
+	{"VES", "928", "Bolívar Soberano", 2, "Bs.S"},
+	{"VND", "704", "Dong", 0, "₫"},
+	{"VUV", "548", "Vatu", 0, "VT"},
+	{"WST", "882", "Tala", 2, "WS$"},
+	{"XAF", "950", "CFA Franc BEAC", 0, "FCFA"},
+	{"XCD", "951", "East Caribbean Dollar", 2, "EC$"},
+	{"XCG", "532", "Caribbean Guilder", 2, "Cg"},
+	{"XOF", "952", "CFA Franc BCEAO", 0, "F CFA"},
+	{"XPF", "953", "CFP Franc", 0, "CFPF"},
+	{"YER", "886", "Yemeni Rial", 2, "YER"},
+	{"ZAR", "710", "Rand", 2, "R"},
+	{"ZMW", "967", "Zambian Kwacha", 2, "ZK"},
+	{"ZWG", "924", "Zimbabwe Gold", 2, "ZiG"},
+})
+
+func buildRegistry(currencies []Currency) map[string]Currency {
+	registry := make(map[string]Currency, len(currencies))
+	for _, c := range currencies {
+		registry[c.Code] = c
+	}
+	return registry
+}
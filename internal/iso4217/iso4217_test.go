@@ -0,0 +1,74 @@
+package iso4217
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		amount string
+		code   string
+		want   string
+	}{
+		{"1234.5", "JPY", "1235"},
+		{"1234.4999", "JPY", "1234"},
+		{"-0.5", "JPY", "-1"},
+		{"0.4", "JPY", "0"},
+		{"12.3456", "KWD", "12.346"},
+		{"12.3455", "KWD", "12.346"},
+		{"-12.3455", "KWD", "-12.346"},
+		{"0.0004", "KWD", "0"},
+		{"0.0005", "KWD", "0.001"},
+		{"10.005", "USD", "10.01"},
+		{"-10.005", "USD", "-10.01"},
+		{"1.23456", "CLF", "1.2346"},
+		{"7.5", "jpy", "8"},
+		{"1.005", "XXX", "1.01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code+" "+tt.amount, func(t *testing.T) {
+			got := Round(decimal.RequireFromString(tt.amount), tt.code)
+			if want := decimal.RequireFromString(tt.want); !got.Equal(want) {
+				t.Errorf("Round(%s, %s) = %s, want %s", tt.amount, tt.code, got, want)
+			}
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := map[string]int32{
+		"JPY":   0,
+		"ISK":   0,
+		"USD":   2,
+		"EUR":   2,
+		"KWD":   3,
+		"BHD":   3,
+		"CLF":   4,
+		" kwd ": 3,
+		"XXX":   DefaultMinorUnits,
+	}
+	for code, want := range tests {
+		if got := MinorUnits(code); got != want {
+			t.Errorf("MinorUnits(%q) = %d, want %d", code, got, want)
+		}
+	}
+
+	for _, c := range All() {
+		if c.MinorUnits > MaxMinorUnits {
+			t.Errorf("%s has %d minor units, more than the stored scale %d", c.Code, c.MinorUnits, MaxMinorUnits)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if c, err := Lookup(" jpy "); err != nil || c.Code != "JPY" {
+		t.Errorf("Lookup(jpy) = %+v, %v; want JPY", c, err)
+	}
+	for _, code := range []string{"", "US", "USDX", "ZZZ"} {
+		if _, err := Lookup(code); err == nil {
+			t.Errorf("Lookup(%q) succeeded, want an error", code)
+		}
+	}
+}
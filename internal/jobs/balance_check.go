@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// RunBalanceCheck checks every account for balance drift once at startup and
+// then on every interval until ctx is cancelled. A zero interval only runs the
+// startup check.
+func RunBalanceCheck(ctx context.Context, accountService services.AccountService, interval time.Duration) {
+	checkBalances(accountService)
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkBalances(accountService)
+		}
+	}
+}
+
+// checkBalances logs every account whose stored balance differs from its computed balance
+func checkBalances(accountService services.AccountService) {
+	mismatches, err := accountService.FindBalanceMismatches()
+	if err != nil {
+		log.Printf("Balance check failed: %v", err)
+		return
+	}
+
+	for _, m := range mismatches {
+		log.Printf("Balance mismatch for account %s (%s, user %s): stored %s, computed %s, difference %s",
+			m.AccountID, m.AccountName, m.UserID, m.StoredBalance, m.ComputedBalance, m.Difference)
+	}
+
+	if len(mismatches) == 0 {
+		log.Println("Balance check completed: all account balances match their transactions")
+	}
+}
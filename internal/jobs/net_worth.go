@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// RunNetWorthSnapshots records every user's net worth once at startup and
+// then on every interval until ctx is cancelled. A zero interval only runs
+// the startup pass.
+func RunNetWorthSnapshots(ctx context.Context, netWorthService services.NetWorthService, interval time.Duration) {
+	takeSnapshots(netWorthService)
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			takeSnapshots(netWorthService)
+		}
+	}
+}
+
+// takeSnapshots records today's net worth, replacing any snapshot taken earlier today
+func takeSnapshots(netWorthService services.NetWorthService) {
+	taken, err := netWorthService.TakeSnapshots(time.Now())
+	if err != nil {
+		log.Printf("Net worth snapshot failed: %v", err)
+	}
+	if taken > 0 {
+		log.Printf("Recorded %d net worth snapshot(s)", taken)
+	}
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// RunRecurringTransactions generates due recurring transactions once at
+// startup and then on every interval until ctx is cancelled. A zero interval
+// only runs the startup pass.
+func RunRecurringTransactions(ctx context.Context, recurringService services.RecurringService, interval time.Duration) {
+	generateRecurring(recurringService)
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			generateRecurring(recurringService)
+		}
+	}
+}
+
+// generateRecurring materializes every occurrence due today or earlier
+func generateRecurring(recurringService services.RecurringService) {
+	generated, err := recurringService.GenerateDue(time.Now())
+	if err != nil {
+		log.Printf("Recurring transaction generation failed: %v", err)
+	}
+	if generated > 0 {
+		log.Printf("Generated %d recurring transaction(s)", generated)
+	}
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vasujain275/expense-tracker-api/internal/services"
+)
+
+// RunStatementGeneration generates the statements of closed credit card
+// billing cycles once at startup and then on every interval until ctx is
+// cancelled. A zero interval only runs the startup pass.
+func RunStatementGeneration(ctx context.Context, creditCardService services.CreditCardService, interval time.Duration) {
+	generateStatements(creditCardService)
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			generateStatements(creditCardService)
+		}
+	}
+}
+
+// generateStatements creates a statement for every billing cycle closed before today
+func generateStatements(creditCardService services.CreditCardService) {
+	generated, err := creditCardService.GenerateDue(time.Now())
+	if err != nil {
+		log.Printf("Credit card statement generation failed: %v", err)
+	}
+	if generated > 0 {
+		log.Printf("Generated %d credit card statement(s)", generated)
+	}
+}
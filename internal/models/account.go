@@ -13,18 +13,59 @@ type AccountType string
 const (
 	AccountTypeBank       AccountType = "bank"
 	AccountTypeCash       AccountType = "cash"
+	AccountTypeSavings    AccountType = "savings"
+	AccountTypeInvestment AccountType = "investment"
 	AccountTypeCreditCard AccountType = "credit_card"
+	AccountTypeLoan       AccountType = "loan"
 )
 
+// AccountClass tells whether an account holds what its owner owns or owes
+type AccountClass string
+
+const (
+	AccountClassAsset     AccountClass = "asset"
+	AccountClassLiability AccountClass = "liability"
+)
+
+// Class returns whether accounts of the type are assets or liabilities.
+// Balances are signed the same way for both: money flowing in is positive,
+// so a liability's balance is negative while money is owed on it.
+func (t AccountType) Class() AccountClass {
+	switch t {
+	case AccountTypeCreditCard, AccountTypeLoan:
+		return AccountClassLiability
+	default:
+		return AccountClassAsset
+	}
+}
+
 type Account struct {
-	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID    uuid.UUID       `json:"user_id" gorm:"type:uuid;not null"`
-	Name      string          `json:"name" gorm:"not null"`
-	Type      AccountType     `json:"type" gorm:"not null"`
-	Balance   decimal.Decimal `json:"balance" gorm:"type:decimal(15,2);not null;default:0"`
-	IsActive  bool            `json:"is_active" gorm:"not null;default:true"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID         uuid.UUID       `json:"user_id" gorm:"type:uuid;not null"`
+	Name           string          `json:"name" gorm:"not null"`
+	Type           AccountType     `json:"type" gorm:"not null"`
+	Currency       string          `json:"currency" gorm:"type:char(3);not null"`
+	OpeningBalance decimal.Decimal `json:"opening_balance" gorm:"type:decimal(19,4);not null;default:0"`
+	Balance        decimal.Decimal `json:"balance" gorm:"type:decimal(19,4);not null;default:0"`
+	IsActive       bool            `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+
+	// Credit card terms, only set on credit card accounts. Statements are
+	// generated once both the closing day and the due day are set.
+	CreditLimit         *decimal.Decimal `json:"credit_limit,omitempty" gorm:"type:decimal(19,4)"`
+	StatementClosingDay *int             `json:"statement_closing_day,omitempty"`
+	PaymentDueDay       *int             `json:"payment_due_day,omitempty"`
+	APR                 *decimal.Decimal `json:"apr,omitempty" gorm:"column:apr;type:decimal(7,4)"`
+
+	// AvailableCredit is the part of the credit limit not used by the balance
+	AvailableCredit *decimal.Decimal `json:"available_credit,omitempty" gorm:"-"`
+
+	// Amounts formatted for display in the requested locale
+	FormattedOpeningBalance  string `json:"formatted_opening_balance,omitempty" gorm:"-" example:"$1,000.00"`
+	FormattedBalance         string `json:"formatted_balance,omitempty" gorm:"-" example:"$1,234.56"`
+	FormattedCreditLimit     string `json:"formatted_credit_limit,omitempty" gorm:"-" example:"$5,000.00"`
+	FormattedAvailableCredit string `json:"formatted_available_credit,omitempty" gorm:"-" example:"$3,765.44"`
 
 	// Relationships
 	User         User          `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -39,6 +80,25 @@ func (a *Account) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// AfterFind fills in the available credit of accounts read from the database
+func (a *Account) AfterFind(tx *gorm.DB) error {
+	a.UpdateAvailableCredit()
+	return nil
+}
+
+// UpdateAvailableCredit computes the available credit from the credit limit
+// and the balance. A credit card's balance is negative while money is owed,
+// so an overpaid card has more credit available than its limit. Accounts
+// without a credit limit have no available credit.
+func (a *Account) UpdateAvailableCredit() {
+	if a.CreditLimit == nil {
+		a.AvailableCredit = nil
+		return
+	}
+	available := a.CreditLimit.Add(a.Balance)
+	a.AvailableCredit = &available
+}
+
 // TableName specifies the table name for Account models
 func (Account) TableName() string {
 	return "accounts"
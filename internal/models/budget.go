@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type BudgetPeriod string
+
+const (
+	BudgetPeriodWeekly  BudgetPeriod = "weekly"
+	BudgetPeriodMonthly BudgetPeriod = "monthly"
+	BudgetPeriodYearly  BudgetPeriod = "yearly"
+)
+
+// Budget caps spending across one or more categories for each period. With
+// Rollover enabled, the unspent part of a period is added to the next one.
+type Budget struct {
+	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID       `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name      string          `json:"name" gorm:"not null"`
+	Amount    decimal.Decimal `json:"amount" gorm:"type:decimal(19,4);not null"`
+	Period    BudgetPeriod    `json:"period" gorm:"not null"`
+	Rollover  bool            `json:"rollover" gorm:"not null;default:false"`
+	StartDate time.Time       `json:"start_date" gorm:"not null"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+
+	// Relationships
+	Categories []Category `json:"categories" gorm:"many2many:budget_categories"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (b *Budget) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Budget model
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// PeriodStart returns the start of the calendar period containing t. Weekly
+// periods start on Monday.
+func (p BudgetPeriod) PeriodStart(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch p {
+	case BudgetPeriodWeekly:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case BudgetPeriodYearly:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// AddPeriods shifts a period start by n periods
+func (p BudgetPeriod) AddPeriods(start time.Time, n int) time.Time {
+	switch p {
+	case BudgetPeriodWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case BudgetPeriodYearly:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, n, 0)
+	}
+}
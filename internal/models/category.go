@@ -14,8 +14,12 @@ const (
 	CategoryTypeExpense CategoryType = "expense"
 )
 
+// Category is owned by a single user. Categories nest below a parent of the
+// same type, such as Food > Restaurants > Coffee.
 type Category struct {
 	ID        uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID    `json:"user_id" gorm:"type:uuid;not null;index"`
+	ParentID  *uuid.UUID   `json:"parent_id,omitempty" gorm:"type:uuid;index"`
 	Name      string       `json:"name" gorm:"not null"`
 	Type      CategoryType `json:"type" gorm:"not null"`
 	Color     string       `json:"color" gorm:"not null;default:'#007bff'"`
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type StatementStatus string
+
+const (
+	// StatementPaid statements were paid in full, or had nothing to pay
+	StatementPaid StatementStatus = "paid"
+	// StatementMinimumPaid statements got at least the minimum payment
+	StatementMinimumPaid StatementStatus = "minimum_paid"
+	// StatementDue statements still need a payment before their due date
+	StatementDue StatementStatus = "due"
+	// StatementOverdue statements did not get the minimum payment by their due date
+	StatementOverdue StatementStatus = "overdue"
+)
+
+// CreditCardStatement summarizes one closed billing cycle of a credit card
+// account. The cycle runs from PeriodStart to PeriodEnd, the closing date,
+// both inclusive. Balances are amounts owed, so they are positive while the
+// card is in debt. Statements are not changed once generated, like the ones
+// a card issuer sends; the credit limit and APR are those at closing.
+type CreditCardStatement struct {
+	ID               uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AccountID        uuid.UUID        `json:"account_id" gorm:"type:uuid;not null"`
+	PeriodStart      time.Time        `json:"period_start" gorm:"type:date;not null"`
+	PeriodEnd        time.Time        `json:"period_end" gorm:"type:date;not null"`
+	DueDate          time.Time        `json:"due_date" gorm:"type:date;not null"`
+	PreviousBalance  decimal.Decimal  `json:"previous_balance" gorm:"type:decimal(19,4);not null"`
+	Charges          decimal.Decimal  `json:"charges" gorm:"type:decimal(19,4);not null"`
+	Credits          decimal.Decimal  `json:"credits" gorm:"type:decimal(19,4);not null"`
+	StatementBalance decimal.Decimal  `json:"statement_balance" gorm:"type:decimal(19,4);not null"`
+	MinimumPayment   decimal.Decimal  `json:"minimum_payment" gorm:"type:decimal(19,4);not null"`
+	CreditLimit      *decimal.Decimal `json:"credit_limit,omitempty" gorm:"type:decimal(19,4)"`
+	APR              *decimal.Decimal `json:"apr,omitempty" gorm:"column:apr;type:decimal(7,4)"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	// Filled in when statements are read: the account's currency, and the
+	// payment tracking. PaidAmount is the total credited to the account after
+	// the closing date up to and including the due date.
+	Currency   string          `json:"currency" gorm:"-"`
+	PaidAmount decimal.Decimal `json:"paid_amount" gorm:"-"`
+	Status     StatementStatus `json:"status" gorm:"-"`
+
+	// Amounts formatted for display in the requested locale
+	FormattedStatementBalance string `json:"formatted_statement_balance,omitempty" gorm:"-" example:"$1,234.56"`
+	FormattedMinimumPayment   string `json:"formatted_minimum_payment,omitempty" gorm:"-" example:"$25.00"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (s *CreditCardStatement) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CreditCardStatement model
+func (CreditCardStatement) TableName() string {
+	return "credit_card_statements"
+}
+
+// NextClosingDate returns the first statement closing date on or after the
+// UTC date of t. Months shorter than the closing day close on their last day.
+func NextClosingDate(t time.Time, closingDay int) time.Time {
+	year, month, day := t.UTC().Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	closing := dayOfMonth(year, month, closingDay)
+	if closing.Before(date) {
+		closing = dayOfMonth(year, month+1, closingDay)
+	}
+	return closing
+}
+
+// PaymentDueDate returns the first date after a statement's closing date that
+// falls on the payment due day
+func PaymentDueDate(closing time.Time, dueDay int) time.Time {
+	year, month, _ := closing.UTC().Date()
+	due := dayOfMonth(year, month, dueDay)
+	if !due.After(closing) {
+		due = dayOfMonth(year, month+1, dueDay)
+	}
+	return due
+}
+
+// dayOfMonth returns the given day of a month, or the month's last day when
+// it is shorter
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ExchangeRate is the price of one unit of BaseCurrency in QuoteCurrency on
+// a date. Reports convert amounts with the latest rate on or before the
+// transaction date, using the inverse of a rate quoted the other way round
+// when needed.
+type ExchangeRate struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        uuid.UUID       `json:"user_id" gorm:"type:uuid;not null"`
+	BaseCurrency  string          `json:"base_currency" gorm:"type:char(3);not null"`
+	QuoteCurrency string          `json:"quote_currency" gorm:"type:char(3);not null"`
+	Rate          decimal.Decimal `json:"rate" gorm:"type:decimal(20,10);not null"`
+	Date          time.Time       `json:"date" gorm:"type:date;not null"`
+	Source        string          `json:"source" gorm:"not null;default:'api'"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (e *ExchangeRate) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ExchangeRate model
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportProfile is a saved column mapping for importing CSV bank statements.
+// Columns are referenced by header name, or by 1-based position when the file
+// has no header row. Imported rows are assigned DefaultCategoryID.
+type ImportProfile struct {
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID            uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name              string    `json:"name" gorm:"not null"`
+	Delimiter         string    `json:"delimiter" gorm:"not null;default:','"`
+	HasHeader         bool      `json:"has_header" gorm:"not null;default:true"`
+	SkipRows          int       `json:"skip_rows" gorm:"not null;default:0"`
+	DateColumn        string    `json:"date_column" gorm:"not null"`
+	AmountColumn      string    `json:"amount_column" gorm:"not null;default:''"`
+	DebitColumn       string    `json:"debit_column" gorm:"not null;default:''"`
+	CreditColumn      string    `json:"credit_column" gorm:"not null;default:''"`
+	DescriptionColumn string    `json:"description_column" gorm:"not null"`
+	ExternalIDColumn  string    `json:"external_id_column" gorm:"not null;default:''"`
+	DateFormat        string    `json:"date_format" gorm:"not null"`
+	DecimalSeparator  string    `json:"decimal_separator" gorm:"not null;default:'.'"`
+	SignConvention    string    `json:"sign_convention" gorm:"not null;default:'negative_is_debit'"`
+	DefaultCategoryID uuid.UUID `json:"default_category_id" gorm:"type:uuid;not null"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (p *ImportProfile) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ImportProfile model
+func (ImportProfile) TableName() string {
+	return "import_profiles"
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// NetWorthSnapshot records a user's net worth on a day, in the currency the
+// user had then. Liabilities are amounts owed, so they are positive while
+// the user is in debt, and NetWorth is Assets minus Liabilities.
+type NetWorthSnapshot struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID       `json:"user_id" gorm:"type:uuid;not null"`
+	Date        time.Time       `json:"date" gorm:"type:date;not null"`
+	Currency    string          `json:"currency" gorm:"type:char(3);not null"`
+	Assets      decimal.Decimal `json:"assets" gorm:"type:decimal(19,4);not null"`
+	Liabilities decimal.Decimal `json:"liabilities" gorm:"type:decimal(19,4);not null"`
+	NetWorth    decimal.Decimal `json:"net_worth" gorm:"type:decimal(19,4);not null"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+
+	// Net worth formatted for display in the requested locale
+	FormattedNetWorth string `json:"formatted_net_worth,omitempty" gorm:"-" example:"$12,345.67"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (n *NetWorthSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for NetWorthSnapshot model
+func (NetWorthSnapshot) TableName() string {
+	return "net_worth_snapshots"
+}
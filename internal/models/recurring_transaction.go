@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// RecurringTransaction is a template that generates a transaction on every
+// occurrence of its RRULE schedule. NextOccurrence is the earliest occurrence
+// not generated yet and is nil once the schedule has ended.
+type RecurringTransaction struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID         uuid.UUID       `json:"user_id" gorm:"type:uuid;not null;index"`
+	AccountID      uuid.UUID       `json:"account_id" gorm:"type:uuid;not null"`
+	CategoryID     uuid.UUID       `json:"category_id" gorm:"type:uuid;not null"`
+	Amount         decimal.Decimal `json:"amount" gorm:"type:decimal(19,4);not null"`
+	Description    string          `json:"description" gorm:"not null"`
+	RRule          string          `json:"rrule" gorm:"column:rrule;not null"`
+	StartDate      time.Time       `json:"start_date" gorm:"not null"`
+	NextOccurrence *time.Time      `json:"next_occurrence"`
+	IsActive       bool            `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+
+	// Relationships
+	Account    Account              `json:"account,omitempty" gorm:"foreignKey:AccountID"`
+	Category   *Category            `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Exceptions []RecurringException `json:"exceptions,omitempty" gorm:"foreignKey:RecurringID"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (r *RecurringTransaction) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RecurringTransaction model
+func (RecurringTransaction) TableName() string {
+	return "recurring_transactions"
+}
+
+type RecurringExceptionAction string
+
+const (
+	RecurringExceptionSkip   RecurringExceptionAction = "skip"
+	RecurringExceptionModify RecurringExceptionAction = "modify"
+)
+
+// RecurringException overrides a single occurrence of a recurring transaction.
+// Modify exceptions replace the non-nil fields of the generated transaction.
+type RecurringException struct {
+	ID             uuid.UUID                `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RecurringID    uuid.UUID                `json:"recurring_id" gorm:"type:uuid;not null"`
+	OccurrenceDate time.Time                `json:"occurrence_date" gorm:"not null"`
+	Action         RecurringExceptionAction `json:"action" gorm:"not null"`
+	Amount         *decimal.Decimal         `json:"amount,omitempty" gorm:"type:decimal(19,4)"`
+	Description    *string                  `json:"description,omitempty"`
+	CategoryID     *uuid.UUID               `json:"category_id,omitempty" gorm:"type:uuid"`
+	Date           *time.Time               `json:"date,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (e *RecurringException) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RecurringException model
+func (RecurringException) TableName() string {
+	return "recurring_exceptions"
+}
@@ -12,17 +12,36 @@ type Transaction struct {
 	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	UserID      uuid.UUID       `json:"user_id" gorm:"type:uuid;not null;index"`
 	AccountID   uuid.UUID       `json:"account_id" gorm:"type:uuid;not null"`
-	CategoryID  uuid.UUID       `json:"category_id" gorm:"type:uuid;not null"`
-	Amount      decimal.Decimal `json:"amount" gorm:"type:decimal(15,2);not null"`
+	CategoryID  *uuid.UUID      `json:"category_id,omitempty" gorm:"type:uuid"`
+	TransferID  *uuid.UUID      `json:"transfer_id,omitempty" gorm:"type:uuid;index"`
+	Amount      decimal.Decimal `json:"amount" gorm:"type:decimal(19,4);not null"`
 	Description string          `json:"description" gorm:"not null"`
 	Date        time.Time       `json:"date" gorm:"not null;index"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 
+	// Set on transactions generated from a recurring transaction
+	RecurringID    *uuid.UUID `json:"recurring_id,omitempty" gorm:"type:uuid"`
+	OccurrenceDate *time.Time `json:"occurrence_date,omitempty"`
+
+	// Set on purchases made in a foreign currency: the amount charged in that
+	// currency, while Amount is what was booked on the account
+	OriginalAmount   *decimal.Decimal `json:"original_amount,omitempty" gorm:"type:decimal(19,4)"`
+	OriginalCurrency *string          `json:"original_currency,omitempty" gorm:"type:char(3)"`
+
+	// Identifier assigned by the bank to an imported statement line
+	ExternalID *string `json:"external_id,omitempty"`
+
+	// Amounts formatted for display in the requested locale
+	FormattedAmount         string `json:"formatted_amount,omitempty" gorm:"-" example:"-$42.50"`
+	FormattedOriginalAmount string `json:"formatted_original_amount,omitempty" gorm:"-" example:"-39,20 €"`
+
 	// Relationships
-	User     User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	Account  Account  `json:"account,omitempty" gorm:"foreignKey:AccountID"`
-	Category Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	User     User               `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Account  Account            `json:"account,omitempty" gorm:"foreignKey:AccountID"`
+	Category *Category          `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Splits   []TransactionSplit `json:"splits,omitempty" gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE"`
+	Tags     []Tag              `json:"tags,omitempty" gorm:"many2many:transaction_tags"`
 }
 
 // BeforeCreate will set a UUID rather than numeric ID
@@ -38,6 +57,16 @@ func (Transaction) TableName() string {
 	return "transactions"
 }
 
+// IsTransfer returns true if the transaction is one leg of an account-to-account transfer
+func (t *Transaction) IsTransfer() bool {
+	return t.TransferID != nil
+}
+
+// IsSplit returns true if the transaction's amount is allocated across split lines
+func (t *Transaction) IsSplit() bool {
+	return len(t.Splits) > 0
+}
+
 // IsIncome returns true if the transaction amount is positive (income)
 func (t *Transaction) IsIncome() bool {
 	return t.Amount.GreaterThan(decimal.Zero)
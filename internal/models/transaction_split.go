@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// TransactionSplit allocates part of a transaction's amount to a category
+type TransactionSplit struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TransactionID uuid.UUID       `json:"transaction_id" gorm:"type:uuid;not null;index"`
+	CategoryID    uuid.UUID       `json:"category_id" gorm:"type:uuid;not null;index"`
+	Amount        decimal.Decimal `json:"amount" gorm:"type:decimal(19,4);not null"`
+	Memo          string          `json:"memo" gorm:"not null;default:''"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+
+	// Relationships
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (s *TransactionSplit) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for TransactionSplit model
+func (TransactionSplit) TableName() string {
+	return "transaction_splits"
+}
@@ -15,6 +15,11 @@ type User struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// Credentials
+	PasswordHash        string     `json:"-" gorm:"not null;default:''"`
+	FailedLoginAttempts int        `json:"-" gorm:"not null;default:0"`
+	LockedUntil         *time.Time `json:"-"`
+
 	// Relationships
 	Accounts     []Account     `json:"accounts,omitempty" gorm:"foreignKey:UserID"`
 	Transactions []Transaction `json:"transactions,omitempty" gorm:"foreignKey:UserID"`
@@ -27,3 +32,8 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// IsLocked returns true if the user is locked out of logging in at the given time
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
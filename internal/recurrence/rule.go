@@ -0,0 +1,398 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules used
+// for recurring transactions: FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYSETPOS,
+// COUNT and UNTIL. Occurrences are whole days.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is a parsed recurrence rule. Examples:
+//
+//	FREQ=MONTHLY;BYMONTHDAY=1                      first of every month
+//	FREQ=MONTHLY;BYMONTHDAY=-1                     last day of every month
+//	FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1  last business day of every month
+//	FREQ=WEEKLY;INTERVAL=2;BYDAY=FR                every other Friday
+//	FREQ=YEARLY;COUNT=5                            on the start date for five years
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	BySetPos   int
+	Count      int
+	Until      *time.Time
+}
+
+// Parse parses an RRULE string, with or without the "RRULE:" prefix
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[strings.ToUpper(code)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY value %q", code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid BYMONTHDAY value %q", v)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYSETPOS":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid BYSETPOS %q", value)
+			}
+			rule.BySetPos = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate checks that the rule only uses supported combinations
+func (r *Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	case "":
+		return errors.New("FREQ is required")
+	default:
+		return fmt.Errorf("unsupported FREQ %q", r.Freq)
+	}
+
+	if r.Interval < 1 {
+		return errors.New("INTERVAL must be at least 1")
+	}
+	if r.Count < 0 {
+		return errors.New("COUNT cannot be negative")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("COUNT and UNTIL cannot both be set")
+	}
+	for _, day := range r.ByMonthDay {
+		if day == 0 || day < -31 || day > 31 {
+			return fmt.Errorf("invalid BYMONTHDAY value %d", day)
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != Monthly {
+		return errors.New("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	if len(r.ByMonthDay) > 0 && len(r.ByDay) > 0 {
+		return errors.New("BYDAY and BYMONTHDAY cannot be combined")
+	}
+	if len(r.ByDay) > 0 && r.Freq == Yearly {
+		return errors.New("BYDAY is not supported with FREQ=YEARLY")
+	}
+	if r.BySetPos != 0 && r.Freq != Monthly && r.Freq != Weekly {
+		return errors.New("BYSETPOS is only supported with FREQ=WEEKLY or FREQ=MONTHLY")
+	}
+	return nil
+}
+
+// String formats the rule in RRULE syntax without the "RRULE:" prefix
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.BySetPos != 0 {
+		parts = append(parts, "BYSETPOS="+strconv.Itoa(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Iterator returns an iterator over the rule's occurrences on or after start.
+// Start anchors the schedule: it supplies the default weekday for weekly rules
+// and the default day of month for monthly and yearly rules.
+func (r *Rule) Iterator(start time.Time) *Iterator {
+	start = truncateDay(start)
+	return &Iterator{
+		rule:   r,
+		start:  start,
+		period: periodStart(r.Freq, start),
+	}
+}
+
+// Iterator yields a rule's occurrences in chronological order
+type Iterator struct {
+	rule    *Rule
+	start   time.Time
+	period  time.Time
+	pending []time.Time
+	emitted int
+	done    bool
+}
+
+// maxEmptyPeriods bounds the search for rules that can never match, such as
+// BYMONTHDAY=30 on a February-only schedule
+const maxEmptyPeriods = 1000
+
+// Next returns the next occurrence, or false once the rule is exhausted
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	empty := 0
+	for len(it.pending) == 0 {
+		if it.rule.Until != nil && it.period.After(*it.rule.Until) || empty >= maxEmptyPeriods {
+			it.done = true
+			return time.Time{}, false
+		}
+
+		for _, candidate := range it.rule.expand(it.period, it.start) {
+			if !candidate.Before(it.start) {
+				it.pending = append(it.pending, candidate)
+			}
+		}
+		it.period = advancePeriod(it.rule.Freq, it.period, it.rule.Interval)
+		empty++
+	}
+
+	next := it.pending[0]
+	it.pending = it.pending[1:]
+
+	if it.rule.Until != nil && next.After(*it.rule.Until) {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	it.emitted++
+	return next, true
+}
+
+// expand returns the sorted candidate dates within the period starting at period
+func (r *Rule) expand(period, start time.Time) []time.Time {
+	var candidates []time.Time
+	year, month, _ := period.Date()
+	loc := period.Location()
+
+	switch r.Freq {
+	case Daily:
+		if len(r.ByDay) == 0 || containsWeekday(r.ByDay, period.Weekday()) {
+			candidates = append(candidates, period)
+		}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		for i := 0; i < 7; i++ {
+			day := period.AddDate(0, 0, i)
+			if containsWeekday(days, day.Weekday()) {
+				candidates = append(candidates, day)
+			}
+		}
+
+	case Monthly:
+		lastDay := daysIn(year, month)
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, d := range r.ByMonthDay {
+				if d < 0 {
+					d = lastDay + d + 1
+				}
+				if d >= 1 && d <= lastDay {
+					candidates = append(candidates, time.Date(year, month, d, 0, 0, 0, 0, loc))
+				}
+			}
+		case len(r.ByDay) > 0:
+			for d := 1; d <= lastDay; d++ {
+				day := time.Date(year, month, d, 0, 0, 0, 0, loc)
+				if containsWeekday(r.ByDay, day.Weekday()) {
+					candidates = append(candidates, day)
+				}
+			}
+		default:
+			if d := start.Day(); d <= lastDay {
+				candidates = append(candidates, time.Date(year, month, d, 0, 0, 0, 0, loc))
+			}
+		}
+
+	case Yearly:
+		if d := start.Day(); d <= daysIn(year, start.Month()) {
+			candidates = append(candidates, time.Date(year, start.Month(), d, 0, 0, 0, 0, loc))
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	candidates = dedupe(candidates)
+
+	if r.BySetPos != 0 {
+		pos := r.BySetPos
+		if pos < 0 {
+			pos = len(candidates) + pos + 1
+		}
+		if pos < 1 || pos > len(candidates) {
+			return nil
+		}
+		return []time.Time{candidates[pos-1]}
+	}
+
+	return candidates
+}
+
+// periodStart returns the start of the frequency period containing t. Weeks
+// start on Monday.
+func periodStart(freq Frequency, t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch freq {
+	case Weekly:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case Monthly:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case Yearly:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// advancePeriod moves a period start forward by interval periods
+func advancePeriod(freq Frequency, period time.Time, interval int) time.Time {
+	switch freq {
+	case Weekly:
+		return period.AddDate(0, 0, 7*interval)
+	case Monthly:
+		return period.AddDate(0, interval, 0)
+	case Yearly:
+		return period.AddDate(interval, 0, 0)
+	default:
+		return period.AddDate(0, 0, interval)
+	}
+}
+
+// parseUntil accepts UNTIL as a date (20060102) or UTC date-time (20060102T150405Z)
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return truncateDay(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(dates []time.Time) []time.Time {
+	if len(dates) < 2 {
+		return dates
+	}
+	result := dates[:1]
+	for _, d := range dates[1:] {
+		if !d.Equal(result[len(result)-1]) {
+			result = append(result, d)
+		}
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "last day of month across leap February",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: date(2024, time.January, 15),
+			want: []time.Time{
+				date(2024, time.January, 31), date(2024, time.February, 29),
+				date(2024, time.March, 31), date(2024, time.April, 30),
+			},
+		},
+		{
+			name:  "last day of month in common year",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: date(2025, time.February, 1),
+			want:  []time.Time{date(2025, time.February, 28), date(2025, time.March, 31)},
+		},
+		{
+			name:  "31st skips shorter months",
+			rule:  "FREQ=MONTHLY",
+			start: date(2025, time.January, 31),
+			want: []time.Time{
+				date(2025, time.January, 31), date(2025, time.March, 31),
+				date(2025, time.May, 31), date(2025, time.July, 31),
+			},
+		},
+		{
+			name:  "BYMONTHDAY=30 skips February",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=30",
+			start: date(2025, time.January, 1),
+			want:  []time.Time{date(2025, time.January, 30), date(2025, time.March, 30)},
+		},
+		{
+			name:  "second to last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-2",
+			start: date(2024, time.February, 1),
+			want:  []time.Time{date(2024, time.February, 28), date(2024, time.March, 30)},
+		},
+		{
+			name:  "last business day of month",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start: date(2025, time.May, 1),
+			want: []time.Time{
+				date(2025, time.May, 30), date(2025, time.June, 30),
+				date(2025, time.July, 31), date(2025, time.August, 29),
+			},
+		},
+		{
+			name:  "first business day of month",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1",
+			start: date(2025, time.May, 1),
+			want:  []time.Time{date(2025, time.May, 1), date(2025, time.June, 2), date(2025, time.July, 1)},
+		},
+		{
+			name:  "second Tuesday of month",
+			rule:  "FREQ=MONTHLY;BYDAY=TU;BYSETPOS=2",
+			start: date(2025, time.January, 1),
+			want:  []time.Time{date(2025, time.January, 14), date(2025, time.February, 11)},
+		},
+		{
+			name:  "fifth Friday only in months that have one",
+			rule:  "FREQ=MONTHLY;BYDAY=FR;BYSETPOS=5",
+			start: date(2025, time.January, 1),
+			want:  []time.Time{date(2025, time.January, 31), date(2025, time.May, 30)},
+		},
+		{
+			name:  "BYSETPOS before start is not emitted",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1",
+			start: date(2025, time.May, 2),
+			want:  []time.Time{date(2025, time.June, 2)},
+		},
+		{
+			name:  "last weekday of week",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR;BYSETPOS=-1",
+			start: date(2025, time.June, 2),
+			want:  []time.Time{date(2025, time.June, 6), date(2025, time.June, 13)},
+		},
+		{
+			name:  "leap day yearly only in leap years",
+			rule:  "FREQ=YEARLY",
+			start: date(2024, time.February, 29),
+			want:  []time.Time{date(2024, time.February, 29), date(2028, time.February, 29)},
+		},
+		{
+			name:  "COUNT limits occurrences",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2",
+			start: date(2025, time.January, 1),
+			want:  []time.Time{date(2025, time.January, 31), date(2025, time.February, 28)},
+		},
+		{
+			name:  "UNTIL is inclusive",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20250331",
+			start: date(2025, time.January, 1),
+			want: []time.Time{
+				date(2025, time.January, 31), date(2025, time.February, 28), date(2025, time.March, 31),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.rule, err)
+			}
+			it := rule.Iterator(tt.start)
+			for i, want := range tt.want {
+				got, ok := it.Next()
+				if !ok {
+					t.Fatalf("occurrence %d: iterator ended, want %s", i, want.Format("2006-01-02"))
+				}
+				if !got.Equal(want) {
+					t.Fatalf("occurrence %d = %s, want %s", i, got.Format("2006-01-02"), want.Format("2006-01-02"))
+				}
+			}
+			if rule.Count > 0 || rule.Until != nil {
+				if got, ok := it.Next(); ok {
+					t.Errorf("extra occurrence %s after the rule ended", got.Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnsupportedRules(t *testing.T) {
+	tests := []string{
+		"",
+		"BYMONTHDAY=1",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;INTERVAL=0",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=1;BYDAY=MO",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20250101",
+		"FREQ=MONTHLY;BYWEEKNO=1",
+	}
+	for _, rule := range tests {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", rule)
+		}
+	}
+}
+
+func TestStringRoundTrips(t *testing.T) {
+	for _, s := range []string{
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+		"FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=12",
+		"FREQ=DAILY;UNTIL=20261231",
+	} {
+		rule, err := Parse("RRULE:" + s)
+		if err != nil {
+			t.Fatalf("parse %q: %v", s, err)
+		}
+		if got := rule.String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+}
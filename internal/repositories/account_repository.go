@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/vasujain275/expense-tracker-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type accountRepository struct {
@@ -29,7 +31,7 @@ func (r *accountRepository) GetByID(id uuid.UUID) (*models.Account, error) {
 	err := r.db.Preload("User").First(&account, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("account not found")
+			return nil, ErrAccountNotFound
 		}
 		return nil, err
 	}
@@ -51,23 +53,141 @@ func (r *accountRepository) GetActiveByUserID(userID uuid.UUID) ([]*models.Accou
 	return accounts, err
 }
 
-// Update updates an account
+// Update writes the editable columns of an account. Balances are left out, so
+// an edit running alongside a transaction cannot overwrite the transaction's
+// balance adjustment with the balance it loaded earlier.
 func (r *accountRepository) Update(account *models.Account) error {
-	return r.db.Save(account).Error
+	result := r.db.Model(account).
+		Select("name", "type", "is_active", "credit_limit", "statement_closing_day",
+			"payment_due_day", "apr", "updated_at").
+		Updates(account)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
 }
 
-// UpdateBalance updates only the balance of an account
-func (r *accountRepository) UpdateBalance(id uuid.UUID, balance decimal.Decimal) error {
-	result := r.db.Model(&models.Account{}).Where("id = ?", id).Update("balance", balance)
+// AdjustBalance atomically adds delta to the balance of an account. The
+// increment happens in SQL, so concurrent adjustments never lose an update.
+func (r *accountRepository) AdjustBalance(id uuid.UUID, delta decimal.Decimal) error {
+	result := r.db.Model(&models.Account{}).Where("id = ?", id).
+		Update("balance", gorm.Expr("balance + ?", delta))
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("account not found")
+		return ErrAccountNotFound
 	}
 	return nil
 }
 
+// RecomputeBalance resets the stored balance to the opening balance plus the sum
+// of the account's transactions. The account row is locked first so in-flight
+// balance adjustments commit before the sum is taken.
+func (r *accountRepository) RecomputeBalance(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var account models.Account
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&account, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		return tx.Exec(`UPDATE accounts SET balance = opening_balance + COALESCE(
+			(SELECT SUM(amount) FROM transactions WHERE transactions.account_id = accounts.id), 0),
+			updated_at = NOW() WHERE id = ?`, id).Error
+	})
+}
+
+// GetReconciliation compares the stored and computed balance of an account
+func (r *accountRepository) GetReconciliation(id uuid.UUID) (*AccountReconciliation, error) {
+	var reconciliations []*AccountReconciliation
+	err := r.reconciliationQuery().Where("accounts.id = ?", id).Find(&reconciliations).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(reconciliations) == 0 {
+		return nil, ErrAccountNotFound
+	}
+	return finalizeReconciliation(reconciliations[0]), nil
+}
+
+// GetMismatchedReconciliations returns every account whose stored balance has
+// drifted from its computed balance
+func (r *accountRepository) GetMismatchedReconciliations() ([]*AccountReconciliation, error) {
+	var reconciliations []*AccountReconciliation
+	err := r.reconciliationQuery().
+		Having("accounts.balance <> accounts.opening_balance + COALESCE(SUM(transactions.amount), 0)").
+		Find(&reconciliations).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range reconciliations {
+		finalizeReconciliation(rec)
+	}
+	return reconciliations, nil
+}
+
+// reconciliationQuery selects stored balances alongside transaction totals per account
+func (r *accountRepository) reconciliationQuery() *gorm.DB {
+	return r.db.Table("accounts").
+		Select("accounts.id AS account_id, accounts.user_id, accounts.name AS account_name, " +
+			"accounts.opening_balance, accounts.balance AS stored_balance, " +
+			"COALESCE(SUM(transactions.amount), 0) AS transaction_total").
+		Joins("LEFT JOIN transactions ON transactions.account_id = accounts.id").
+		Group("accounts.id")
+}
+
+// finalizeReconciliation fills in the computed balance and difference
+func finalizeReconciliation(rec *AccountReconciliation) *AccountReconciliation {
+	rec.ComputedBalance = rec.OpeningBalance.Add(rec.TransactionTotal)
+	rec.Difference = rec.StoredBalance.Sub(rec.ComputedBalance)
+	rec.Balanced = rec.Difference.IsZero()
+	return rec
+}
+
+// balanceHistoryQuery groups an account's transactions by period and runs a
+// window sum over every period from the one containing from to the one
+// containing to, empty periods included. Transactions before the first
+// period are summed once into the starting total. Its arguments are the
+// period unit, the account ID, the end of to (exclusive), the unit, from,
+// the unit, to and the step between periods.
+const balanceHistoryQuery = `WITH changes AS (
+		SELECT date_trunc(?, date AT TIME ZONE 'UTC') AS period, SUM(amount) AS change, COUNT(*) AS transactions
+		FROM transactions
+		WHERE account_id = ? AND date < ?
+		GROUP BY 1
+	),
+	periods AS (
+		SELECT generate_series(
+			date_trunc(?, ?::timestamptz AT TIME ZONE 'UTC'),
+			date_trunc(?, ?::timestamptz AT TIME ZONE 'UTC'),
+			?::interval) AS period
+	)
+	SELECT periods.period AS period_start,
+		COALESCE(changes.change, 0) AS change,
+		COALESCE(changes.transactions, 0) AS transactions,
+		(SELECT COALESCE(SUM(change), 0) FROM changes WHERE period < (SELECT MIN(period) FROM periods))
+			+ SUM(COALESCE(changes.change, 0)) OVER (ORDER BY periods.period) AS ledger_total
+	FROM periods
+	LEFT JOIN changes ON changes.period = periods.period
+	ORDER BY periods.period`
+
+// GetBalanceHistory sums an account's transactions per interval ("day",
+// "week" or "month") from the period containing from up to and including
+// the date to. Weeks start on Monday.
+func (r *accountRepository) GetBalanceHistory(id uuid.UUID, interval string, from, to time.Time) ([]*BalanceHistoryPoint, error) {
+	end := to.AddDate(0, 0, 1)
+	var points []*BalanceHistoryPoint
+	err := r.db.Raw(balanceHistoryQuery, interval, id, end, interval, from, interval, to, "1 "+interval).
+		Scan(&points).Error
+	return points, err
+}
+
 // Delete deletes an account by ID
 func (r *accountRepository) Delete(id uuid.UUID) error {
 	result := r.db.Delete(&models.Account{}, "id = ?", id)
@@ -75,7 +195,7 @@ func (r *accountRepository) Delete(id uuid.UUID) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("account not found")
+		return ErrAccountNotFound
 	}
 	return nil
 }
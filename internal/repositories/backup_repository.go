@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type backupRepository struct {
+	db *gorm.DB
+}
+
+// NewBackupRepository creates a new backup repository
+func NewBackupRepository(db *gorm.DB) BackupRepository {
+	return &backupRepository{db: db}
+}
+
+// LoadUserData retrieves a user with every record they own, children included
+func (r *backupRepository) LoadUserData(userID uuid.UUID) (*UserData, error) {
+	var data UserData
+	if err := r.db.First(&data.User, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ?", userID).
+		Order("name ASC").Find(&data.Categories).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("lower(name) ASC").Find(&data.Tags).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at ASC").Find(&data.Accounts).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("date ASC, base_currency ASC, quote_currency ASC").Find(&data.ExchangeRates).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Joins("JOIN accounts ON accounts.id = credit_card_statements.account_id").
+		Where("accounts.user_id = ?", userID).
+		Order("credit_card_statements.account_id ASC, credit_card_statements.period_end ASC").
+		Find(&data.Statements).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("date ASC").Find(&data.NetWorthSnapshots).Error; err != nil {
+		return nil, err
+	}
+	err := r.db.Preload("Exceptions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurrence_date ASC")
+	}).Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Recurring).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.Preload("Splits", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Preload("Tags").Where("user_id = ?", userID).Order("date ASC, created_at ASC, id ASC").Find(&data.Transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Categories").Where("user_id = ?", userID).
+		Order("created_at ASC").Find(&data.Budgets).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at ASC").Find(&data.ImportProfiles).Error; err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// InsertUserData inserts a user and their records as given, keeping IDs and
+// timestamps. Parents are inserted before the records referencing them.
+func (r *backupRepository) InsertUserData(data *UserData) error {
+	create := func(value interface{}) error {
+		return r.db.Omit(clause.Associations).CreateInBatches(value, 500).Error
+	}
+	flags := collectFalseFlags(data)
+
+	if err := r.db.Omit(clause.Associations).Create(&data.User).Error; err != nil {
+		return err
+	}
+	if len(data.Categories) > 0 {
+		categories := parentsFirst(data.Categories)
+		if err := create(&categories); err != nil {
+			return err
+		}
+	}
+	if len(data.Tags) > 0 {
+		if err := create(&data.Tags); err != nil {
+			return err
+		}
+	}
+	if len(data.Accounts) > 0 {
+		if err := create(&data.Accounts); err != nil {
+			return err
+		}
+	}
+	if len(data.ExchangeRates) > 0 {
+		if err := create(&data.ExchangeRates); err != nil {
+			return err
+		}
+	}
+	if len(data.Statements) > 0 {
+		if err := create(&data.Statements); err != nil {
+			return err
+		}
+	}
+	if len(data.NetWorthSnapshots) > 0 {
+		if err := create(&data.NetWorthSnapshots); err != nil {
+			return err
+		}
+	}
+
+	var exceptions []models.RecurringException
+	for _, recurring := range data.Recurring {
+		exceptions = append(exceptions, recurring.Exceptions...)
+	}
+	if len(data.Recurring) > 0 {
+		if err := create(&data.Recurring); err != nil {
+			return err
+		}
+	}
+	if len(exceptions) > 0 {
+		if err := create(&exceptions); err != nil {
+			return err
+		}
+	}
+
+	var splits []models.TransactionSplit
+	var transactionTags []map[string]interface{}
+	for _, transaction := range data.Transactions {
+		splits = append(splits, transaction.Splits...)
+		for _, tag := range transaction.Tags {
+			transactionTags = append(transactionTags, map[string]interface{}{
+				"transaction_id": transaction.ID,
+				"tag_id":         tag.ID,
+			})
+		}
+	}
+	if len(data.Transactions) > 0 {
+		if err := create(&data.Transactions); err != nil {
+			return err
+		}
+	}
+	if len(splits) > 0 {
+		if err := create(&splits); err != nil {
+			return err
+		}
+	}
+	if len(transactionTags) > 0 {
+		if err := r.db.Table("transaction_tags").CreateInBatches(transactionTags, 500).Error; err != nil {
+			return err
+		}
+	}
+
+	for i := range data.Budgets {
+		budget := &data.Budgets[i]
+		// Link the existing categories without upserting them
+		if err := r.db.Omit("Categories.*").Create(budget).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(data.ImportProfiles) > 0 {
+		if err := create(&data.ImportProfiles); err != nil {
+			return err
+		}
+	}
+
+	return r.restoreFalseFlags(flags)
+}
+
+// DeleteUserData deletes every record a user owns, leaving the user itself.
+// Records are deleted before the records they reference; children declared
+// ON DELETE CASCADE go with their parents.
+func (r *backupRepository) DeleteUserData(userID uuid.UUID) error {
+	owned := []interface{}{
+		&models.ImportProfile{},
+		&models.Budget{},
+		&models.Transaction{},
+		&models.RecurringTransaction{},
+		&models.NetWorthSnapshot{},
+		&models.ExchangeRate{},
+		&models.Account{},
+		&models.Tag{},
+		&models.Category{},
+	}
+	for _, model := range owned {
+		if err := r.db.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parentsFirst orders categories so that every parent comes before its
+// subcategories
+func parentsFirst(categories []models.Category) []models.Category {
+	children := make(map[uuid.UUID][]models.Category)
+	ids := make(map[uuid.UUID]bool, len(categories))
+	for _, category := range categories {
+		ids[category.ID] = true
+	}
+
+	ordered := make([]models.Category, 0, len(categories))
+	for _, category := range categories {
+		if category.ParentID != nil && ids[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			ordered = append(ordered, category)
+		}
+	}
+	for i := 0; i < len(ordered); i++ {
+		ordered = append(ordered, children[ordered[i].ID]...)
+	}
+	return ordered
+}
+
+// falseFlags holds the records whose boolean columns that default to true are
+// false. On insert, gorm replaces a false value with the column default, so
+// these are collected beforehand and cleared afterwards.
+type falseFlags struct {
+	accounts, recurring, profiles []uuid.UUID
+}
+
+func collectFalseFlags(data *UserData) falseFlags {
+	var flags falseFlags
+	for _, account := range data.Accounts {
+		if !account.IsActive {
+			flags.accounts = append(flags.accounts, account.ID)
+		}
+	}
+	for _, recurring := range data.Recurring {
+		if !recurring.IsActive {
+			flags.recurring = append(flags.recurring, recurring.ID)
+		}
+	}
+	for _, profile := range data.ImportProfiles {
+		if !profile.HasHeader {
+			flags.profiles = append(flags.profiles, profile.ID)
+		}
+	}
+	return flags
+}
+
+func (r *backupRepository) restoreFalseFlags(flags falseFlags) error {
+	if len(flags.accounts) > 0 {
+		if err := r.db.Model(&models.Account{}).Where("id IN ?", flags.accounts).
+			UpdateColumn("is_active", false).Error; err != nil {
+			return err
+		}
+	}
+	if len(flags.recurring) > 0 {
+		if err := r.db.Model(&models.RecurringTransaction{}).Where("id IN ?", flags.recurring).
+			UpdateColumn("is_active", false).Error; err != nil {
+			return err
+		}
+	}
+	if len(flags.profiles) > 0 {
+		if err := r.db.Model(&models.ImportProfile{}).Where("id IN ?", flags.profiles).
+			UpdateColumn("has_header", false).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
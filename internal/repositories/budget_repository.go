@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new budget repository
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+// Create creates a new budget and links it to its categories
+func (r *budgetRepository) Create(budget *models.Budget) error {
+	return r.db.Create(budget).Error
+}
+
+// GetByID retrieves a budget by ID with its categories
+func (r *budgetRepository) GetByID(id uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.Preload("Categories").First(&budget, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// GetByUserID retrieves all budgets for a user with their categories
+func (r *budgetRepository) GetByUserID(userID uuid.UUID) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	err := r.db.Preload("Categories").Where("user_id = ?", userID).
+		Order("name ASC").Find(&budgets).Error
+	return budgets, err
+}
+
+// Update updates a budget and replaces its category links
+func (r *budgetRepository) Update(budget *models.Budget) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(budget).Error; err != nil {
+			return err
+		}
+		return tx.Model(budget).Association("Categories").Replace(budget.Categories)
+	})
+}
+
+// Delete deletes a budget by ID; its category links are removed by cascade
+func (r *budgetRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Budget{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBudgetNotFound
+	}
+	return nil
+}
@@ -22,33 +22,116 @@ func (r *categoryRepository) Create(category *models.Category) error {
 	return r.db.Create(category).Error
 }
 
+// CreateBatch creates several categories at once. Parents must come before
+// their subcategories.
+func (r *categoryRepository) CreateBatch(categories []*models.Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(categories, 500).Error
+}
+
 // GetByID retrieves a category by ID
 func (r *categoryRepository) GetByID(id uuid.UUID) (*models.Category, error) {
 	var category models.Category
 	err := r.db.First(&category, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("category not found")
+			return nil, ErrCategoryNotFound
 		}
 		return nil, err
 	}
 	return &category, nil
 }
 
-// GetAll retrieves all categories
-func (r *categoryRepository) GetAll() ([]*models.Category, error) {
+// GetByUserID retrieves all categories of a user
+func (r *categoryRepository) GetByUserID(userID uuid.UUID) ([]*models.Category, error) {
 	var categories []*models.Category
-	err := r.db.Order("name ASC").Find(&categories).Error
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&categories).Error
 	return categories, err
 }
 
-// GetByType retrieves categories by type
-func (r *categoryRepository) GetByType(categoryType models.CategoryType) ([]*models.Category, error) {
+// GetByType retrieves a user's categories by type
+func (r *categoryRepository) GetByType(userID uuid.UUID, categoryType models.CategoryType) ([]*models.Category, error) {
 	var categories []*models.Category
-	err := r.db.Where("type = ?", categoryType).Order("name ASC").Find(&categories).Error
+	err := r.db.Where("user_id = ? AND type = ?", userID, categoryType).
+		Order("name ASC").Find(&categories).Error
 	return categories, err
 }
 
+// GetDescendantIDs retrieves the IDs of every subcategory below a category,
+// at any depth
+func (r *categoryRepository) GetDescendantIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Raw(`WITH RECURSIVE descendants AS (
+			SELECT id FROM categories WHERE parent_id = ?
+			UNION
+			SELECT categories.id FROM categories
+			JOIN descendants ON categories.parent_id = descendants.id
+		)
+		SELECT id FROM descendants`, id).Scan(&ids).Error
+	return ids, err
+}
+
+// GetUsage counts the records that refer to a category
+func (r *categoryRepository) GetUsage(id uuid.UUID) (*CategoryUsage, error) {
+	var usage CategoryUsage
+	err := r.db.Raw(`SELECT
+			(SELECT COUNT(*) FROM transactions WHERE category_id = @id) AS transactions,
+			(SELECT COUNT(*) FROM transaction_splits WHERE category_id = @id) AS transaction_splits,
+			(SELECT COUNT(*) FROM budget_categories WHERE category_id = @id) AS budgets,
+			(SELECT COUNT(*) FROM recurring_transactions WHERE category_id = @id) AS recurring_transactions,
+			(SELECT COUNT(*) FROM recurring_exceptions WHERE category_id = @id) AS recurring_exceptions,
+			(SELECT COUNT(*) FROM import_profiles WHERE default_category_id = @id) AS import_profiles,
+			(SELECT COUNT(*) FROM categories WHERE parent_id = @id) AS subcategories`,
+		map[string]interface{}{"id": id}).Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// Reassign points every record that refers to one category at another and
+// returns how many records moved. Subcategories move below the target.
+// Budgets that already cover the target keep a single link to it. Run it
+// inside a unit of work so the records move together.
+func (r *categoryRepository) Reassign(fromID, toID uuid.UUID) (*CategoryUsage, error) {
+	var moved CategoryUsage
+	updates := []struct {
+		model  interface{}
+		column string
+		count  *int64
+	}{
+		{&models.Transaction{}, "category_id", &moved.Transactions},
+		{&models.TransactionSplit{}, "category_id", &moved.TransactionSplits},
+		{&models.RecurringTransaction{}, "category_id", &moved.RecurringTransactions},
+		{&models.RecurringException{}, "category_id", &moved.RecurringExceptions},
+		{&models.ImportProfile{}, "default_category_id", &moved.ImportProfiles},
+		{&models.Category{}, "parent_id", &moved.Subcategories},
+	}
+	for _, update := range updates {
+		result := r.db.Model(update.model).Where(update.column+" = ?", fromID).Update(update.column, toID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		*update.count = result.RowsAffected
+	}
+
+	err := r.db.Exec(`INSERT INTO budget_categories (budget_id, category_id)
+		SELECT budget_id, ? FROM budget_categories WHERE category_id = ?
+		ON CONFLICT DO NOTHING`, toID, fromID).Error
+	if err != nil {
+		return nil, err
+	}
+	result := r.db.Exec("DELETE FROM budget_categories WHERE category_id = ?", fromID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	moved.Budgets = result.RowsAffected
+
+	return &moved, nil
+}
+
 // Update updates a category
 func (r *categoryRepository) Update(category *models.Category) error {
 	return r.db.Save(category).Error
@@ -61,14 +144,14 @@ func (r *categoryRepository) Delete(id uuid.UUID) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("category not found")
+		return ErrCategoryNotFound
 	}
 	return nil
 }
 
-// Exists checks if a category exists by ID
-func (r *categoryRepository) Exists(id uuid.UUID) (bool, error) {
+// Exists checks if a category exists and belongs to the user
+func (r *categoryRepository) Exists(userID, id uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Category{}).Where("id = ?", id).Count(&count).Error
+	err := r.db.Model(&models.Category{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error
 	return count > 0, err
 }
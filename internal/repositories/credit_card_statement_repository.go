@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type creditCardStatementRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditCardStatementRepository creates a new credit card statement repository
+func NewCreditCardStatementRepository(db *gorm.DB) CreditCardStatementRepository {
+	return &creditCardStatementRepository{db: db}
+}
+
+// Create inserts statements, skipping those whose billing cycle already has
+// one so concurrent generation of the same cycle is harmless
+func (r *creditCardStatementRepository) Create(statements []*models.CreditCardStatement) error {
+	if len(statements) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "period_end"}},
+		DoNothing: true,
+	}).CreateInBatches(&statements, 500).Error
+}
+
+// GetByAccountID retrieves the statements of an account, newest first
+func (r *creditCardStatementRepository) GetByAccountID(accountID uuid.UUID) ([]*models.CreditCardStatement, error) {
+	var statements []*models.CreditCardStatement
+	err := r.db.Where("account_id = ?", accountID).Order("period_end DESC").Find(&statements).Error
+	return statements, err
+}
+
+// GetLatest retrieves the statement of an account's most recent billing cycle
+func (r *creditCardStatementRepository) GetLatest(accountID uuid.UUID) (*models.CreditCardStatement, error) {
+	var statement models.CreditCardStatement
+	err := r.db.Where("account_id = ?", accountID).Order("period_end DESC").First(&statement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStatementNotFound
+		}
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// GetPaidAmounts returns, per statement of an account, the total credited to
+// the account after the closing date up to and including the due date
+func (r *creditCardStatementRepository) GetPaidAmounts(accountID uuid.UUID) (map[uuid.UUID]decimal.Decimal, error) {
+	var rows []struct {
+		ID   uuid.UUID
+		Paid decimal.Decimal
+	}
+	err := r.db.Table("credit_card_statements").
+		Select("credit_card_statements.id, COALESCE(SUM(transactions.amount), 0) AS paid").
+		Joins(`LEFT JOIN transactions ON transactions.account_id = credit_card_statements.account_id
+			AND transactions.amount > 0
+			AND transactions.date >= (credit_card_statements.period_end + 1)::timestamp AT TIME ZONE 'UTC'
+			AND transactions.date < (credit_card_statements.due_date + 1)::timestamp AT TIME ZONE 'UTC'`).
+		Where("credit_card_statements.account_id = ?", accountID).
+		Group("credit_card_statements.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	paid := make(map[uuid.UUID]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		paid[row.ID] = row.Paid
+	}
+	return paid, nil
+}
+
+// GetCycleActivity sums an account's transactions dated before start, and
+// its charges and credits from start up to but excluding end
+func (r *creditCardStatementRepository) GetCycleActivity(accountID uuid.UUID, start, end time.Time) (*CycleActivity, error) {
+	var activity CycleActivity
+	err := r.db.Table("transactions").
+		Select(`COALESCE(SUM(amount) FILTER (WHERE date < ?), 0) AS prior_total,
+			COALESCE(-SUM(amount) FILTER (WHERE date >= ? AND amount < 0), 0) AS charges,
+			COALESCE(SUM(amount) FILTER (WHERE date >= ? AND amount > 0), 0) AS credits`,
+			start, start, start).
+		Where("account_id = ? AND date < ?", accountID, end).
+		Scan(&activity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// GetFirstTransactionDate returns the date of an account's earliest
+// transaction, or nil when it has none
+func (r *creditCardStatementRepository) GetFirstTransactionDate(accountID uuid.UUID) (*time.Time, error) {
+	var first sql.NullTime
+	err := r.db.Table("transactions").Select("MIN(date)").
+		Where("account_id = ?", accountID).Scan(&first).Error
+	if err != nil || !first.Valid {
+		return nil, err
+	}
+	return &first.Time, nil
+}
+
+// GetBillingAccounts retrieves every credit card account with a billing cycle
+func (r *creditCardStatementRepository) GetBillingAccounts() ([]*models.Account, error) {
+	var accounts []*models.Account
+	err := r.db.Where("type = ? AND statement_closing_day IS NOT NULL AND payment_due_day IS NOT NULL",
+		models.AccountTypeCreditCard).Order("created_at ASC").Find(&accounts).Error
+	return accounts, err
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Sentinel errors returned when a record cannot be found
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrBudgetNotFound      = errors.New("budget not found")
+	ErrRecurringNotFound   = errors.New("recurring transaction not found")
+
+	ErrImportProfileNotFound = errors.New("import profile not found")
+	ErrTagNotFound           = errors.New("tag not found")
+	ErrExchangeRateNotFound  = errors.New("exchange rate not found")
+	ErrStatementNotFound     = errors.New("statement not found")
+)
+
+// ErrDuplicateTag is returned when a user already has a tag with the same name
+var ErrDuplicateTag = errors.New("tag with this name already exists")
+
+// ErrDuplicateTransaction is returned when a transaction violates a uniqueness
+// rule, such as a recurring occurrence that was already generated
+var ErrDuplicateTransaction = errors.New("transaction already exists")
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// IsTransient reports whether err is a database failure that may succeed when
+// retried: a lost or refused connection, a timeout or cancellation, a
+// serialization failure or deadlock, a lock that could not be taken or a
+// server short of resources
+func IsTransient(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || pgconn.Timeout(err) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && len(pgErr.Code) == 5 {
+		switch pgErr.Code[:2] {
+		case "08", "40", "53", "55", "57", "58":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type importProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewImportProfileRepository creates a new import profile repository
+func NewImportProfileRepository(db *gorm.DB) ImportProfileRepository {
+	return &importProfileRepository{db: db}
+}
+
+// Create creates a new import profile
+func (r *importProfileRepository) Create(profile *models.ImportProfile) error {
+	return r.db.Create(profile).Error
+}
+
+// GetByID retrieves an import profile by ID
+func (r *importProfileRepository) GetByID(id uuid.UUID) (*models.ImportProfile, error) {
+	var profile models.ImportProfile
+	err := r.db.First(&profile, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportProfileNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetByUserID retrieves all import profiles for a user
+func (r *importProfileRepository) GetByUserID(userID uuid.UUID) ([]*models.ImportProfile, error) {
+	var profiles []*models.ImportProfile
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+// Update updates an import profile
+func (r *importProfileRepository) Update(profile *models.ImportProfile) error {
+	return r.db.Save(profile).Error
+}
+
+// Delete deletes an import profile by ID
+func (r *importProfileRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.ImportProfile{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrImportProfileNotFound
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ type UserRepository interface {
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	Update(user *models.User) error
+	RecordFailedLogin(id uuid.UUID, maxAttempts int, lockedUntil time.Time) (*time.Time, error)
+	ResetLoginFailures(id uuid.UUID) error
 	Delete(id uuid.UUID) error
 	Exists(id uuid.UUID) (bool, error)
 }
@@ -25,26 +27,91 @@ type AccountRepository interface {
 	GetByUserID(userID uuid.UUID) ([]*models.Account, error)
 	Update(account *models.Account) error
 	Delete(id uuid.UUID) error
-	UpdateBalance(id uuid.UUID, balance decimal.Decimal) error
+	AdjustBalance(id uuid.UUID, delta decimal.Decimal) error
+	RecomputeBalance(id uuid.UUID) error
 	GetActiveByUserID(userID uuid.UUID) ([]*models.Account, error)
+	GetReconciliation(id uuid.UUID) (*AccountReconciliation, error)
+	GetMismatchedReconciliations() ([]*AccountReconciliation, error)
+	GetBalanceHistory(id uuid.UUID, interval string, from, to time.Time) ([]*BalanceHistoryPoint, error)
+}
+
+// BalanceHistoryPoint is an account's balance at the end of one period of a
+// balance history. Change and Transactions cover the period's transactions;
+// LedgerTotal sums every transaction up to the end of the period, so Balance
+// is the opening balance plus LedgerTotal.
+type BalanceHistoryPoint struct {
+	PeriodStart  time.Time       `json:"period_start"`
+	PeriodEnd    time.Time       `json:"period_end" gorm:"-"`
+	Change       decimal.Decimal `json:"change"`
+	Transactions int64           `json:"transactions"`
+	LedgerTotal  decimal.Decimal `json:"-"`
+	Balance      decimal.Decimal `json:"balance" gorm:"-"`
+}
+
+// AccountReconciliation compares an account's stored balance with the balance
+// computed from its opening balance and transactions
+type AccountReconciliation struct {
+	AccountID        uuid.UUID       `json:"account_id"`
+	UserID           uuid.UUID       `json:"user_id"`
+	AccountName      string          `json:"account_name"`
+	OpeningBalance   decimal.Decimal `json:"opening_balance"`
+	TransactionTotal decimal.Decimal `json:"transaction_total"`
+	StoredBalance    decimal.Decimal `json:"stored_balance"`
+	ComputedBalance  decimal.Decimal `json:"computed_balance"`
+	Difference       decimal.Decimal `json:"difference"`
+	Balanced         bool            `json:"balanced"`
 }
 
 // CategoryRepository interface defines methods for category data access
 type CategoryRepository interface {
 	Create(category *models.Category) error
+	CreateBatch(categories []*models.Category) error
 	GetByID(id uuid.UUID) (*models.Category, error)
-	GetAll() ([]*models.Category, error)
-	GetByType(categoryType models.CategoryType) ([]*models.Category, error)
+	GetByUserID(userID uuid.UUID) ([]*models.Category, error)
+	GetByType(userID uuid.UUID, categoryType models.CategoryType) ([]*models.Category, error)
+	GetDescendantIDs(id uuid.UUID) ([]uuid.UUID, error)
+	GetUsage(id uuid.UUID) (*CategoryUsage, error)
+	Reassign(fromID, toID uuid.UUID) (*CategoryUsage, error)
 	Update(category *models.Category) error
 	Delete(id uuid.UUID) error
-	Exists(id uuid.UUID) (bool, error)
+	Exists(userID, id uuid.UUID) (bool, error)
+}
+
+// CategoryUsage counts the records that refer to a category
+type CategoryUsage struct {
+	Transactions          int64 `json:"transactions"`
+	TransactionSplits     int64 `json:"transaction_splits"`
+	Budgets               int64 `json:"budgets"`
+	RecurringTransactions int64 `json:"recurring_transactions"`
+	RecurringExceptions   int64 `json:"recurring_exceptions"`
+	ImportProfiles        int64 `json:"import_profiles"`
+	Subcategories         int64 `json:"subcategories"`
 }
 
-// TransactionFilter holds filter parameters for transaction queries
+// Total returns the number of referring records
+func (u CategoryUsage) Total() int64 {
+	return u.Transactions + u.TransactionSplits + u.Budgets + u.RecurringTransactions +
+		u.RecurringExceptions + u.ImportProfiles + u.Subcategories
+}
+
+// TagMatch selects how a transaction filter matches several tags
+type TagMatch string
+
+const (
+	// TagMatchAny matches transactions with at least one of the tags
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll matches transactions with every one of the tags
+	TagMatchAll TagMatch = "all"
+)
+
+// TransactionFilter holds filter parameters for transaction queries. Tags
+// are matched by name, ignoring case.
 type TransactionFilter struct {
 	UserID     uuid.UUID
 	AccountID  *uuid.UUID
 	CategoryID *uuid.UUID
+	Tags       []string
+	TagMatch   TagMatch
 	StartDate  *time.Time
 	EndDate    *time.Time
 	MinAmount  *decimal.Decimal
@@ -53,22 +120,238 @@ type TransactionFilter struct {
 	Offset     int
 }
 
-// TransactionSummary represents spending summary by category
+// TransactionSummary represents spending summary by category. TotalAmount
+// and Count include the category's subcategories; OwnAmount is the part
+// assigned to the category itself. Amounts are in the reporting currency.
 type TransactionSummary struct {
 	CategoryID   uuid.UUID       `json:"category_id"`
 	CategoryName string          `json:"category_name"`
+	ParentID     *uuid.UUID      `json:"parent_id,omitempty"`
 	TotalAmount  decimal.Decimal `json:"total_amount"`
+	OwnAmount    decimal.Decimal `json:"own_amount"`
 	Count        int64           `json:"count"`
 }
 
+// PeriodTransactionSummary is the spending summary of a category within the
+// period starting at PeriodStart
+type PeriodTransactionSummary struct {
+	PeriodStart time.Time
+	TransactionSummary
+}
+
+// TagSummary represents transaction totals by tag. A transaction with
+// several tags counts towards each of them.
+type TagSummary struct {
+	TagID       uuid.UUID       `json:"tag_id"`
+	TagName     string          `json:"tag_name"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	Income      decimal.Decimal `json:"income"`
+	Expenses    decimal.Decimal `json:"expenses"`
+	Count       int64           `json:"count"`
+}
+
+// CurrencyConversion describes how amounts in FromCurrency were converted to
+// the reporting currency: Count allocations dated FirstDate to LastDate used
+// the rate published on RateDate. Rate and RateDate are nil when no rate was
+// available.
+type CurrencyConversion struct {
+	FromCurrency string           `json:"from_currency"`
+	ToCurrency   string           `json:"to_currency"`
+	Rate         *decimal.Decimal `json:"rate"`
+	RateDate     *time.Time       `json:"rate_date"`
+	FirstDate    time.Time        `json:"first_date"`
+	LastDate     time.Time        `json:"last_date"`
+	Count        int64            `json:"count"`
+}
+
+// ExchangeRateFilter holds filter parameters for exchange rate queries
+type ExchangeRateFilter struct {
+	UserID        uuid.UUID
+	BaseCurrency  string
+	QuoteCurrency string
+	StartDate     *time.Time
+	EndDate       *time.Time
+	Limit         int
+	Offset        int
+}
+
+// TransactionExportRow is a transaction with the names of its account and
+// category. Split transactions have no category of their own, so CategoryName
+// lists their split categories instead.
+type TransactionExportRow struct {
+	ID           uuid.UUID
+	Date         time.Time
+	AccountID    uuid.UUID
+	AccountName  string
+	CategoryID   *uuid.UUID
+	CategoryName string
+	Description  string
+	Amount       decimal.Decimal
+	Currency     string
+	TransferID   *uuid.UUID
+	ExternalID   *string
+}
+
+// TransactionAllocation is one category allocation of a transaction: a split
+// line, or the whole transaction when it is not split. Amount is the
+// allocated part of the transaction amount.
+type TransactionAllocation struct {
+	TransactionID uuid.UUID
+	Date          time.Time
+	Description   string
+	AccountID     uuid.UUID
+	TransferID    *uuid.UUID
+	CategoryID    *uuid.UUID
+	Amount        decimal.Decimal
+}
+
 // TransactionRepository interface defines methods for transaction data access
 type TransactionRepository interface {
 	Create(transaction *models.Transaction) error
 	GetByID(id uuid.UUID) (*models.Transaction, error)
+	GetForUpdate(id uuid.UUID) (*models.Transaction, error)
 	GetByFilter(filter TransactionFilter) ([]*models.Transaction, error)
+	StreamByFilter(filter TransactionFilter, fn func(row *TransactionExportRow) error) error
+	StreamAllocations(userID uuid.UUID, fn func(allocation *TransactionAllocation) error) error
+	GetDateRange(userID uuid.UUID) (first, last *time.Time, err error)
+	GetByTransferID(transferID uuid.UUID) ([]*models.Transaction, error)
+	GetByTransferIDForUpdate(transferID uuid.UUID) ([]*models.Transaction, error)
+	GetByAccountAndDateRange(accountID uuid.UUID, startDate, endDate time.Time) ([]*models.Transaction, error)
+	FindExternalIDs(accountID uuid.UUID, externalIDs []string) ([]string, error)
+	CreateBatch(transactions []*models.Transaction) error
 	Update(transaction *models.Transaction) error
+	ReplaceSplits(transactionID uuid.UUID, splits []models.TransactionSplit) error
 	Delete(id uuid.UUID) error
-	GetSummaryByCategory(userID uuid.UUID, startDate, endDate *time.Time) ([]*TransactionSummary, error)
-	GetTotalByDateRange(userID uuid.UUID, startDate, endDate time.Time) (decimal.Decimal, error)
+	GetSummaryByCategory(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*TransactionSummary, error)
+	GetSummaryByCategoryAndPeriod(userID uuid.UUID, currency, period string, startDate, endDate time.Time) ([]*PeriodTransactionSummary, error)
+	GetSummaryByTag(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*TagSummary, error)
+	GetTotalByDateRange(userID uuid.UUID, currency string, startDate, endDate time.Time) (decimal.Decimal, error)
+	GetConversions(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*CurrencyConversion, error)
 	Count(filter TransactionFilter) (int64, error)
+	CountOwned(userID uuid.UUID, ids []uuid.UUID) (int64, error)
+}
+
+// BudgetRepository interface defines methods for budget data access
+type BudgetRepository interface {
+	Create(budget *models.Budget) error
+	GetByID(id uuid.UUID) (*models.Budget, error)
+	GetByUserID(userID uuid.UUID) ([]*models.Budget, error)
+	Update(budget *models.Budget) error
+	Delete(id uuid.UUID) error
+}
+
+// RecurringRepository interface defines methods for recurring transaction data access
+type RecurringRepository interface {
+	Create(recurring *models.RecurringTransaction) error
+	GetByID(id uuid.UUID) (*models.RecurringTransaction, error)
+	GetByUserID(userID uuid.UUID) ([]*models.RecurringTransaction, error)
+	GetDue(asOf time.Time) ([]*models.RecurringTransaction, error)
+	Update(recurring *models.RecurringTransaction) error
+	UpdateNextOccurrence(id uuid.UUID, next *time.Time) error
+	Delete(id uuid.UUID) error
+	SaveException(exception *models.RecurringException) error
+	DeleteException(recurringID uuid.UUID, occurrenceDate time.Time) error
+}
+
+// ImportProfileRepository interface defines methods for import profile data access
+type ImportProfileRepository interface {
+	Create(profile *models.ImportProfile) error
+	GetByID(id uuid.UUID) (*models.ImportProfile, error)
+	GetByUserID(userID uuid.UUID) ([]*models.ImportProfile, error)
+	Update(profile *models.ImportProfile) error
+	Delete(id uuid.UUID) error
+}
+
+// TagRepository interface defines methods for tag data access
+type TagRepository interface {
+	Create(tag *models.Tag) error
+	GetByID(id uuid.UUID) (*models.Tag, error)
+	GetByUserID(userID uuid.UUID) ([]*models.Tag, error)
+	Update(tag *models.Tag) error
+	Delete(id uuid.UUID) error
+	AddToTransactions(tagID uuid.UUID, transactionIDs []uuid.UUID) (int64, error)
+	RemoveFromTransactions(tagID uuid.UUID, transactionIDs []uuid.UUID) (int64, error)
+}
+
+// ExchangeRateRepository interface defines methods for exchange rate data access
+type ExchangeRateRepository interface {
+	Upsert(rates []*models.ExchangeRate) error
+	GetByID(id uuid.UUID) (*models.ExchangeRate, error)
+	GetByFilter(filter ExchangeRateFilter) ([]*models.ExchangeRate, error)
+	Delete(id uuid.UUID) error
+}
+
+// CreditCardStatementRepository interface defines methods for credit card
+// statement data access
+type CreditCardStatementRepository interface {
+	Create(statements []*models.CreditCardStatement) error
+	GetByAccountID(accountID uuid.UUID) ([]*models.CreditCardStatement, error)
+	GetLatest(accountID uuid.UUID) (*models.CreditCardStatement, error)
+	GetPaidAmounts(accountID uuid.UUID) (map[uuid.UUID]decimal.Decimal, error)
+	GetCycleActivity(accountID uuid.UUID, start, end time.Time) (*CycleActivity, error)
+	GetFirstTransactionDate(accountID uuid.UUID) (*time.Time, error)
+	GetBillingAccounts() ([]*models.Account, error)
+}
+
+// CycleActivity sums an account's transactions up to the end of a billing
+// cycle. Charges and credits are the cycle's negative and positive
+// transactions, both as positive amounts.
+type CycleActivity struct {
+	PriorTotal decimal.Decimal
+	Charges    decimal.Decimal
+	Credits    decimal.Decimal
+}
+
+// NetWorthRepository interface defines methods for net worth data access
+type NetWorthRepository interface {
+	GetAccountBalances(userID uuid.UUID, currency string, date time.Time) ([]*AccountNetWorth, error)
+	UpsertSnapshot(snapshot *models.NetWorthSnapshot) error
+	GetSnapshots(userID uuid.UUID, startDate, endDate *time.Time) ([]*models.NetWorthSnapshot, error)
+	GetSnapshotUsers() ([]*models.User, error)
+}
+
+// AccountNetWorth is an active account's balance together with its value in
+// the reporting currency, converted with the latest rate on or before the
+// date and rounded to the currency's minor units. Rate, RateDate and
+// ConvertedBalance are nil when no rate is available.
+type AccountNetWorth struct {
+	AccountID        uuid.UUID           `json:"account_id"`
+	AccountName      string              `json:"account_name"`
+	Type             models.AccountType  `json:"type"`
+	Class            models.AccountClass `json:"class" gorm:"-"`
+	Currency         string              `json:"currency"`
+	Balance          decimal.Decimal     `json:"balance"`
+	Rate             *decimal.Decimal    `json:"rate"`
+	RateDate         *time.Time          `json:"rate_date"`
+	ConvertedBalance *decimal.Decimal    `json:"converted_balance"`
+}
+
+// UserData is a user together with every record they own. Transactions carry
+// their splits, recurring transactions their exceptions and budgets their
+// categories. Categories holds every category the records may refer to.
+type UserData struct {
+	User              models.User
+	Accounts          []models.Account
+	Categories        []models.Category
+	Tags              []models.Tag
+	ExchangeRates     []models.ExchangeRate
+	Statements        []models.CreditCardStatement
+	NetWorthSnapshots []models.NetWorthSnapshot
+	Recurring         []models.RecurringTransaction
+	Transactions      []models.Transaction
+	Budgets           []models.Budget
+	ImportProfiles    []models.ImportProfile
+}
+
+// BackupRepository interface defines methods for loading, inserting and
+// deleting a user's complete data set
+type BackupRepository interface {
+	LoadUserData(userID uuid.UUID) (*UserData, error)
+	InsertUserData(data *UserData) error
+	DeleteUserData(userID uuid.UUID) error
+}
+
+// UnitOfWork runs a set of repository operations atomically
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
 }
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type netWorthRepository struct {
+	db *gorm.DB
+}
+
+// NewNetWorthRepository creates a new net worth repository
+func NewNetWorthRepository(db *gorm.DB) NetWorthRepository {
+	return &netWorthRepository{db: db}
+}
+
+// accountFxJoin joins, as fx, the rate converting an account's currency to
+// the reporting currency on a date, with arguments currency, date, currency,
+// currency. It works like fxJoin, for account balances instead of
+// transactions.
+const accountFxJoin = `LEFT JOIN LATERAL (
+		SELECT 1::numeric AS rate, NULL::date AS date WHERE accounts.currency = ?
+		UNION ALL
+		(SELECT CASE WHEN exchange_rates.base_currency = accounts.currency
+				THEN exchange_rates.rate ELSE 1 / exchange_rates.rate END,
+			exchange_rates.date
+		FROM exchange_rates
+		WHERE exchange_rates.user_id = accounts.user_id
+			AND exchange_rates.date <= ?
+			AND ((exchange_rates.base_currency = accounts.currency AND exchange_rates.quote_currency = ?)
+				OR (exchange_rates.base_currency = ? AND exchange_rates.quote_currency = accounts.currency))
+		ORDER BY exchange_rates.date DESC, exchange_rates.base_currency = accounts.currency DESC
+		LIMIT 1)
+		LIMIT 1
+	) AS fx ON true`
+
+// GetAccountBalances retrieves the balances of a user's active accounts,
+// converted to currency with the rates on date, oldest account first
+func (r *netWorthRepository) GetAccountBalances(userID uuid.UUID, currency string, date time.Time) ([]*AccountNetWorth, error) {
+	var balances []*AccountNetWorth
+	err := r.db.Table("accounts").
+		Select(fmt.Sprintf("accounts.id AS account_id, accounts.name AS account_name, accounts.type, "+
+			"accounts.currency, accounts.balance, fx.rate, fx.date AS rate_date, "+
+			"ROUND(accounts.balance * fx.rate, %d) AS converted_balance", iso4217.MinorUnits(currency))).
+		Joins(accountFxJoin, currency, date, currency, currency).
+		Where("accounts.user_id = ? AND accounts.is_active", userID).
+		Order("accounts.created_at ASC, accounts.id ASC").
+		Scan(&balances).Error
+	return balances, err
+}
+
+// UpsertSnapshot stores a snapshot, replacing the one already taken for the
+// same user and day
+func (r *netWorthRepository) UpsertSnapshot(snapshot *models.NetWorthSnapshot) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"currency", "assets", "liabilities", "net_worth", "updated_at"}),
+	}).Create(snapshot).Error
+}
+
+// GetSnapshots retrieves a user's snapshots in the date range, oldest first
+func (r *netWorthRepository) GetSnapshots(userID uuid.UUID, startDate, endDate *time.Time) ([]*models.NetWorthSnapshot, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if startDate != nil {
+		query = query.Where("date >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("date <= ?", *endDate)
+	}
+
+	var snapshots []*models.NetWorthSnapshot
+	err := query.Order("date ASC").Find(&snapshots).Error
+	return snapshots, err
+}
+
+// GetSnapshotUsers retrieves every user with at least one active account
+func (r *netWorthRepository) GetSnapshotUsers() ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.Where("EXISTS (SELECT 1 FROM accounts WHERE accounts.user_id = users.id AND accounts.is_active)").
+		Order("created_at ASC").Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type recurringRepository struct {
+	db *gorm.DB
+}
+
+// NewRecurringRepository creates a new recurring transaction repository
+func NewRecurringRepository(db *gorm.DB) RecurringRepository {
+	return &recurringRepository{db: db}
+}
+
+// Create creates a new recurring transaction
+func (r *recurringRepository) Create(recurring *models.RecurringTransaction) error {
+	return r.db.Omit(clause.Associations).Create(recurring).Error
+}
+
+// GetByID retrieves a recurring transaction by ID with its exceptions
+func (r *recurringRepository) GetByID(id uuid.UUID) (*models.RecurringTransaction, error) {
+	var recurring models.RecurringTransaction
+	err := r.db.Preload("Account").Preload("Category").
+		Preload("Exceptions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurrence_date ASC")
+		}).
+		First(&recurring, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecurringNotFound
+		}
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// GetByUserID retrieves all recurring transactions for a user
+func (r *recurringRepository) GetByUserID(userID uuid.UUID) ([]*models.RecurringTransaction, error) {
+	var recurring []*models.RecurringTransaction
+	err := r.db.Preload("Account").Preload("Category").
+		Where("user_id = ?", userID).
+		Order("next_occurrence ASC NULLS LAST, description ASC").
+		Find(&recurring).Error
+	return recurring, err
+}
+
+// GetDue retrieves active recurring transactions with an occurrence on or before asOf
+func (r *recurringRepository) GetDue(asOf time.Time) ([]*models.RecurringTransaction, error) {
+	var recurring []*models.RecurringTransaction
+	err := r.db.Preload("Exceptions").
+		Where("is_active AND next_occurrence IS NOT NULL AND next_occurrence <= ?", asOf).
+		Order("next_occurrence ASC").
+		Find(&recurring).Error
+	return recurring, err
+}
+
+// Update updates a recurring transaction without touching its associations
+func (r *recurringRepository) Update(recurring *models.RecurringTransaction) error {
+	return r.db.Omit(clause.Associations).Save(recurring).Error
+}
+
+// UpdateNextOccurrence records the earliest occurrence not generated yet
+func (r *recurringRepository) UpdateNextOccurrence(id uuid.UUID, next *time.Time) error {
+	return r.db.Model(&models.RecurringTransaction{}).Where("id = ?", id).
+		Update("next_occurrence", next).Error
+}
+
+// Delete deletes a recurring transaction by ID. Its exceptions are removed by
+// cascade and transactions it generated are kept.
+func (r *recurringRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.RecurringTransaction{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecurringNotFound
+	}
+	return nil
+}
+
+// SaveException creates or replaces the exception for an occurrence
+func (r *recurringRepository) SaveException(exception *models.RecurringException) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "recurring_id"}, {Name: "occurrence_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"action", "amount", "description", "category_id", "date", "updated_at",
+		}),
+	}).Create(exception).Error
+}
+
+// DeleteException removes the exception for an occurrence, if any
+func (r *recurringRepository) DeleteException(recurringID uuid.UUID, occurrenceDate time.Time) error {
+	return r.db.Where("recurring_id = ? AND occurrence_date = ?", recurringID, occurrenceDate).
+		Delete(&models.RecurringException{}).Error
+}
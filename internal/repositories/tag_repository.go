@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// Create creates a new tag
+func (r *tagRepository) Create(tag *models.Tag) error {
+	err := r.db.Create(tag).Error
+	if isUniqueViolation(err) {
+		return ErrDuplicateTag
+	}
+	return err
+}
+
+// GetByID retrieves a tag by ID
+func (r *tagRepository) GetByID(id uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.First(&tag, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByUserID retrieves all tags of a user
+func (r *tagRepository) GetByUserID(userID uuid.UUID) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := r.db.Where("user_id = ?", userID).Order("lower(name) ASC").Find(&tags).Error
+	return tags, err
+}
+
+// Update updates a tag
+func (r *tagRepository) Update(tag *models.Tag) error {
+	err := r.db.Save(tag).Error
+	if isUniqueViolation(err) {
+		return ErrDuplicateTag
+	}
+	return err
+}
+
+// Delete deletes a tag by ID; it is removed from transactions by cascade
+func (r *tagRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Tag{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// AddToTransactions attaches a tag to transactions and returns how many did
+// not have it yet
+func (r *tagRepository) AddToTransactions(tagID uuid.UUID, transactionIDs []uuid.UUID) (int64, error) {
+	if len(transactionIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.Exec(`INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT id, ? FROM transactions WHERE id IN ?
+		ON CONFLICT DO NOTHING`, tagID, transactionIDs)
+	return result.RowsAffected, result.Error
+}
+
+// RemoveFromTransactions detaches a tag from transactions and returns how
+// many had it
+func (r *tagRepository) RemoveFromTransactions(tagID uuid.UUID, transactionIDs []uuid.UUID) (int64, error) {
+	if len(transactionIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.Exec("DELETE FROM transaction_tags WHERE tag_id = ? AND transaction_id IN ?",
+		tagID, transactionIDs)
+	return result.RowsAffected, result.Error
+}
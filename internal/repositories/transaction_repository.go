@@ -2,12 +2,16 @@ package repositories
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
 	"github.com/vasujain275/expense-tracker-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transactionRepository struct {
@@ -21,16 +25,38 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepository {
 
 // Create creates a new transaction
 func (r *transactionRepository) Create(transaction *models.Transaction) error {
-	return r.db.Create(transaction).Error
+	err := r.db.Create(transaction).Error
+	if isUniqueViolation(err) {
+		return ErrDuplicateTransaction
+	}
+	return err
 }
 
 // GetByID retrieves a transaction by ID with related data
 func (r *transactionRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
 	var transaction models.Transaction
-	err := r.db.Preload("Account").Preload("Category").First(&transaction, "id = ?", id).Error
+	err := r.db.Preload("Account").Preload("Category").Preload("Splits.Category").Preload("Tags").
+		First(&transaction, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// GetForUpdate retrieves a transaction with its account and splits, locking
+// the transaction row until the surrounding database transaction ends. Used
+// inside a unit of work, it serializes concurrent updates and deletes of the
+// same transaction.
+func (r *transactionRepository) GetForUpdate(id uuid.UUID) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Account").Preload("Splits").
+		First(&transaction, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("transaction not found")
+			return nil, ErrTransactionNotFound
 		}
 		return nil, err
 	}
@@ -39,29 +65,39 @@ func (r *transactionRepository) GetByID(id uuid.UUID) (*models.Transaction, erro
 
 // GetByFilter retrieves transactions based on filter criteria
 func (r *transactionRepository) GetByFilter(filter TransactionFilter) ([]*models.Transaction, error) {
-	query := r.db.Preload("Account").Preload("Category").Where("user_id = ?", filter.UserID)
+	query := applyTransactionFilter(
+		r.db.Preload("Account").Preload("Category").Preload("Splits.Category").Preload("Tags"),
+		filter,
+	)
 
-	// Apply filters
-	if filter.AccountID != nil {
-		query = query.Where("account_id = ?", *filter.AccountID)
-	}
-	if filter.CategoryID != nil {
-		query = query.Where("category_id = ?", *filter.CategoryID)
-	}
-	if filter.StartDate != nil {
-		query = query.Where("date >= ?", *filter.StartDate)
-	}
-	if filter.EndDate != nil {
-		query = query.Where("date <= ?", *filter.EndDate)
-	}
-	if filter.MinAmount != nil {
-		query = query.Where("amount >= ?", *filter.MinAmount)
+	// Apply pagination
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
 	}
-	if filter.MaxAmount != nil {
-		query = query.Where("amount <= ?", *filter.MaxAmount)
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
 	}
 
-	// Apply pagination
+	var transactions []*models.Transaction
+	err := query.Order("transactions.date DESC, transactions.created_at DESC").Find(&transactions).Error
+	return transactions, err
+}
+
+// StreamByFilter calls fn for every transaction matching the filter, reading
+// rows from the database cursor one at a time instead of loading them all.
+// Iteration stops at the first error returned by fn.
+func (r *transactionRepository) StreamByFilter(filter TransactionFilter, fn func(row *TransactionExportRow) error) error {
+	query := applyTransactionFilter(r.db.Table("transactions"), filter).
+		Select("transactions.id, transactions.date, transactions.account_id, " +
+			"accounts.name AS account_name, transactions.category_id, " +
+			"COALESCE(categories.name, (SELECT string_agg(split_categories.name, '; ' ORDER BY split_categories.name) " +
+			"FROM transaction_splits JOIN categories AS split_categories ON split_categories.id = transaction_splits.category_id " +
+			"WHERE transaction_splits.transaction_id = transactions.id), '') AS category_name, " +
+			"transactions.description, transactions.amount, accounts.currency, " +
+			"transactions.transfer_id, transactions.external_id").
+		Joins("JOIN accounts ON accounts.id = transactions.account_id").
+		Joins("LEFT JOIN categories ON categories.id = transactions.category_id")
+
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
@@ -69,35 +105,243 @@ func (r *transactionRepository) GetByFilter(filter TransactionFilter) ([]*models
 		query = query.Offset(filter.Offset)
 	}
 
+	rows, err := query.Order("transactions.date DESC, transactions.created_at DESC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row TransactionExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamAllocations calls fn for every category allocation of the user's
+// transactions in date order. Allocations of the same transaction are
+// consecutive. Rows are read from the database cursor one at a time.
+func (r *transactionRepository) StreamAllocations(userID uuid.UUID, fn func(allocation *TransactionAllocation) error) error {
+	rows, err := r.db.Table("transactions").
+		Select("transactions.id AS transaction_id, transactions.date, transactions.description, "+
+			"transactions.account_id, transactions.transfer_id, "+
+			"COALESCE(transaction_splits.category_id, transactions.category_id) AS category_id, "+
+			"COALESCE(transaction_splits.amount, transactions.amount) AS amount").
+		Joins("LEFT JOIN transaction_splits ON transaction_splits.transaction_id = transactions.id").
+		Where("transactions.user_id = ?", userID).
+		Order("transactions.date ASC, transactions.created_at ASC, transactions.id ASC, transaction_splits.created_at ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var allocation TransactionAllocation
+		if err := r.db.ScanRows(rows, &allocation); err != nil {
+			return err
+		}
+		if err := fn(&allocation); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetDateRange returns the dates of the user's first and last transactions,
+// or nil when the user has none
+func (r *transactionRepository) GetDateRange(userID uuid.UUID) (first, last *time.Time, err error) {
+	var result struct {
+		First *time.Time
+		Last  *time.Time
+	}
+	err = r.db.Model(&models.Transaction{}).
+		Select("MIN(date) AS first, MAX(date) AS last").
+		Where("user_id = ?", userID).
+		Scan(&result).Error
+	return result.First, result.Last, err
+}
+
+// GetByTransferID retrieves both legs of a transfer
+func (r *transactionRepository) GetByTransferID(transferID uuid.UUID) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	err := r.db.Preload("Account").Where("transfer_id = ?", transferID).
+		Order("amount ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+// GetByTransferIDForUpdate retrieves both legs of a transfer like
+// GetByTransferID, locking them until the surrounding database transaction ends
+func (r *transactionRepository) GetByTransferIDForUpdate(transferID uuid.UUID) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Account").
+		Where("transfer_id = ?", transferID).Order("amount ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+// GetByAccountAndDateRange retrieves an account's transactions dated within the range, inclusive
+func (r *transactionRepository) GetByAccountAndDateRange(accountID uuid.UUID, startDate, endDate time.Time) ([]*models.Transaction, error) {
 	var transactions []*models.Transaction
-	err := query.Order("date DESC, created_at DESC").Find(&transactions).Error
+	err := r.db.Where("account_id = ? AND date >= ? AND date <= ?", accountID, startDate, endDate).
+		Order("date ASC").Find(&transactions).Error
 	return transactions, err
 }
 
-// Update updates a transaction
+// FindExternalIDs returns which of the given external IDs already exist on the account
+func (r *transactionRepository) FindExternalIDs(accountID uuid.UUID, externalIDs []string) ([]string, error) {
+	var found []string
+	if len(externalIDs) == 0 {
+		return found, nil
+	}
+	err := r.db.Model(&models.Transaction{}).
+		Where("account_id = ? AND external_id IN ?", accountID, externalIDs).
+		Pluck("external_id", &found).Error
+	return found, err
+}
+
+// CreateBatch inserts many transactions in batches
+func (r *transactionRepository) CreateBatch(transactions []*models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	err := r.db.Omit(clause.Associations).CreateInBatches(transactions, 500).Error
+	if isUniqueViolation(err) {
+		return ErrDuplicateTransaction
+	}
+	return err
+}
+
+// Update writes the editable columns of a transaction. Preloaded associations
+// are not saved, so a changed AccountID or CategoryID is not overwritten by
+// the stale relation. Unlike a save it never inserts: a transaction deleted
+// in the meantime is reported as not found.
 func (r *transactionRepository) Update(transaction *models.Transaction) error {
-	return r.db.Save(transaction).Error
+	result := r.db.Model(transaction).
+		Select("account_id", "category_id", "amount", "description", "date",
+			"original_amount", "original_currency", "updated_at").
+		Updates(transaction)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransactionNotFound
+	}
+	return nil
+}
+
+// ReplaceSplits replaces all split lines of a transaction with the given ones
+func (r *transactionRepository) ReplaceSplits(transactionID uuid.UUID, splits []models.TransactionSplit) error {
+	if err := r.db.Where("transaction_id = ?", transactionID).Delete(&models.TransactionSplit{}).Error; err != nil {
+		return err
+	}
+	if len(splits) == 0 {
+		return nil
+	}
+	for i := range splits {
+		splits[i].TransactionID = transactionID
+	}
+	return r.db.Omit(clause.Associations).Create(&splits).Error
 }
 
-// Delete deletes a transaction by ID
+// Delete deletes a transaction and its split lines by ID
 func (r *transactionRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("transaction_id = ?", id).Delete(&models.TransactionSplit{}).Error; err != nil {
+		return err
+	}
 	result := r.db.Delete(&models.Transaction{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("transaction not found")
+		return ErrTransactionNotFound
 	}
 	return nil
 }
 
-// GetSummaryByCategory gets spending summary grouped by category
-func (r *transactionRepository) GetSummaryByCategory(userID uuid.UUID, startDate, endDate *time.Time) ([]*TransactionSummary, error) {
+// categoryTree pairs every category of the user given as its argument with
+// itself and each of its descendants, so joining allocations on the
+// descendant credits them to every ancestor
+const categoryTree = `WITH RECURSIVE category_tree AS (
+		SELECT id AS ancestor_id, id AS category_id FROM categories WHERE user_id = ?
+		UNION
+		SELECT category_tree.ancestor_id, categories.id FROM categories
+		JOIN category_tree ON categories.parent_id = category_tree.category_id
+	)`
+
+// GetSummaryByCategory gets spending summary grouped by category. Split
+// transactions contribute their allocations rather than the parent category.
+// Transfer legs move money between accounts and are neither income nor
+// expense, so they are excluded. Each category's total includes the spending
+// of all its subcategories, found through a recursive walk of the hierarchy.
+func (r *transactionRepository) GetSummaryByCategory(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*TransactionSummary, error) {
+	allocations := r.allocations(currency).
+		Where("transactions.user_id = ? AND transactions.transfer_id IS NULL", userID)
+	if startDate != nil {
+		allocations = allocations.Where("transactions.date >= ?", *startDate)
+	}
+	if endDate != nil {
+		allocations = allocations.Where("transactions.date <= ?", *endDate)
+	}
+
+	var summaries []*TransactionSummary
+	err := r.db.Raw(categoryTree+`
+		SELECT categories.id AS category_id, categories.name AS category_name, categories.parent_id,
+			SUM(ABS(allocations.amount)) AS total_amount,
+			COALESCE(SUM(ABS(allocations.amount)) FILTER (WHERE category_tree.category_id = category_tree.ancestor_id), 0) AS own_amount,
+			COUNT(DISTINCT allocations.transaction_id) AS count
+		FROM (?) AS allocations
+		JOIN category_tree ON allocations.category_id = category_tree.category_id
+		JOIN categories ON categories.id = category_tree.ancestor_id
+		GROUP BY categories.id, categories.name, categories.parent_id
+		ORDER BY total_amount DESC`, userID, allocations).Scan(&summaries).Error
+	return summaries, err
+}
+
+// GetSummaryByCategoryAndPeriod is GetSummaryByCategory for every period from
+// startDate to endDate inclusive in one query. Transactions are grouped by
+// the UTC period ("week", "month" or "year") they fall in; weeks start on
+// Monday. Periods without transactions have no rows.
+func (r *transactionRepository) GetSummaryByCategoryAndPeriod(userID uuid.UUID, currency, period string, startDate, endDate time.Time) ([]*PeriodTransactionSummary, error) {
+	allocations := r.allocations(currency).
+		Where("transactions.user_id = ? AND transactions.transfer_id IS NULL", userID).
+		Where("transactions.date >= ? AND transactions.date <= ?", startDate, endDate)
+
+	var summaries []*PeriodTransactionSummary
+	err := r.db.Raw(categoryTree+`
+		SELECT date_trunc(?, allocations.date AT TIME ZONE 'UTC') AS period_start,
+			categories.id AS category_id, categories.name AS category_name, categories.parent_id,
+			SUM(ABS(allocations.amount)) AS total_amount,
+			COALESCE(SUM(ABS(allocations.amount)) FILTER (WHERE category_tree.category_id = category_tree.ancestor_id), 0) AS own_amount,
+			COUNT(DISTINCT allocations.transaction_id) AS count
+		FROM (?) AS allocations
+		JOIN category_tree ON allocations.category_id = category_tree.category_id
+		JOIN categories ON categories.id = category_tree.ancestor_id
+		GROUP BY 1, categories.id, categories.name, categories.parent_id
+		ORDER BY period_start, total_amount DESC`, userID, period, allocations).Scan(&summaries).Error
+	return summaries, err
+}
+
+// GetSummaryByTag gets transaction totals grouped by tag, parallel to
+// GetSummaryByCategory. TotalAmount is the sum of absolute amounts, split
+// into income and expenses. Transfer legs are excluded.
+func (r *transactionRepository) GetSummaryByTag(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*TagSummary, error) {
+	amount := convertedAmount(currency)
 	query := r.db.Table("transactions").
-		Select("category_id, categories.name as category_name, SUM(ABS(amount)) as total_amount, COUNT(*) as count").
-		Joins("JOIN categories ON transactions.category_id = categories.id").
-		Where("transactions.user_id = ?", userID).
-		Group("category_id, categories.name")
+		Select("tags.id AS tag_id, tags.name AS tag_name, "+
+			"SUM(ABS("+amount+")) AS total_amount, "+
+			"COALESCE(SUM("+amount+") FILTER (WHERE transactions.amount > 0), 0) AS income, "+
+			"COALESCE(-SUM("+amount+") FILTER (WHERE transactions.amount < 0), 0) AS expenses, "+
+			"COUNT(*) AS count").
+		Joins(fxJoin, currency, currency, currency).
+		Joins("JOIN transaction_tags ON transaction_tags.transaction_id = transactions.id").
+		Joins("JOIN tags ON tags.id = transaction_tags.tag_id").
+		Where("transactions.user_id = ? AND transactions.transfer_id IS NULL", userID).
+		Group("tags.id, tags.name")
 
 	if startDate != nil {
 		query = query.Where("transactions.date >= ?", *startDate)
@@ -106,46 +350,160 @@ func (r *transactionRepository) GetSummaryByCategory(userID uuid.UUID, startDate
 		query = query.Where("transactions.date <= ?", *endDate)
 	}
 
-	var summaries []*TransactionSummary
+	var summaries []*TagSummary
 	err := query.Order("total_amount DESC").Find(&summaries).Error
 	return summaries, err
 }
 
-// GetTotalByDateRange gets total transaction amount for a date range
-func (r *transactionRepository) GetTotalByDateRange(userID uuid.UUID, startDate, endDate time.Time) (decimal.Decimal, error) {
+// GetTotalByDateRange gets total transaction amount for a date range in the
+// reporting currency, excluding transfer legs
+func (r *transactionRepository) GetTotalByDateRange(userID uuid.UUID, currency string, startDate, endDate time.Time) (decimal.Decimal, error) {
 	var total decimal.Decimal
-	err := r.db.Model(&models.Transaction{}).
-		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
-		Select("COALESCE(SUM(amount), 0)").
+	err := r.db.Table("transactions").
+		Joins(fxJoin, currency, currency, currency).
+		Where("transactions.user_id = ? AND transactions.date >= ? AND transactions.date <= ? AND transactions.transfer_id IS NULL",
+			userID, startDate, endDate).
+		Select("COALESCE(SUM(" + convertedAmount(currency) + "), 0)").
 		Scan(&total).Error
 	return total, err
 }
 
+// GetConversions lists the exchange rates the reports over a date range use
+// to convert transactions into the reporting currency, one row per source
+// currency and rate. Rows with a nil rate count transactions that cannot be
+// converted for lack of a rate.
+func (r *transactionRepository) GetConversions(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*CurrencyConversion, error) {
+	query := r.db.Table("transactions").
+		Select("accounts.currency AS from_currency, ? AS to_currency, fx.rate, fx.date AS rate_date, "+
+			"MIN(transactions.date) AS first_date, MAX(transactions.date) AS last_date, COUNT(*) AS count", currency).
+		Joins(fxJoin, currency, currency, currency).
+		Where("transactions.user_id = ? AND transactions.transfer_id IS NULL AND accounts.currency <> ?", userID, currency).
+		Group("accounts.currency, fx.rate, fx.date")
+
+	if startDate != nil {
+		query = query.Where("transactions.date >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("transactions.date <= ?", *endDate)
+	}
+
+	var conversions []*CurrencyConversion
+	err := query.Order("from_currency ASC, rate_date ASC NULLS FIRST").Find(&conversions).Error
+	return conversions, err
+}
+
 // Count counts transactions based on filter criteria
 func (r *transactionRepository) Count(filter TransactionFilter) (int64, error) {
-	query := r.db.Model(&models.Transaction{}).Where("user_id = ?", filter.UserID)
+	var count int64
+	err := applyTransactionFilter(r.db.Model(&models.Transaction{}), filter).Count(&count).Error
+	return count, err
+}
+
+// allocations selects one row per category allocation: each split line of a
+// split transaction, or the transaction itself when it is not split. Amounts
+// are converted to the reporting currency and rounded to its minor units.
+func (r *transactionRepository) allocations(currency string) *gorm.DB {
+	return r.db.Table("transactions").
+		Select(fmt.Sprintf("transactions.id AS transaction_id, transactions.user_id, transactions.account_id, "+
+			"transactions.date, transactions.transfer_id, "+
+			"COALESCE(transaction_splits.category_id, transactions.category_id) AS category_id, "+
+			"ROUND(COALESCE(transaction_splits.amount, transactions.amount) * fx.rate, %d) AS amount",
+			iso4217.MinorUnits(currency))).
+		Joins(fxJoin, currency, currency, currency).
+		Joins("LEFT JOIN transaction_splits ON transaction_splits.transaction_id = transactions.id")
+}
+
+// fxJoin joins a transaction's account and, as fx, the rate converting the
+// account's currency to the reporting currency given three times: 1 when
+// they match, otherwise the user's latest rate on or before the transaction
+// date, inverted when only the opposite pair is known. fx.rate is NULL when
+// no rate is available.
+const fxJoin = `JOIN accounts ON accounts.id = transactions.account_id
+	LEFT JOIN LATERAL (
+		SELECT 1::numeric AS rate, NULL::date AS date WHERE accounts.currency = ?
+		UNION ALL
+		(SELECT CASE WHEN exchange_rates.base_currency = accounts.currency
+				THEN exchange_rates.rate ELSE 1 / exchange_rates.rate END,
+			exchange_rates.date
+		FROM exchange_rates
+		WHERE exchange_rates.user_id = transactions.user_id
+			AND exchange_rates.date <= transactions.date
+			AND ((exchange_rates.base_currency = accounts.currency AND exchange_rates.quote_currency = ?)
+				OR (exchange_rates.base_currency = ? AND exchange_rates.quote_currency = accounts.currency))
+		ORDER BY exchange_rates.date DESC, exchange_rates.base_currency = accounts.currency DESC
+		LIMIT 1)
+		LIMIT 1
+	) AS fx ON true`
+
+// convertedAmount is a transaction's amount in the reporting currency,
+// rounded to its minor units, for queries joining fxJoin
+func convertedAmount(currency string) string {
+	return fmt.Sprintf("ROUND(transactions.amount * fx.rate, %d)", iso4217.MinorUnits(currency))
+}
+
+// applyTransactionFilter applies the filter criteria shared by listing and counting
+func applyTransactionFilter(query *gorm.DB, filter TransactionFilter) *gorm.DB {
+	query = query.Where("transactions.user_id = ?", filter.UserID)
 
-	// Apply same filters as GetByFilter
 	if filter.AccountID != nil {
-		query = query.Where("account_id = ?", *filter.AccountID)
+		query = query.Where("transactions.account_id = ?", *filter.AccountID)
 	}
 	if filter.CategoryID != nil {
-		query = query.Where("category_id = ?", *filter.CategoryID)
+		// Match the transaction's own category or any of its split allocations
+		query = query.Where("transactions.category_id = ? OR EXISTS (SELECT 1 FROM transaction_splits "+
+			"WHERE transaction_splits.transaction_id = transactions.id AND transaction_splits.category_id = ?)",
+			*filter.CategoryID, *filter.CategoryID)
+	}
+	if len(filter.Tags) > 0 {
+		names := make([]string, 0, len(filter.Tags))
+		for _, name := range filter.Tags {
+			names = append(names, strings.ToLower(name))
+		}
+		tagged := "SELECT COUNT(DISTINCT lower(tags.name)) FROM transaction_tags " +
+			"JOIN tags ON tags.id = transaction_tags.tag_id " +
+			"WHERE transaction_tags.transaction_id = transactions.id AND lower(tags.name) IN ?"
+		if filter.TagMatch == TagMatchAll {
+			query = query.Where("("+tagged+") = ?", names, len(uniqueStrings(names)))
+		} else {
+			query = query.Where("("+tagged+") > 0", names)
+		}
 	}
 	if filter.StartDate != nil {
-		query = query.Where("date >= ?", *filter.StartDate)
+		query = query.Where("transactions.date >= ?", *filter.StartDate)
 	}
 	if filter.EndDate != nil {
-		query = query.Where("date <= ?", *filter.EndDate)
+		query = query.Where("transactions.date <= ?", *filter.EndDate)
 	}
 	if filter.MinAmount != nil {
-		query = query.Where("amount >= ?", *filter.MinAmount)
+		query = query.Where("transactions.amount >= ?", *filter.MinAmount)
 	}
 	if filter.MaxAmount != nil {
-		query = query.Where("amount <= ?", *filter.MaxAmount)
+		query = query.Where("transactions.amount <= ?", *filter.MaxAmount)
 	}
 
+	return query
+}
+
+// CountOwned counts how many of the given transactions belong to the user
+func (r *transactionRepository) CountOwned(userID uuid.UUID, ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
 	var count int64
-	err := query.Count(&count).Error
+	err := r.db.Model(&models.Transaction{}).
+		Where("user_id = ? AND id IN ?", userID, ids).Count(&count).Error
 	return count, err
 }
+
+// uniqueStrings returns the distinct values of s
+func uniqueStrings(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	unique := make([]string, 0, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
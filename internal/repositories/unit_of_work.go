@@ -0,0 +1,40 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Repositories bundles repositories that share the same database handle
+type Repositories struct {
+	Users        UserRepository
+	Accounts     AccountRepository
+	Categories   CategoryRepository
+	Transactions TransactionRepository
+	Backups      BackupRepository
+}
+
+// NewRepositories creates all repositories on top of the given database handle
+func NewRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Users:        NewUserRepository(db),
+		Accounts:     NewAccountRepository(db),
+		Categories:   NewCategoryRepository(db),
+		Transactions: NewTransactionRepository(db),
+		Backups:      NewBackupRepository(db),
+	}
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a unit of work backed by database transactions
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn inside a single SQL transaction. The transaction is committed if
+// fn returns nil and rolled back if it returns an error or panics.
+func (u *unitOfWork) Do(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vasujain275/expense-tracker-api/internal/models"
@@ -28,7 +30,7 @@ func (r *userRepository) GetByID(id uuid.UUID) (*models.User, error) {
 	err := r.db.First(&user, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -41,7 +43,7 @@ func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	err := r.db.First(&user, "email = ?", email).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -53,6 +55,40 @@ func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
+// RecordFailedLogin counts a failed login in a single UPDATE, so parallel
+// attempts cannot overwrite each other's increments. The attempt that reaches
+// maxAttempts locks the user until lockedUntil and resets the count; a
+// maxAttempts of zero never locks. It returns the user's lockout time, or nil
+// when the user is not locked.
+func (r *userRepository) RecordFailedLogin(id uuid.UUID, maxAttempts int, lockedUntil time.Time) (*time.Time, error) {
+	var until sql.NullTime
+	err := r.db.Raw(`UPDATE users SET
+			failed_login_attempts = CASE WHEN @max > 0 AND failed_login_attempts + 1 >= @max
+				THEN 0 ELSE failed_login_attempts + 1 END,
+			locked_until = CASE WHEN @max > 0 AND failed_login_attempts + 1 >= @max
+				THEN @until ELSE locked_until END,
+			updated_at = NOW()
+		WHERE id = @id
+		RETURNING locked_until`,
+		sql.Named("max", maxAttempts), sql.Named("until", lockedUntil), sql.Named("id", id)).
+		Scan(&until).Error
+	if err != nil {
+		return nil, err
+	}
+	if !until.Valid {
+		return nil, nil
+	}
+	return &until.Time, nil
+}
+
+// ResetLoginFailures clears the failed login count and lockout of a user
+func (r *userRepository) ResetLoginFailures(id uuid.UUID) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+	}).Error
+}
+
 // Delete deletes a user by ID
 func (r *userRepository) Delete(id uuid.UUID) error {
 	result := r.db.Delete(&models.User{}, "id = ?", id)
@@ -60,7 +96,7 @@ func (r *userRepository) Delete(id uuid.UUID) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 	return nil
 }
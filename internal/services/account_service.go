@@ -2,7 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,9 @@ import (
 	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 )
 
+// maxBalanceHistoryDays limits the range of a balance history to ten years
+const maxBalanceHistoryDays = 3660
+
 type accountService struct {
 	accountRepo repositories.AccountRepository
 	userRepo    repositories.UserRepository
@@ -23,45 +28,57 @@ func NewAccountService(accountRepo repositories.AccountRepository, userRepo repo
 	}
 }
 
-// CreateAccount creates a new account for a user
-func (s *accountService) CreateAccount(userID uuid.UUID, name string, accountType models.AccountType, initialBalance decimal.Decimal) (*models.Account, error) {
+// CreateAccount creates a new account for a user in the given currency, or
+// in the user's currency when none is given. Credit card accounts may also
+// get their credit card terms.
+func (s *accountService) CreateAccount(userID uuid.UUID, name string, accountType models.AccountType, currency string, initialBalance decimal.Decimal, card CreditCardSettings) (*models.Account, error) {
 	// Validate inputs
 	if err := s.validateAccountInput(userID, name, accountType); err != nil {
 		return nil, err
 	}
 
 	// Check if user exists
-	exists, err := s.userRepo.Exists(userID)
+	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, errors.New("user not found")
+
+	// Accounts default to the user's currency; it cannot change later, as
+	// the account's amounts are recorded in it
+	if currency == "" {
+		currency = user.Currency
+	}
+	currency, err = normalizeCurrency(currency)
+	if err != nil {
+		return nil, err
 	}
+	initialBalance = roundAmount(initialBalance, currency)
 
 	// Create account
 	account := &models.Account{
-		UserID:   userID,
-		Name:     strings.TrimSpace(name),
-		Type:     accountType,
-		Balance:  initialBalance,
-		IsActive: true,
+		UserID:         userID,
+		Name:           strings.TrimSpace(name),
+		Type:           accountType,
+		Currency:       currency,
+		OpeningBalance: initialBalance,
+		Balance:        initialBalance,
+		IsActive:       true,
+	}
+	if err := applyCreditCardSettings(account, card); err != nil {
+		return nil, err
 	}
 
 	if err := s.accountRepo.Create(account); err != nil {
 		return nil, err
 	}
 
+	account.UpdateAvailableCredit()
 	return account, nil
 }
 
-// GetAccountByID retrieves an account by ID
-func (s *accountService) GetAccountByID(id uuid.UUID) (*models.Account, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid account ID")
-	}
-
-	return s.accountRepo.GetByID(id)
+// GetAccountByID retrieves an account by ID if it belongs to the user
+func (s *accountService) GetAccountByID(userID, id uuid.UUID) (*models.Account, error) {
+	return ownedAccount(s.accountRepo, userID, id)
 }
 
 // GetUserAccounts retrieves all accounts for a user
@@ -76,7 +93,7 @@ func (s *accountService) GetUserAccounts(userID uuid.UUID, activeOnly bool) ([]*
 		return nil, err
 	}
 	if !exists {
-		return nil, errors.New("user not found")
+		return nil, repositories.ErrUserNotFound
 	}
 
 	if activeOnly {
@@ -86,14 +103,12 @@ func (s *accountService) GetUserAccounts(userID uuid.UUID, activeOnly bool) ([]*
 	return s.accountRepo.GetByUserID(userID)
 }
 
-// UpdateAccount updates an account
-func (s *accountService) UpdateAccount(id uuid.UUID, name string, accountType models.AccountType, isActive bool) (*models.Account, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid account ID")
-	}
-
+// UpdateAccount updates an account owned by the user. An empty name or type,
+// a nil isActive and credit card terms left nil in card are unchanged; an
+// account that stops being a credit card loses its terms.
+func (s *accountService) UpdateAccount(userID, id uuid.UUID, name string, accountType models.AccountType, isActive *bool, card CreditCardSettings) (*models.Account, error) {
 	// Get existing account
-	account, err := s.accountRepo.GetByID(id)
+	account, err := ownedAccount(s.accountRepo, userID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -114,24 +129,27 @@ func (s *accountService) UpdateAccount(id uuid.UUID, name string, accountType mo
 		account.Type = accountType
 	}
 
-	account.IsActive = isActive
+	if isActive != nil {
+		account.IsActive = *isActive
+	}
+
+	if err := applyCreditCardSettings(account, card); err != nil {
+		return nil, err
+	}
 
 	// Update account
 	if err := s.accountRepo.Update(account); err != nil {
 		return nil, err
 	}
 
+	account.UpdateAvailableCredit()
 	return account, nil
 }
 
-// DeleteAccount deletes an account
-func (s *accountService) DeleteAccount(id uuid.UUID) error {
-	if id == uuid.Nil {
-		return errors.New("invalid account ID")
-	}
-
+// DeleteAccount deletes an account owned by the user
+func (s *accountService) DeleteAccount(userID, id uuid.UUID) error {
 	// Check if account exists
-	account, err := s.accountRepo.GetByID(id)
+	account, err := ownedAccount(s.accountRepo, userID, id)
 	if err != nil {
 		return err
 	}
@@ -144,18 +162,151 @@ func (s *accountService) DeleteAccount(id uuid.UUID) error {
 	return s.accountRepo.Delete(id)
 }
 
-// GetAccountBalance retrieves the current balance of an account
-func (s *accountService) GetAccountBalance(id uuid.UUID) (decimal.Decimal, error) {
+// GetAccountBalance retrieves the current balance of an account owned by the user
+func (s *accountService) GetAccountBalance(userID, id uuid.UUID) (decimal.Decimal, error) {
+	account, err := ownedAccount(s.accountRepo, userID, id)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return account.Balance, nil
+}
+
+// ReconcileAccount compares the stored balance of an account with the balance
+// computed from its opening balance and transactions
+func (s *accountService) ReconcileAccount(userID, id uuid.UUID) (*repositories.AccountReconciliation, error) {
+	if _, err := ownedAccount(s.accountRepo, userID, id); err != nil {
+		return nil, err
+	}
+
+	return s.accountRepo.GetReconciliation(id)
+}
+
+// RecomputeBalance fixes balance drift by resetting the stored balance to the computed one
+func (s *accountService) RecomputeBalance(userID, id uuid.UUID) (*BalanceRecomputeResult, error) {
+	account, err := ownedAccount(s.accountRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.accountRepo.RecomputeBalance(id); err != nil {
+		return nil, err
+	}
+
+	reconciliation, err := s.accountRepo.GetReconciliation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceRecomputeResult{
+		PreviousBalance: account.Balance,
+		Reconciliation:  reconciliation,
+	}, nil
+}
+
+// FindBalanceMismatches returns all accounts whose stored balance has drifted
+func (s *accountService) FindBalanceMismatches() ([]*repositories.AccountReconciliation, error) {
+	return s.accountRepo.GetMismatchedReconciliations()
+}
+
+// GetBalanceHistory reconstructs the balance of an account owned by the user
+// at the end of every day, week or month from from to to. To defaults to
+// today and from to 30 days, 12 weeks or 12 months before to.
+func (s *accountService) GetBalanceHistory(userID, id uuid.UUID, from, to *time.Time, interval string) (*BalanceHistory, error) {
+	account, err := ownedAccount(s.accountRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval == "" {
+		interval = "day"
+	}
+	end := dateOnly(time.Now())
+	if to != nil {
+		end = dateOnly(*to)
+	}
+	var start time.Time
+	switch interval {
+	case "day":
+		start = end.AddDate(0, 0, -29)
+	case "week":
+		start = end.AddDate(0, 0, -7*11)
+	case "month":
+		start = end.AddDate(0, -11, 0)
+	default:
+		return nil, errors.New("interval must be day, week or month")
+	}
+	if from != nil {
+		start = dateOnly(*from)
+	}
+	if end.Before(start) {
+		return nil, errors.New("to must not be before from")
+	}
+	if days := end.Sub(start).Hours() / 24; days >= maxBalanceHistoryDays {
+		return nil, fmt.Errorf("balance history cannot span more than %d days", maxBalanceHistoryDays)
+	}
+
+	points, err := s.accountRepo.GetBalanceHistory(account.ID, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, point := range points {
+		point.PeriodStart = dateOnly(point.PeriodStart)
+		point.PeriodEnd = periodEnd(point.PeriodStart, interval)
+		if point.PeriodEnd.After(end) {
+			point.PeriodEnd = end
+		}
+		point.Balance = account.OpeningBalance.Add(point.LedgerTotal)
+	}
+
+	reconciliation, err := s.accountRepo.GetReconciliation(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceHistory{
+		AccountID:     account.ID,
+		Currency:      account.Currency,
+		Interval:      interval,
+		From:          start,
+		To:            end,
+		StoredBalance: account.Balance,
+		Balanced:      reconciliation.Balanced,
+		Points:        points,
+	}, nil
+}
+
+// periodEnd returns the last day of the day, week or month starting at start
+func periodEnd(start time.Time, interval string) time.Time {
+	switch interval {
+	case "week":
+		return start.AddDate(0, 0, 6)
+	case "month":
+		return start.AddDate(0, 1, -1)
+	default:
+		return start
+	}
+}
+
+// ownedAccount loads an account and reports it as not found when it belongs to
+// another user, so callers cannot probe for other users' accounts
+func ownedAccount(accountRepo repositories.AccountRepository, userID, id uuid.UUID) (*models.Account, error) {
 	if id == uuid.Nil {
-		return decimal.Zero, errors.New("invalid account ID")
+		return nil, errors.New("invalid account ID")
+	}
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
 	}
 
-	account, err := s.accountRepo.GetByID(id)
+	account, err := accountRepo.GetByID(id)
 	if err != nil {
-		return decimal.Zero, err
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, repositories.ErrAccountNotFound
 	}
 
-	return account.Balance, nil
+	return account, nil
 }
 
 // validateAccountInput validates account input fields
@@ -182,10 +333,61 @@ func (s *accountService) validateAccountInput(userID uuid.UUID, name string, acc
 	return nil
 }
 
+// applyCreditCardSettings validates credit card terms and sets them on the
+// account. Only credit card accounts can have terms; other accounts have
+// theirs cleared.
+func applyCreditCardSettings(account *models.Account, card CreditCardSettings) error {
+	if account.Type != models.AccountTypeCreditCard {
+		if card.CreditLimit != nil || card.StatementClosingDay != nil || card.PaymentDueDay != nil || card.APR != nil {
+			return errors.New("credit card settings are only allowed on credit card accounts")
+		}
+		account.CreditLimit = nil
+		account.StatementClosingDay = nil
+		account.PaymentDueDay = nil
+		account.APR = nil
+		return nil
+	}
+
+	if card.CreditLimit != nil {
+		limit := roundAmount(*card.CreditLimit, account.Currency)
+		if !limit.IsPositive() {
+			return errors.New("credit limit must be positive")
+		}
+		account.CreditLimit = &limit
+	}
+	if card.StatementClosingDay != nil {
+		day := *card.StatementClosingDay
+		if day < 1 || day > 31 {
+			return errors.New("statement closing day must be between 1 and 31")
+		}
+		account.StatementClosingDay = &day
+	}
+	if card.PaymentDueDay != nil {
+		day := *card.PaymentDueDay
+		if day < 1 || day > 31 {
+			return errors.New("payment due day must be between 1 and 31")
+		}
+		account.PaymentDueDay = &day
+	}
+	if (account.StatementClosingDay == nil) != (account.PaymentDueDay == nil) {
+		return errors.New("statement closing day and payment due day must be set together")
+	}
+	if card.APR != nil {
+		apr := card.APR.Round(4)
+		if apr.IsNegative() || apr.GreaterThan(decimal.NewFromInt(100)) {
+			return errors.New("apr must be between 0 and 100")
+		}
+		account.APR = &apr
+	}
+
+	return nil
+}
+
 // isValidAccountType checks if the account type is valid
 func (s *accountService) isValidAccountType(accountType models.AccountType) bool {
 	switch accountType {
-	case models.AccountTypeBank, models.AccountTypeCash, models.AccountTypeCreditCard:
+	case models.AccountTypeBank, models.AccountTypeCash, models.AccountTypeSavings,
+		models.AccountTypeInvestment, models.AccountTypeCreditCard, models.AccountTypeLoan:
 		return true
 	default:
 		return false
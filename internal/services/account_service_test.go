@@ -0,0 +1,40 @@
+package services
+
+import "testing"
+
+// TestUpdateAccountKeepsOmittedFields updates an account one field at a time.
+// Fields left out of an update, is_active in particular, must keep their values.
+func TestUpdateAccountKeepsOmittedFields(t *testing.T) {
+	store := newMemoryStore()
+	repos := store.repositories()
+	user, account, _ := store.addUser("USD")
+	accounts := NewAccountService(repos.Accounts, repos.Users)
+	inactive, active := false, true
+
+	steps := []struct {
+		name       string
+		rename     string
+		isActive   *bool
+		wantName   string
+		wantActive bool
+	}{
+		{"rename only", "Everyday", nil, "Everyday", true},
+		{"deactivate", "", &inactive, "Everyday", false},
+		{"rename while inactive", "Old checking", nil, "Old checking", false},
+		{"reactivate", "", &active, "Old checking", true},
+	}
+	for _, step := range steps {
+		updated, err := accounts.UpdateAccount(user.ID, account.ID, step.rename, "", step.isActive, CreditCardSettings{})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if stored := store.account(account.ID); stored.Name != step.wantName || stored.IsActive != step.wantActive {
+			t.Fatalf("%s: stored name %q, active %v; want %q, %v",
+				step.name, stored.Name, stored.IsActive, step.wantName, step.wantActive)
+		}
+		if updated.Name != step.wantName || updated.IsActive != step.wantActive {
+			t.Errorf("%s: returned name %q, active %v; want %q, %v",
+				step.name, updated.Name, updated.IsActive, step.wantName, step.wantActive)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	tokenIssuer = "expense-tracker-api"
+
+	minPasswordLength = 8
+	// bcrypt ignores everything past 72 bytes, so longer passwords are rejected
+	maxPasswordLength = 72
+)
+
+var (
+	// ErrInvalidCredentials is returned when the email or password does not match
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrAccountLocked is returned when too many failed logins locked the user out
+	ErrAccountLocked = errors.New("account is temporarily locked due to too many failed login attempts")
+)
+
+// dummyPasswordHash is compared against when the email is unknown so that
+// login takes the same time whether or not the user exists
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+type authService struct {
+	userRepo         repositories.UserRepository
+	secret           []byte
+	expiration       time.Duration
+	maxLoginAttempts int
+	lockoutDuration  time.Duration
+}
+
+// NewAuthService creates a new auth service that signs tokens with the given secret
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	secret string,
+	expiration time.Duration,
+	maxLoginAttempts int,
+	lockoutDuration time.Duration,
+) AuthService {
+	return &authService{
+		userRepo:         userRepo,
+		secret:           []byte(secret),
+		expiration:       expiration,
+		maxLoginAttempts: maxLoginAttempts,
+		lockoutDuration:  lockoutDuration,
+	}
+}
+
+// Login verifies the user's credentials, locking the user out after repeated failures
+func (s *authService) Login(email, password string) (*models.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, ErrAccountLocked
+	}
+
+	if err := checkPassword(user.PasswordHash, password); err != nil {
+		lockedUntil, err := s.userRepo.RecordFailedLogin(user.ID, s.maxLoginAttempts, now.Add(s.lockoutDuration))
+		if err != nil {
+			return nil, err
+		}
+		if lockedUntil != nil && now.Before(*lockedUntil) {
+			return nil, ErrAccountLocked
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// Reset lockout state after a successful login
+	if user.FailedLoginAttempts != 0 || user.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(user.ID); err != nil {
+			return nil, err
+		}
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
+	}
+
+	return user, nil
+}
+
+// ChangePassword replaces the user's password after verifying the current one
+func (s *authService) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
+	if userID == uuid.Nil {
+		return errors.New("invalid user ID")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPassword(user.PasswordHash, currentPassword); err != nil {
+		return errors.New("current password is incorrect")
+	}
+
+	if currentPassword == newPassword {
+		return errors.New("new password must be different from the current password")
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hash
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+
+	return s.userRepo.Update(user)
+}
+
+// GenerateToken issues a signed JWT for the given user
+func (s *authService) GenerateToken(userID uuid.UUID) (string, time.Time, error) {
+	if userID == uuid.Nil {
+		return "", time.Time{}, errors.New("invalid user ID")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.expiration)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		Issuer:    tokenIssuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// ValidateToken verifies a signed JWT and returns the user ID it was issued for
+func (s *authService) ValidateToken(tokenString string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid or expired token")
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil || userID == uuid.Nil {
+		return uuid.Nil, errors.New("invalid token subject")
+	}
+
+	return userID, nil
+}
+
+// validatePassword checks the password length constraints
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return errors.New("password must be at least 8 characters long")
+	}
+	if len(password) > maxPasswordLength {
+		return errors.New("password must be at most 72 bytes long")
+	}
+	return nil
+}
+
+// hashPassword validates and hashes a plaintext password with bcrypt
+func hashPassword(password string) (string, error) {
+	if err := validatePassword(password); err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// checkPassword compares a plaintext password against a bcrypt hash
+func checkPassword(hash, password string) error {
+	if hash == "" {
+		return ErrInvalidCredentials
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
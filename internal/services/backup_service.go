@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/backup"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+type backupService struct {
+	uow           repositories.UnitOfWork
+	backupRepo    repositories.BackupRepository
+	schemaVersion int64
+	signingKey    []byte
+}
+
+// NewBackupService creates a new backup service. schemaVersion is the current
+// database schema version, recorded in backups and checked on restore.
+// Archives are signed with signingKey, and only archives signed with it can be
+// restored.
+func NewBackupService(uow repositories.UnitOfWork, backupRepo repositories.BackupRepository, schemaVersion int64, signingKey string) BackupService {
+	return &backupService{
+		uow:           uow,
+		backupRepo:    backupRepo,
+		schemaVersion: schemaVersion,
+		signingKey:    []byte(signingKey),
+	}
+}
+
+// Backup writes an archive of the user and everything they own
+func (s *backupService) Backup(userID uuid.UUID, w io.Writer) error {
+	if userID == uuid.Nil {
+		return errors.New("invalid user ID")
+	}
+
+	data, err := s.backupRepo.LoadUserData(userID)
+	if err != nil {
+		return err
+	}
+	return backup.Write(w, backup.FromUserData(data), s.schemaVersion, s.signingKey)
+}
+
+// Restore re-creates the user in an archive with all of their data inside a
+// single database transaction. Archives do not carry the password, so the
+// restored user logs in with password. With preserveIDs the records keep the
+// IDs from the archive; otherwise every record gets a new ID. Account
+// balances are recomputed from the restored transactions.
+func (s *backupService) Restore(r io.Reader, password string, preserveIDs bool) (*models.User, error) {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := backup.Read(r, s.schemaVersion, s.signingKey)
+	if err != nil {
+		return nil, err
+	}
+	data.User.Email = strings.ToLower(strings.TrimSpace(data.User.Email))
+
+	var restored *models.User
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		if existing, err := repos.Users.GetByEmail(data.User.Email); err == nil && existing != nil {
+			return errors.New("user with this email already exists")
+		} else if err != nil && !errors.Is(err, repositories.ErrUserNotFound) {
+			return err
+		}
+		if preserveIDs {
+			exists, err := repos.Users.Exists(data.User.ID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return errors.New("user with this ID already exists")
+			}
+		}
+
+		ids := make(map[uuid.UUID]uuid.UUID)
+		data.MapIDs(func(id uuid.UUID) uuid.UUID {
+			if mapped, ok := ids[id]; ok {
+				return mapped
+			}
+			mapped := id
+			if !preserveIDs {
+				mapped = uuid.New()
+			}
+			ids[id] = mapped
+			return mapped
+		})
+
+		target := data.UserData()
+		target.User.PasswordHash = passwordHash
+		if err := repos.Backups.InsertUserData(target); err != nil {
+			return err
+		}
+		for _, account := range target.Accounts {
+			if err := repos.Accounts.RecomputeBalance(account.ID); err != nil {
+				return err
+			}
+		}
+
+		restored = &target.User
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
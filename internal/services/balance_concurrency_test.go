@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/database"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the disposable Postgres database named by
+// TEST_DATABASE_URL and migrates it. Tests using it are skipped when the
+// variable is not set. Records are not cleaned up afterwards.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(20)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// TestConcurrentBalanceUpdates creates, updates and deletes transactions of
+// one account in parallel, some of them racing on the same transaction, while
+// the account is renamed. No balance change may be lost.
+func TestConcurrentBalanceUpdates(t *testing.T) {
+	db := openTestDB(t)
+	uow := repositories.NewUnitOfWork(db)
+	repos := repositories.NewRepositories(db)
+	users := NewUserService(uow, repos.Users)
+	accounts := NewAccountService(repos.Accounts, repos.Users)
+	transactions := NewTransactionService(uow, repos.Transactions, repos.Accounts, repos.Categories, repos.Users)
+
+	user, err := users.CreateUser(uuid.NewString()+"@example.com", "Concurrency", "USD", "correct-horse-battery")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	categories, err := repos.Categories.GetByUserID(user.ID)
+	if err != nil || len(categories) == 0 {
+		t.Fatalf("load categories: %v", err)
+	}
+	account, err := accounts.CreateAccount(user.ID, "Checking", models.AccountTypeBank, "USD",
+		decimal.NewFromInt(1000), CreditCardSettings{})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	create := func(amount int64) (*models.Transaction, error) {
+		return transactions.CreateTransaction(TransactionCreateRequest{
+			UserID: user.ID, AccountID: account.ID, CategoryID: categories[0].ID,
+			Amount: decimal.NewFromInt(amount), Description: "Concurrent", Date: time.Now(),
+		})
+	}
+
+	const seeds = 50
+	seeded := make([]*models.Transaction, seeds)
+	for i := range seeded {
+		if seeded[i], err = create(-int64(i + 1)); err != nil {
+			t.Fatalf("seed transaction: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 1000)
+	run := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Losing a race against a delete is expected
+			if err := fn(); err != nil && !errors.Is(err, repositories.ErrTransactionNotFound) {
+				errs <- err
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		amount := int64(i%17 + 1)
+		if i%2 == 0 {
+			amount = -amount
+		}
+		run(func() error { _, err := create(amount); return err })
+	}
+	for i, transaction := range seeded {
+		id := transaction.ID
+		for _, amount := range []int64{int64(i + 100), -int64(i + 200)} {
+			amount := decimal.NewFromInt(amount)
+			run(func() error {
+				_, err := transactions.UpdateTransaction(user.ID, id, TransactionUpdateRequest{Amount: &amount})
+				return err
+			})
+		}
+		if i%2 == 0 {
+			run(func() error { return transactions.DeleteTransaction(user.ID, id) })
+		}
+	}
+	for i := 0; i < 20; i++ {
+		run(func() error {
+			_, err := accounts.UpdateAccount(user.ID, account.ID, "Checking", "", nil, CreditCardSettings{})
+			return err
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent operation failed: %v", err)
+	}
+
+	reconciliation, err := accounts.ReconcileAccount(user.ID, account.ID)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if !reconciliation.Balanced {
+		t.Fatalf("balance = %s, want opening balance %s + transactions %s = %s",
+			reconciliation.StoredBalance, reconciliation.OpeningBalance,
+			reconciliation.TransactionTotal, reconciliation.ComputedBalance)
+	}
+}
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+const (
+	defaultBudgetStatusPeriods = 3
+	maxBudgetStatusPeriods     = 36
+)
+
+type budgetService struct {
+	budgetRepo      repositories.BudgetRepository
+	categoryRepo    repositories.CategoryRepository
+	transactionRepo repositories.TransactionRepository
+	userRepo        repositories.UserRepository
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(
+	budgetRepo repositories.BudgetRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactionRepo repositories.TransactionRepository,
+	userRepo repositories.UserRepository,
+) BudgetService {
+	return &budgetService{
+		budgetRepo:      budgetRepo,
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// CreateBudget creates a new budget over one or more expense categories
+func (s *budgetService) CreateBudget(req BudgetCreateRequest) (*models.Budget, error) {
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if !s.isValidPeriod(req.Period) {
+		return nil, errors.New("invalid budget period (expected weekly, monthly or yearly)")
+	}
+
+	// Budgets are in the user's currency
+	user, err := s.userRepo.GetByID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	amount := roundAmount(req.Amount, user.Currency)
+	if !amount.IsPositive() {
+		return nil, errors.New("budget amount must be positive")
+	}
+
+	categories, err := s.loadCategories(req.UserID, req.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Budgets start with the current period unless told otherwise
+	startDate := time.Now().UTC()
+	if req.StartDate != nil {
+		startDate = *req.StartDate
+	}
+
+	budget := &models.Budget{
+		UserID:     req.UserID,
+		Name:       budgetName(req.Name, categories),
+		Amount:     amount,
+		Period:     req.Period,
+		Rollover:   req.Rollover,
+		StartDate:  req.Period.PeriodStart(startDate),
+		Categories: categories,
+	}
+
+	if err := s.budgetRepo.Create(budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// GetBudget retrieves a budget by ID if it belongs to the user
+func (s *budgetService) GetBudget(userID, id uuid.UUID) (*models.Budget, error) {
+	return s.getOwnedBudget(userID, id)
+}
+
+// GetUserBudgets retrieves all budgets for a user
+func (s *budgetService) GetUserBudgets(userID uuid.UUID) ([]*models.Budget, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.budgetRepo.GetByUserID(userID)
+}
+
+// UpdateBudget updates a budget owned by the user
+func (s *budgetService) UpdateBudget(userID, id uuid.UUID, req BudgetUpdateRequest) (*models.Budget, error) {
+	budget, err := s.getOwnedBudget(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CategoryIDs != nil {
+		categories, err := s.loadCategories(userID, *req.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		budget.Categories = categories
+	}
+
+	if req.Name != nil {
+		budget.Name = budgetName(*req.Name, budget.Categories)
+	}
+
+	if req.Amount != nil {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		amount := roundAmount(*req.Amount, user.Currency)
+		if !amount.IsPositive() {
+			return nil, errors.New("budget amount must be positive")
+		}
+		budget.Amount = amount
+	}
+
+	if req.Period != nil {
+		if !s.isValidPeriod(*req.Period) {
+			return nil, errors.New("invalid budget period (expected weekly, monthly or yearly)")
+		}
+		budget.Period = *req.Period
+	}
+
+	if req.Rollover != nil {
+		budget.Rollover = *req.Rollover
+	}
+
+	if req.StartDate != nil {
+		budget.StartDate = *req.StartDate
+	}
+
+	// Keep the start date aligned with the (possibly new) period
+	budget.StartDate = budget.Period.PeriodStart(budget.StartDate)
+
+	if err := s.budgetRepo.Update(budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// DeleteBudget deletes a budget owned by the user
+func (s *budgetService) DeleteBudget(userID, id uuid.UUID) error {
+	if _, err := s.getOwnedBudget(userID, id); err != nil {
+		return err
+	}
+
+	return s.budgetRepo.Delete(id)
+}
+
+// GetBudgetStatus reports spending against each of the user's budgets for the
+// current period and up to periods-1 periods before it. Rollover budgets carry
+// unspent amounts forward from their start date, so earlier periods are
+// evaluated even when they are not returned. Spending is loaded with one
+// query per period length, however many periods are evaluated.
+func (s *budgetService) GetBudgetStatus(userID uuid.UUID, periods int) ([]*BudgetStatus, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if periods == 0 {
+		periods = defaultBudgetStatusPeriods
+	}
+	if periods < 1 || periods > maxBudgetStatusPeriods {
+		return nil, errors.New("periods must be between 1 and 36")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := s.budgetRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	// Work out the periods each budget is evaluated over, and the range of
+	// periods to load spending for by period length
+	type window struct{ from, oldest, current time.Time }
+	windows := make([]window, len(budgets))
+	ranges := make(map[models.BudgetPeriod]*window)
+	for i, budget := range budgets {
+		current := budget.Period.PeriodStart(now)
+		first := budget.Period.PeriodStart(budget.StartDate.UTC())
+		oldest := budget.Period.AddPeriods(current, -(periods - 1))
+		if oldest.Before(first) {
+			oldest = first
+		}
+
+		from := oldest
+		if budget.Rollover {
+			from = first
+		}
+		windows[i] = window{from: from, oldest: oldest, current: current}
+
+		if r, ok := ranges[budget.Period]; !ok {
+			ranges[budget.Period] = &window{from: from, current: current}
+		} else if from.Before(r.from) {
+			r.from = from
+		}
+	}
+
+	spending := make(map[models.BudgetPeriod]periodSpending, len(ranges))
+	for period, r := range ranges {
+		// Transaction dates are inclusive on both ends of the summary range
+		end := period.AddPeriods(r.current, 1).Add(-time.Nanosecond)
+		spending[period], err = s.loadPeriodSpending(userID, user.Currency, period, r.from, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]*BudgetStatus, 0, len(budgets))
+	for i, budget := range budgets {
+		w := windows[i]
+
+		status := &BudgetStatus{Budget: budget, Periods: []BudgetPeriodStatus{}}
+		carry := decimal.Zero
+		for start := w.from; !start.After(w.current); start = budget.Period.AddPeriods(start, 1) {
+			end := budget.Period.AddPeriods(start, 1).Add(-time.Nanosecond)
+			spent := spending[budget.Period].total(budget.Categories, start)
+
+			periodStatus := newBudgetPeriodStatus(start, end, budget.Amount, carry, spent)
+			if budget.Rollover {
+				carry = decimal.Max(periodStatus.Remaining, decimal.Zero)
+			}
+
+			if !start.Before(w.oldest) {
+				status.Periods = append(status.Periods, periodStatus)
+			}
+		}
+
+		// Most recent period first
+		for i, j := 0, len(status.Periods)-1; i < j; i, j = i+1, j-1 {
+			status.Periods[i], status.Periods[j] = status.Periods[j], status.Periods[i]
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// newBudgetPeriodStatus computes the figures for a single budget period
+func newBudgetPeriodStatus(start, end time.Time, amount, rollover, spent decimal.Decimal) BudgetPeriodStatus {
+	budgeted := amount.Add(rollover)
+	remaining := budgeted.Sub(spent)
+
+	percentUsed := decimal.Zero
+	if budgeted.IsPositive() {
+		percentUsed = spent.Div(budgeted).Mul(decimal.NewFromInt(100)).Round(2)
+	}
+
+	return BudgetPeriodStatus{
+		StartDate:   start,
+		EndDate:     end,
+		Budgeted:    budgeted,
+		Rollover:    rollover,
+		Spent:       spent,
+		Remaining:   remaining,
+		PercentUsed: percentUsed,
+		Overspent:   remaining.IsNegative(),
+	}
+}
+
+// budgetPeriodUnits maps budget periods to the units spending is grouped by
+var budgetPeriodUnits = map[models.BudgetPeriod]string{
+	models.BudgetPeriodWeekly:  "week",
+	models.BudgetPeriodMonthly: "month",
+	models.BudgetPeriodYearly:  "year",
+}
+
+// periodSpending holds per-category spending in the user's currency, keyed
+// by the Unix time of the start of the period it falls in
+type periodSpending map[int64]map[uuid.UUID]*repositories.TransactionSummary
+
+// loadPeriodSpending loads the spending of every period of the given length
+// from start to end in a single query
+func (s *budgetService) loadPeriodSpending(userID uuid.UUID, currency string, period models.BudgetPeriod, start, end time.Time) (periodSpending, error) {
+	// Spending without an exchange rate would be left out of the totals
+	if _, err := reportConversions(s.transactionRepo, userID, currency, &start, &end); err != nil {
+		return nil, err
+	}
+	summaries, err := s.transactionRepo.GetSummaryByCategoryAndPeriod(userID, currency, budgetPeriodUnits[period], start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	spending := make(periodSpending)
+	for _, summary := range summaries {
+		key := summary.PeriodStart.Unix()
+		if spending[key] == nil {
+			spending[key] = make(map[uuid.UUID]*repositories.TransactionSummary)
+		}
+		spending[key][summary.CategoryID] = &summary.TransactionSummary
+	}
+	return spending, nil
+}
+
+// total returns how much was spent across the given categories and their
+// subcategories in the period starting at start. Summary totals already
+// include subcategories, so a category is skipped when one of its ancestors
+// is also in the budget.
+func (p periodSpending) total(categories []models.Category, start time.Time) decimal.Decimal {
+	byCategory := p[start.Unix()]
+
+	included := make(map[uuid.UUID]bool, len(categories))
+	for _, category := range categories {
+		included[category.ID] = true
+	}
+
+	spent := decimal.Zero
+	for _, category := range categories {
+		summary, ok := byCategory[category.ID]
+		if !ok {
+			continue
+		}
+		// Every ancestor of a category with spending has a summary as well
+		nested := false
+		for parent := summary.ParentID; parent != nil && !nested; {
+			nested = included[*parent]
+			if ancestor, ok := byCategory[*parent]; ok {
+				parent = ancestor.ParentID
+			} else {
+				parent = nil
+			}
+		}
+		if !nested {
+			spent = spent.Add(summary.TotalAmount)
+		}
+	}
+	return spent
+}
+
+// loadCategories verifies that every category belongs to the user and is an
+// expense category
+func (s *budgetService) loadCategories(userID uuid.UUID, ids []uuid.UUID) ([]models.Category, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("a budget needs at least one category")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	categories := make([]models.Category, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		category, err := s.categoryRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if category.UserID != userID {
+			return nil, repositories.ErrCategoryNotFound
+		}
+		if category.Type != models.CategoryTypeExpense {
+			return nil, errors.New("budgets can only cover expense categories")
+		}
+		categories = append(categories, *category)
+	}
+
+	return categories, nil
+}
+
+// getOwnedBudget retrieves a budget, treating budgets owned by other users as missing
+func (s *budgetService) getOwnedBudget(userID, id uuid.UUID) (*models.Budget, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid budget ID")
+	}
+
+	budget, err := s.budgetRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if budget.UserID != userID {
+		return nil, repositories.ErrBudgetNotFound
+	}
+
+	return budget, nil
+}
+
+// isValidPeriod checks if the budget period is valid
+func (s *budgetService) isValidPeriod(period models.BudgetPeriod) bool {
+	switch period {
+	case models.BudgetPeriodWeekly, models.BudgetPeriodMonthly, models.BudgetPeriodYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+// budgetName returns the trimmed name, falling back to the category names
+func budgetName(name string, categories []models.Category) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	return strings.Join(names, ", ")
+}
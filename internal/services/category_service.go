@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
@@ -9,19 +10,29 @@ import (
 	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 )
 
+// ErrCategoryInUse is returned when deleting a category that records still refer to
+var ErrCategoryInUse = errors.New("category is still in use")
+
 type categoryService struct {
+	uow          repositories.UnitOfWork
 	categoryRepo repositories.CategoryRepository
 }
 
 // NewCategoryService creates a new category service
-func NewCategoryService(categoryRepo repositories.CategoryRepository) CategoryService {
+func NewCategoryService(uow repositories.UnitOfWork, categoryRepo repositories.CategoryRepository) CategoryService {
 	return &categoryService{
+		uow:          uow,
 		categoryRepo: categoryRepo,
 	}
 }
 
-// CreateCategory creates a new category
-func (s *categoryService) CreateCategory(name string, categoryType models.CategoryType, color string) (*models.Category, error) {
+// CreateCategory creates a new category for the user, optionally below a
+// parent category of the same type
+func (s *categoryService) CreateCategory(userID uuid.UUID, name string, categoryType models.CategoryType, color string, parentID *uuid.UUID) (*models.Category, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
 	// Validate inputs
 	if err := s.validateCategoryInput(name, categoryType, color); err != nil {
 		return nil, err
@@ -34,9 +45,17 @@ func (s *categoryService) CreateCategory(name string, categoryType models.Catego
 
 	// Create category
 	category := &models.Category{
-		Name:  strings.TrimSpace(name),
-		Type:  categoryType,
-		Color: color,
+		UserID: userID,
+		Name:   strings.TrimSpace(name),
+		Type:   categoryType,
+		Color:  color,
+	}
+
+	if parentID != nil {
+		if err := s.checkParent(category, *parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
 	}
 
 	if err := s.categoryRepo.Create(category); err != nil {
@@ -46,55 +65,64 @@ func (s *categoryService) CreateCategory(name string, categoryType models.Catego
 	return category, nil
 }
 
-// GetCategoryByID retrieves a category by ID
-func (s *categoryService) GetCategoryByID(id uuid.UUID) (*models.Category, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid category ID")
-	}
-
-	return s.categoryRepo.GetByID(id)
+// GetCategoryByID retrieves a category owned by the user
+func (s *categoryService) GetCategoryByID(userID, id uuid.UUID) (*models.Category, error) {
+	return s.getOwnedCategory(userID, id)
 }
 
-// GetAllCategories retrieves all categories
-func (s *categoryService) GetAllCategories() ([]*models.Category, error) {
-	return s.categoryRepo.GetAll()
+// GetAllCategories retrieves all of the user's categories
+func (s *categoryService) GetAllCategories(userID uuid.UUID) ([]*models.Category, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.categoryRepo.GetByUserID(userID)
 }
 
-// GetCategoriesByType retrieves categories by type
-func (s *categoryService) GetCategoriesByType(categoryType models.CategoryType) ([]*models.Category, error) {
+// GetCategoriesByType retrieves the user's categories of a type
+func (s *categoryService) GetCategoriesByType(userID uuid.UUID, categoryType models.CategoryType) ([]*models.Category, error) {
 	if !s.isValidCategoryType(categoryType) {
 		return nil, errors.New("invalid category type")
 	}
 
-	return s.categoryRepo.GetByType(categoryType)
+	return s.categoryRepo.GetByType(userID, categoryType)
 }
 
-// UpdateCategory updates a category
-func (s *categoryService) UpdateCategory(id uuid.UUID, name, color string) (*models.Category, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid category ID")
-	}
-
+// UpdateCategory updates a category owned by the user. Moving a category
+// moves its subcategories with it.
+func (s *categoryService) UpdateCategory(userID, id uuid.UUID, req CategoryUpdateRequest) (*models.Category, error) {
 	// Get existing category
-	category, err := s.categoryRepo.GetByID(id)
+	category, err := s.getOwnedCategory(userID, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Validate and update fields
-	if name != "" {
-		name = strings.TrimSpace(name)
+	if req.Name != "" {
+		name := strings.TrimSpace(req.Name)
 		if len(name) < 2 {
 			return nil, errors.New("category name must be at least 2 characters long")
 		}
 		category.Name = name
 	}
 
-	if color != "" {
-		if !s.isValidColor(color) {
+	if req.Color != "" {
+		if !isValidColor(req.Color) {
 			return nil, errors.New("invalid color format (expected hex color like #007bff)")
 		}
-		category.Color = color
+		category.Color = req.Color
+	}
+
+	switch {
+	case req.RemoveParent && req.ParentID != nil:
+		return nil, errors.New("parent ID cannot be set when removing the parent")
+	case req.RemoveParent:
+		category.ParentID = nil
+	case req.ParentID != nil:
+		if err := s.checkParent(category, *req.ParentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = req.ParentID
 	}
 
 	// Update category
@@ -105,25 +133,123 @@ func (s *categoryService) UpdateCategory(id uuid.UUID, name, color string) (*mod
 	return category, nil
 }
 
-// DeleteCategory deletes a category
-func (s *categoryService) DeleteCategory(id uuid.UUID) error {
+// DeleteCategory deletes a category owned by the user. A category that is
+// still referenced by transactions, budgets, recurring transactions, import
+// profiles or subcategories is only deleted when reassignTo names a category
+// to move those records to, as in MergeCategory.
+func (s *categoryService) DeleteCategory(userID, id uuid.UUID, reassignTo *uuid.UUID) (*CategoryMergeResult, error) {
+	if reassignTo != nil {
+		return s.MergeCategory(userID, id, *reassignTo)
+	}
+
+	if _, err := s.getOwnedCategory(userID, id); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		usage, err := repos.Categories.GetUsage(id)
+		if err != nil {
+			return err
+		}
+		if usage.Total() > 0 {
+			return fmt.Errorf("%w by %s; pass reassign_to to move them to another category", ErrCategoryInUse, describeUsage(usage))
+		}
+		return repos.Categories.Delete(id)
+	})
+	return nil, err
+}
+
+// MergeCategory moves every record that refers to a category, including its
+// subcategories, to the target category and deletes it, all in one database
+// transaction. Both categories must belong to the user and have the same type.
+func (s *categoryService) MergeCategory(userID, id, targetID uuid.UUID) (*CategoryMergeResult, error) {
+	source, err := s.getOwnedCategory(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if targetID == id {
+		return nil, errors.New("a category cannot be merged into itself")
+	}
+	target, err := s.getOwnedCategory(userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Type != source.Type {
+		return nil, errors.New("target category must have the same type")
+	}
+
+	// The target takes over the subcategories, so it cannot be one of them
+	descendants, err := s.categoryRepo.GetDescendantIDs(source.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, descendantID := range descendants {
+		if descendantID == target.ID {
+			return nil, errors.New("a category cannot be merged into one of its subcategories")
+		}
+	}
+
+	result := &CategoryMergeResult{SourceID: source.ID, TargetID: target.ID}
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		moved, err := repos.Categories.Reassign(source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+		result.Moved = *moved
+		return repos.Categories.Delete(source.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getOwnedCategory retrieves a category, treating categories owned by other
+// users as missing
+func (s *categoryService) getOwnedCategory(userID, id uuid.UUID) (*models.Category, error) {
 	if id == uuid.Nil {
-		return errors.New("invalid category ID")
+		return nil, errors.New("invalid category ID")
 	}
 
-	// Check if category exists
-	exists, err := s.categoryRepo.Exists(id)
+	category, err := s.categoryRepo.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if category.UserID != userID {
+		return nil, repositories.ErrCategoryNotFound
 	}
-	if !exists {
-		return errors.New("category not found")
+	return category, nil
+}
+
+// checkParent verifies that parentID can become the parent of category: it
+// must belong to the same user, have the same type, and not be the category
+// itself or one of its subcategories
+func (s *categoryService) checkParent(category *models.Category, parentID uuid.UUID) error {
+	if parentID == category.ID {
+		return errors.New("a category cannot be its own parent")
 	}
 
-	// TODO: Check if category is being used by any transactions
-	// This would require a transaction repository dependency
+	parent, err := s.getOwnedCategory(category.UserID, parentID)
+	if err != nil {
+		return err
+	}
+	if parent.Type != category.Type {
+		return errors.New("parent category must have the same type")
+	}
 
-	return s.categoryRepo.Delete(id)
+	if category.ID == uuid.Nil {
+		return nil
+	}
+	descendants, err := s.categoryRepo.GetDescendantIDs(category.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendants {
+		if id == parentID {
+			return errors.New("a category cannot be moved below one of its subcategories")
+		}
+	}
+	return nil
 }
 
 // validateCategoryInput validates category input fields
@@ -143,7 +269,7 @@ func (s *categoryService) validateCategoryInput(name string, categoryType models
 	}
 
 	// Validate color if provided
-	if color != "" && !s.isValidColor(color) {
+	if color != "" && !isValidColor(color) {
 		return errors.New("invalid color format (expected hex color like #007bff)")
 	}
 
@@ -161,7 +287,7 @@ func (s *categoryService) isValidCategoryType(categoryType models.CategoryType)
 }
 
 // isValidColor checks if the color is a valid hex color
-func (s *categoryService) isValidColor(color string) bool {
+func isValidColor(color string) bool {
 	if len(color) != 7 {
 		return false
 	}
@@ -179,3 +305,85 @@ func (s *categoryService) isValidColor(color string) bool {
 
 	return true
 }
+
+// describeUsage lists the non-zero counts of a category usage
+func describeUsage(usage *repositories.CategoryUsage) string {
+	counts := []struct {
+		n              int64
+		singular, many string
+	}{
+		{usage.Transactions, "transaction", "transactions"},
+		{usage.TransactionSplits, "transaction split", "transaction splits"},
+		{usage.Budgets, "budget", "budgets"},
+		{usage.RecurringTransactions, "recurring transaction", "recurring transactions"},
+		{usage.RecurringExceptions, "recurring exception", "recurring exceptions"},
+		{usage.ImportProfiles, "import profile", "import profiles"},
+		{usage.Subcategories, "subcategory", "subcategories"},
+	}
+
+	var parts []string
+	for _, c := range counts {
+		switch {
+		case c.n == 1:
+			parts = append(parts, "1 "+c.singular)
+		case c.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.many))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// defaultCategory is a category created for every new user
+type defaultCategory struct {
+	Name     string
+	Type     models.CategoryType
+	Color    string
+	Children []defaultCategory
+}
+
+// defaultCategories is the category tree seeded on registration
+var defaultCategories = []defaultCategory{
+	{Name: "Salary", Type: models.CategoryTypeIncome, Color: "#28a745"},
+	{Name: "Interest", Type: models.CategoryTypeIncome, Color: "#20c997"},
+	{Name: "Other Income", Type: models.CategoryTypeIncome, Color: "#17a2b8"},
+	{Name: "Food", Type: models.CategoryTypeExpense, Color: "#fd7e14", Children: []defaultCategory{
+		{Name: "Groceries", Type: models.CategoryTypeExpense, Color: "#fd7e14"},
+		{Name: "Restaurants", Type: models.CategoryTypeExpense, Color: "#fd7e14", Children: []defaultCategory{
+			{Name: "Coffee", Type: models.CategoryTypeExpense, Color: "#fd7e14"},
+		}},
+	}},
+	{Name: "Housing", Type: models.CategoryTypeExpense, Color: "#6f42c1", Children: []defaultCategory{
+		{Name: "Rent", Type: models.CategoryTypeExpense, Color: "#6f42c1"},
+		{Name: "Utilities", Type: models.CategoryTypeExpense, Color: "#6f42c1"},
+	}},
+	{Name: "Transportation", Type: models.CategoryTypeExpense, Color: "#007bff", Children: []defaultCategory{
+		{Name: "Fuel", Type: models.CategoryTypeExpense, Color: "#007bff"},
+		{Name: "Public Transit", Type: models.CategoryTypeExpense, Color: "#007bff"},
+	}},
+	{Name: "Health", Type: models.CategoryTypeExpense, Color: "#dc3545"},
+	{Name: "Shopping", Type: models.CategoryTypeExpense, Color: "#e83e8c"},
+	{Name: "Entertainment", Type: models.CategoryTypeExpense, Color: "#ffc107"},
+}
+
+// newDefaultCategories builds the default category tree for a user, with
+// every parent ahead of its subcategories
+func newDefaultCategories(userID uuid.UUID) []*models.Category {
+	var categories []*models.Category
+	var add func(defaults []defaultCategory, parentID *uuid.UUID)
+	add = func(defaults []defaultCategory, parentID *uuid.UUID) {
+		for _, d := range defaults {
+			category := &models.Category{
+				ID:       uuid.New(),
+				UserID:   userID,
+				ParentID: parentID,
+				Name:     d.Name,
+				Type:     d.Type,
+				Color:    d.Color,
+			}
+			categories = append(categories, category)
+			add(d.Children, &category.ID)
+		}
+	}
+	add(defaultCategories, nil)
+	return categories
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// Minimum payments are the larger of minimumPaymentRate of the statement
+// balance and the floor of the card's currency, but never more than the balance
+var minimumPaymentRate = decimal.RequireFromString("0.02")
+
+// minimumPaymentFloors holds the minimum payment floor by currency, each a
+// round amount close to 25 US dollars. Cards in other currencies have no floor.
+var minimumPaymentFloors = map[string]decimal.Decimal{
+	"AUD": decimal.NewFromInt(40),
+	"BHD": decimal.NewFromInt(10),
+	"BRL": decimal.NewFromInt(130),
+	"CAD": decimal.NewFromInt(35),
+	"CHF": decimal.NewFromInt(20),
+	"CNY": decimal.NewFromInt(180),
+	"CZK": decimal.NewFromInt(550),
+	"DKK": decimal.NewFromInt(170),
+	"EUR": decimal.NewFromInt(25),
+	"GBP": decimal.NewFromInt(20),
+	"HKD": decimal.NewFromInt(200),
+	"INR": decimal.NewFromInt(2000),
+	"JOD": decimal.NewFromInt(18),
+	"JPY": decimal.NewFromInt(3500),
+	"KRW": decimal.NewFromInt(35000),
+	"KWD": decimal.NewFromInt(8),
+	"MXN": decimal.NewFromInt(450),
+	"NOK": decimal.NewFromInt(250),
+	"NZD": decimal.NewFromInt(40),
+	"OMR": decimal.NewFromInt(10),
+	"PLN": decimal.NewFromInt(100),
+	"SEK": decimal.NewFromInt(250),
+	"SGD": decimal.NewFromInt(35),
+	"USD": decimal.NewFromInt(25),
+	"ZAR": decimal.NewFromInt(450),
+}
+
+type creditCardService struct {
+	accountRepo   repositories.AccountRepository
+	statementRepo repositories.CreditCardStatementRepository
+}
+
+// NewCreditCardService creates a new credit card service
+func NewCreditCardService(accountRepo repositories.AccountRepository, statementRepo repositories.CreditCardStatementRepository) CreditCardService {
+	return &creditCardService{
+		accountRepo:   accountRepo,
+		statementRepo: statementRepo,
+	}
+}
+
+// GetStatements retrieves the statements of a credit card account owned by
+// the user, newest first, after generating those of any billing cycle that
+// has closed since. Each statement reports what was paid towards it.
+func (s *creditCardService) GetStatements(userID, accountID uuid.UUID) ([]*models.CreditCardStatement, error) {
+	account, err := ownedAccount(s.accountRepo, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Type != models.AccountTypeCreditCard {
+		return nil, errors.New("account is not a credit card")
+	}
+
+	now := time.Now()
+	if _, err := s.generateStatements(account, now); err != nil {
+		return nil, err
+	}
+
+	statements, err := s.statementRepo.GetByAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	paid, err := s.statementRepo.GetPaidAmounts(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOnly(now)
+	for _, statement := range statements {
+		statement.Currency = account.Currency
+		statement.PaidAmount = paid[statement.ID]
+		statement.Status = statementStatus(statement, today)
+	}
+
+	return statements, nil
+}
+
+// GenerateDue generates the statements of every credit card billing cycle
+// that closed before asOf and returns how many were generated
+func (s *creditCardService) GenerateDue(asOf time.Time) (int, error) {
+	accounts, err := s.statementRepo.GetBillingAccounts()
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	var errs []error
+	for _, account := range accounts {
+		n, err := s.generateStatements(account, asOf)
+		generated += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", account.ID, err))
+		}
+	}
+
+	return generated, errors.Join(errs...)
+}
+
+// generateStatements creates a statement for every billing cycle of the
+// account that closed before asOf and has none yet. A cycle closes at the end
+// of its closing date. The first cycle starts when the account's history
+// does: at its creation or its earliest transaction, whichever is first.
+func (s *creditCardService) generateStatements(account *models.Account, asOf time.Time) (int, error) {
+	if account.StatementClosingDay == nil || account.PaymentDueDay == nil {
+		return 0, nil
+	}
+
+	var start time.Time
+	latest, err := s.statementRepo.GetLatest(account.ID)
+	switch {
+	case err == nil:
+		start = dateOnly(latest.PeriodEnd).AddDate(0, 0, 1)
+	case errors.Is(err, repositories.ErrStatementNotFound):
+		start = dateOnly(account.CreatedAt)
+		first, err := s.statementRepo.GetFirstTransactionDate(account.ID)
+		if err != nil {
+			return 0, err
+		}
+		if first != nil && first.Before(start) {
+			start = dateOnly(*first)
+		}
+	default:
+		return 0, err
+	}
+
+	today := dateOnly(asOf)
+	var statements []*models.CreditCardStatement
+	for {
+		end := models.NextClosingDate(start, *account.StatementClosingDay)
+		if !end.Before(today) {
+			break
+		}
+
+		statement, err := s.buildStatement(account, start, end)
+		if err != nil {
+			return 0, err
+		}
+		statements = append(statements, statement)
+		start = end.AddDate(0, 0, 1)
+	}
+
+	if err := s.statementRepo.Create(statements); err != nil {
+		return 0, err
+	}
+	return len(statements), nil
+}
+
+// buildStatement computes the statement of the billing cycle from start to
+// end. A card's balance is negative while money is owed, so the balances on
+// the statement are the negated account balances.
+func (s *creditCardService) buildStatement(account *models.Account, start, end time.Time) (*models.CreditCardStatement, error) {
+	activity, err := s.statementRepo.GetCycleActivity(account.ID, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	previous := account.OpeningBalance.Add(activity.PriorTotal).Neg()
+	balance := previous.Add(activity.Charges).Sub(activity.Credits)
+
+	return &models.CreditCardStatement{
+		AccountID:        account.ID,
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		DueDate:          models.PaymentDueDate(end, *account.PaymentDueDay),
+		PreviousBalance:  previous,
+		Charges:          activity.Charges,
+		Credits:          activity.Credits,
+		StatementBalance: balance,
+		MinimumPayment:   minimumPayment(balance, account.Currency),
+		CreditLimit:      account.CreditLimit,
+		APR:              account.APR,
+	}, nil
+}
+
+// minimumPayment returns the minimum payment due on a statement balance,
+// rounded up to the minor units of the currency
+func minimumPayment(balance decimal.Decimal, currency string) decimal.Decimal {
+	if !balance.IsPositive() {
+		return decimal.Zero
+	}
+
+	minimum := balance.Mul(minimumPaymentRate).RoundUp(iso4217.MinorUnits(currency))
+	if floor, ok := minimumPaymentFloors[currency]; ok {
+		minimum = decimal.Max(minimum, floor)
+	}
+	return decimal.Min(minimum, balance)
+}
+
+// statementStatus reports how far a statement has been paid as of today
+func statementStatus(statement *models.CreditCardStatement, today time.Time) models.StatementStatus {
+	switch {
+	case statement.PaidAmount.GreaterThanOrEqual(statement.StatementBalance):
+		return models.StatementPaid
+	case statement.PaidAmount.GreaterThanOrEqual(statement.MinimumPayment):
+		return models.StatementMinimumPaid
+	case today.After(dateOnly(statement.DueDate)):
+		return models.StatementOverdue
+	default:
+		return models.StatementDue
+	}
+}
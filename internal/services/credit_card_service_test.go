@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMinimumPayment(t *testing.T) {
+	tests := []struct {
+		name     string
+		balance  string
+		currency string
+		want     string
+	}{
+		{"nothing owed", "0", "USD", "0"},
+		{"in credit", "-50", "USD", "0"},
+		{"floor", "300", "USD", "25"},
+		{"rate", "5000", "USD", "100"},
+		{"rate rounded up to cents", "1300.01", "USD", "26.01"},
+		{"below floor", "10", "USD", "10"},
+		{"JPY floor", "50000", "JPY", "3500"},
+		{"JPY rate rounded up to yen", "1000001", "JPY", "20001"},
+		{"KWD floor", "100", "KWD", "8"},
+		{"KWD rate rounded up to fils", "1000.005", "KWD", "20.001"},
+		{"currency without floor", "1001", "ISK", "21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := minimumPayment(decimal.RequireFromString(tt.balance), tt.currency)
+			if want := decimal.RequireFromString(tt.want); !got.Equal(want) {
+				t.Errorf("minimumPayment(%s %s) = %s, want %s", tt.balance, tt.currency, got, want)
+			}
+		})
+	}
+}
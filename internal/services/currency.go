@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// ErrExchangeRateMissing is returned when a report needs to convert an amount
+// for which no exchange rate has been loaded
+var ErrExchangeRateMissing = errors.New("exchange rate missing")
+
+// normalizeCurrency validates an ISO 4217 currency code against the registry
+// and returns it in upper case
+func normalizeCurrency(code string) (string, error) {
+	c, err := iso4217.Lookup(code)
+	if err != nil {
+		return "", err
+	}
+	return c.Code, nil
+}
+
+// roundAmount rounds an amount to the minor units of the currency it is in
+func roundAmount(amount decimal.Decimal, currency string) decimal.Decimal {
+	return iso4217.Round(amount, currency)
+}
+
+// reportConversions returns the exchange rates a report over the date range
+// uses to convert amounts into currency. Transactions without a rate would be
+// left out of the report, so they fail it with ErrExchangeRateMissing.
+func reportConversions(transactionRepo repositories.TransactionRepository, userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*repositories.CurrencyConversion, error) {
+	conversions, err := transactionRepo.GetConversions(userID, currency, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, conversion := range conversions {
+		if conversion.Rate == nil {
+			return nil, fmt.Errorf("%w: no %s to %s rate on or before %s, needed for %d transaction(s)",
+				ErrExchangeRateMissing, conversion.FromCurrency, conversion.ToCurrency,
+				conversion.FirstDate.Format("2006-01-02"), conversion.Count)
+		}
+	}
+	return conversions, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// maxExchangeRatesPerLoad bounds the number of rates a single load may store
+const maxExchangeRatesPerLoad = 10000
+
+// Sources recorded on loaded exchange rates
+const (
+	exchangeRateSourceAPI  = "api"
+	exchangeRateSourceFile = "file"
+)
+
+type exchangeRateService struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(exchangeRateRepo repositories.ExchangeRateRepository, userRepo repositories.UserRepository) ExchangeRateService {
+	return &exchangeRateService{
+		exchangeRateRepo: exchangeRateRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// LoadRates stores exchange rates sent through the API
+func (s *exchangeRateService) LoadRates(userID uuid.UUID, rates []ExchangeRateRequest) (*ExchangeRateLoadResult, error) {
+	return s.store(userID, rates, exchangeRateSourceAPI)
+}
+
+// ImportRatesCSV stores exchange rates from a CSV file with a header row
+// naming the date, base_currency, quote_currency and rate columns. Dates are
+// YYYY-MM-DD. Any error rejects the whole file.
+func (s *exchangeRateService) ImportRatesCSV(userID uuid.UUID, r io.Reader) (*ExchangeRateLoadResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("exchange rate file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate file: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	columns := make(map[string]int, 4)
+	for _, name := range []string{"date", "base_currency", "quote_currency", "rate"} {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("exchange rate file has no %s column", name)
+		}
+		columns[name] = i
+	}
+
+	var rates []ExchangeRateRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate file: %w", err)
+		}
+		if len(rates) == maxExchangeRatesPerLoad {
+			return nil, fmt.Errorf("exchange rate file has more than %d rates", maxExchangeRatesPerLoad)
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[columns["date"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date, must be YYYY-MM-DD", line)
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(record[columns["rate"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate", line)
+		}
+		rates = append(rates, ExchangeRateRequest{
+			BaseCurrency:  record[columns["base_currency"]],
+			QuoteCurrency: record[columns["quote_currency"]],
+			Rate:          rate,
+			Date:          date,
+		})
+	}
+
+	return s.store(userID, rates, exchangeRateSourceFile)
+}
+
+// GetRates lists the user's exchange rates, newest first
+func (s *exchangeRateService) GetRates(req ExchangeRateListRequest) ([]*models.ExchangeRate, error) {
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	filter := repositories.ExchangeRateFilter{
+		UserID:    req.UserID,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	var err error
+	if req.BaseCurrency != "" {
+		if filter.BaseCurrency, err = normalizeCurrency(req.BaseCurrency); err != nil {
+			return nil, err
+		}
+	}
+	if req.QuoteCurrency != "" {
+		if filter.QuoteCurrency, err = normalizeCurrency(req.QuoteCurrency); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.exchangeRateRepo.GetByFilter(filter)
+}
+
+// DeleteRate deletes an exchange rate owned by the user
+func (s *exchangeRateService) DeleteRate(userID, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("invalid exchange rate ID")
+	}
+
+	rate, err := s.exchangeRateRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if rate.UserID != userID {
+		return repositories.ErrExchangeRateNotFound
+	}
+
+	return s.exchangeRateRepo.Delete(id)
+}
+
+// store validates rates and stores them for the user. Any invalid rate
+// rejects the whole load.
+func (s *exchangeRateService) store(userID uuid.UUID, reqs []ExchangeRateRequest, source string) (*ExchangeRateLoadResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.New("at least one exchange rate is required")
+	}
+	if len(reqs) > maxExchangeRatesPerLoad {
+		return nil, fmt.Errorf("cannot load more than %d exchange rates at once", maxExchangeRatesPerLoad)
+	}
+
+	exists, err := s.userRepo.Exists(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, repositories.ErrUserNotFound
+	}
+
+	// The last rate given for a pair and date wins
+	type key struct {
+		base, quote string
+		date        time.Time
+	}
+	byKey := make(map[key]*models.ExchangeRate, len(reqs))
+	rates := make([]*models.ExchangeRate, 0, len(reqs))
+	for i, req := range reqs {
+		rate, err := newExchangeRate(userID, req, source)
+		if err != nil {
+			return nil, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+		k := key{rate.BaseCurrency, rate.QuoteCurrency, rate.Date}
+		if existing, ok := byKey[k]; ok {
+			existing.Rate = rate.Rate
+			continue
+		}
+		byKey[k] = rate
+		rates = append(rates, rate)
+	}
+
+	if err := s.exchangeRateRepo.Upsert(rates); err != nil {
+		return nil, err
+	}
+	return &ExchangeRateLoadResult{Loaded: len(rates)}, nil
+}
+
+// newExchangeRate validates a rate request and converts it to a model
+func newExchangeRate(userID uuid.UUID, req ExchangeRateRequest, source string) (*models.ExchangeRate, error) {
+	base, err := normalizeCurrency(req.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := normalizeCurrency(req.QuoteCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if base == quote {
+		return nil, errors.New("base and quote currencies must differ")
+	}
+	if !req.Rate.IsPositive() {
+		return nil, errors.New("exchange rate must be positive")
+	}
+	if req.Date.IsZero() {
+		return nil, errors.New("exchange rate date is required")
+	}
+
+	return &models.ExchangeRate{
+		UserID:        userID,
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          req.Rate,
+		Date:          dateOnly(req.Date),
+		Source:        source,
+	}, nil
+}
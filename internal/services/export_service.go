@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/exporter"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+type exportService struct {
+	userRepo        repositories.UserRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+	transactionRepo repositories.TransactionRepository
+}
+
+// NewExportService creates a new plain-text accounting export service
+func NewExportService(
+	userRepo repositories.UserRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactionRepo repositories.TransactionRepository,
+) ExportService {
+	return &exportService{
+		userRepo:        userRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// ExportJournal writes the user's accounts, categories and transactions as a
+// ledger, hledger or beancount journal in the user's currency. Transactions
+// are streamed, and the journal ends with a balance assertion per account.
+func (s *exportService) ExportJournal(userID uuid.UUID, format exporter.JournalFormat, w io.Writer) error {
+	if userID == uuid.Nil {
+		return errors.New("invalid user ID")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := s.accountRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.categoryRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	// Open every account on the earliest date the journal refers to
+	first, _, err := s.transactionRepo.GetDateRange(userID)
+	if err != nil {
+		return err
+	}
+	start := dateOnly(time.Now())
+	if first != nil && first.Before(start) {
+		start = dateOnly(*first)
+	}
+	for _, account := range accounts {
+		if created := dateOnly(account.CreatedAt); created.Before(start) {
+			start = created
+		}
+	}
+
+	writer, err := exporter.NewJournalWriter(format, w, user.Currency, accounts, categories, start)
+	if err != nil {
+		return err
+	}
+
+	// Allocations of one transaction arrive consecutively; collect them into
+	// a single journal entry
+	var current *exporter.JournalTransaction
+	err = s.transactionRepo.StreamAllocations(userID, func(allocation *repositories.TransactionAllocation) error {
+		if current != nil && current.ID != allocation.TransactionID {
+			if err := writer.WriteTransaction(current); err != nil {
+				return err
+			}
+			current = nil
+		}
+		if current == nil {
+			current = &exporter.JournalTransaction{
+				ID:          allocation.TransactionID,
+				Date:        dateOnly(allocation.Date),
+				Description: allocation.Description,
+				AccountID:   allocation.AccountID,
+				TransferID:  allocation.TransferID,
+			}
+		}
+		current.Amount = current.Amount.Add(allocation.Amount)
+		current.Postings = append(current.Postings, exporter.JournalPosting{
+			CategoryID: allocation.CategoryID,
+			Amount:     allocation.Amount,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		if err := writer.WriteTransaction(current); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
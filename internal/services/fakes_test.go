@@ -0,0 +1,373 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// memoryStore holds the records shared by the in-memory repositories below.
+// The repositories embed their interface, so a test calling a method they do
+// not implement panics instead of silently passing.
+type memoryStore struct {
+	mu           sync.Mutex
+	users        map[uuid.UUID]*models.User
+	accounts     map[uuid.UUID]*models.Account
+	categories   map[uuid.UUID]*models.Category
+	transactions map[uuid.UUID]*models.Transaction
+
+	// failCreate, when set, is consulted before a transaction is stored and
+	// fails the create with the error it returns
+	failCreate func(transaction *models.Transaction) error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		users:        make(map[uuid.UUID]*models.User),
+		accounts:     make(map[uuid.UUID]*models.Account),
+		categories:   make(map[uuid.UUID]*models.Category),
+		transactions: make(map[uuid.UUID]*models.Transaction),
+	}
+}
+
+func (m *memoryStore) repositories() repositories.Repositories {
+	return repositories.Repositories{
+		Users:        &memoryUserRepository{store: m},
+		Accounts:     &memoryAccountRepository{store: m},
+		Categories:   &memoryCategoryRepository{store: m},
+		Transactions: &memoryTransactionRepository{store: m},
+	}
+}
+
+// addUser stores a user with a category and an account in currency
+func (m *memoryStore) addUser(currency string) (*models.User, *models.Account, *models.Category) {
+	user := &models.User{ID: uuid.New(), Email: uuid.NewString() + "@example.com", Name: "Test", Currency: currency}
+	account := &models.Account{
+		ID: uuid.New(), UserID: user.ID, Name: "Checking", Type: models.AccountTypeBank,
+		Currency: currency, IsActive: true,
+	}
+	category := &models.Category{ID: uuid.New(), UserID: user.ID, Name: "Food", Type: models.CategoryTypeExpense}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users[user.ID] = user
+	m.accounts[account.ID] = account
+	m.categories[category.ID] = category
+	return user, account, category
+}
+
+// addAccount stores another account of the user
+func (m *memoryStore) addAccount(userID uuid.UUID, currency string) *models.Account {
+	account := &models.Account{
+		ID: uuid.New(), UserID: userID, Name: "Account " + currency, Type: models.AccountTypeBank,
+		Currency: currency, IsActive: true,
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accounts[account.ID] = account
+	return account
+}
+
+// account returns a copy of a stored account
+func (m *memoryStore) account(id uuid.UUID) *models.Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	account := *m.accounts[id]
+	return &account
+}
+
+type memoryUnitOfWork struct {
+	store *memoryStore
+}
+
+func (u *memoryUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return fn(u.store.repositories())
+}
+
+type memoryUserRepository struct {
+	repositories.UserRepository
+	store *memoryStore
+}
+
+func (r *memoryUserRepository) GetByID(id uuid.UUID) (*models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, repositories.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *memoryUserRepository) Exists(id uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	_, ok := r.store.users[id]
+	return ok, nil
+}
+
+func (r *memoryUserRepository) Update(user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	copied := *user
+	r.store.users[user.ID] = &copied
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.users[id]; !ok {
+		return repositories.ErrUserNotFound
+	}
+	delete(r.store.users, id)
+	return nil
+}
+
+type memoryAccountRepository struct {
+	repositories.AccountRepository
+	store *memoryStore
+}
+
+func (r *memoryAccountRepository) GetByID(id uuid.UUID) (*models.Account, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	account, ok := r.store.accounts[id]
+	if !ok {
+		return nil, repositories.ErrAccountNotFound
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func (r *memoryAccountRepository) Update(account *models.Account) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.accounts[account.ID]
+	if !ok {
+		return repositories.ErrAccountNotFound
+	}
+	copied := *account
+	copied.Balance = stored.Balance
+	copied.OpeningBalance = stored.OpeningBalance
+	r.store.accounts[account.ID] = &copied
+	return nil
+}
+
+func (r *memoryAccountRepository) AdjustBalance(id uuid.UUID, delta decimal.Decimal) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	account, ok := r.store.accounts[id]
+	if !ok {
+		return repositories.ErrAccountNotFound
+	}
+	account.Balance = account.Balance.Add(delta)
+	return nil
+}
+
+func (r *memoryAccountRepository) Delete(id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.accounts[id]; !ok {
+		return repositories.ErrAccountNotFound
+	}
+	delete(r.store.accounts, id)
+	return nil
+}
+
+type memoryCategoryRepository struct {
+	repositories.CategoryRepository
+	store *memoryStore
+}
+
+func (r *memoryCategoryRepository) Exists(userID, id uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	category, ok := r.store.categories[id]
+	return ok && category.UserID == userID, nil
+}
+
+type memoryTransactionRepository struct {
+	repositories.TransactionRepository
+	store *memoryStore
+}
+
+func (r *memoryTransactionRepository) Create(transaction *models.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if r.store.failCreate != nil {
+		if err := r.store.failCreate(transaction); err != nil {
+			return err
+		}
+	}
+	if transaction.ID == uuid.Nil {
+		transaction.ID = uuid.New()
+	}
+	copied := *transaction
+	r.store.transactions[transaction.ID] = &copied
+	return nil
+}
+
+func (r *memoryTransactionRepository) CreateBatch(transactions []*models.Transaction) error {
+	for _, transaction := range transactions {
+		if err := r.Create(transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryTransactionRepository) GetByAccountAndDateRange(accountID uuid.UUID, startDate, endDate time.Time) ([]*models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var found []*models.Transaction
+	for _, transaction := range r.store.transactions {
+		if transaction.AccountID == accountID && !transaction.Date.Before(startDate) && !transaction.Date.After(endDate) {
+			copied := *transaction
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryTransactionRepository) FindExternalIDs(accountID uuid.UUID, externalIDs []string) ([]string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	wanted := make(map[string]bool, len(externalIDs))
+	for _, id := range externalIDs {
+		wanted[id] = true
+	}
+	var found []string
+	for _, transaction := range r.store.transactions {
+		if transaction.AccountID == accountID && transaction.ExternalID != nil && wanted[*transaction.ExternalID] {
+			found = append(found, *transaction.ExternalID)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryTransactionRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	transaction, ok := r.store.transactions[id]
+	if !ok {
+		return nil, repositories.ErrTransactionNotFound
+	}
+	copied := *transaction
+	if account, ok := r.store.accounts[copied.AccountID]; ok {
+		copied.Account = *account
+	}
+	return &copied, nil
+}
+
+func (r *memoryTransactionRepository) GetForUpdate(id uuid.UUID) (*models.Transaction, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryTransactionRepository) GetByTransferIDForUpdate(transferID uuid.UUID) ([]*models.Transaction, error) {
+	return r.GetByTransferID(transferID)
+}
+
+func (r *memoryTransactionRepository) GetByTransferID(transferID uuid.UUID) ([]*models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var legs []*models.Transaction
+	for _, transaction := range r.store.transactions {
+		if transaction.TransferID != nil && *transaction.TransferID == transferID {
+			copied := *transaction
+			copied.Account = *r.store.accounts[copied.AccountID]
+			legs = append(legs, &copied)
+		}
+	}
+	return legs, nil
+}
+
+func (r *memoryTransactionRepository) Update(transaction *models.Transaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.transactions[transaction.ID]; !ok {
+		return repositories.ErrTransactionNotFound
+	}
+	copied := *transaction
+	r.store.transactions[transaction.ID] = &copied
+	return nil
+}
+
+func (r *memoryTransactionRepository) Delete(id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.transactions[id]; !ok {
+		return repositories.ErrTransactionNotFound
+	}
+	delete(r.store.transactions, id)
+	return nil
+}
+
+func (r *memoryTransactionRepository) GetConversions(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*repositories.CurrencyConversion, error) {
+	return nil, nil
+}
+
+func (r *memoryTransactionRepository) GetSummaryByCategory(userID uuid.UUID, currency string, startDate, endDate *time.Time) ([]*repositories.TransactionSummary, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	totals := make(map[uuid.UUID]*repositories.TransactionSummary)
+	var summaries []*repositories.TransactionSummary
+	for _, transaction := range r.store.transactions {
+		if transaction.UserID != userID || transaction.CategoryID == nil {
+			continue
+		}
+		summary, ok := totals[*transaction.CategoryID]
+		if !ok {
+			summary = &repositories.TransactionSummary{CategoryID: *transaction.CategoryID}
+			totals[*transaction.CategoryID] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.TotalAmount = summary.TotalAmount.Add(transaction.Amount)
+		summary.OwnAmount = summary.OwnAmount.Add(transaction.Amount)
+		summary.Count++
+	}
+	return summaries, nil
+}
+
+type memoryRecurringRepository struct {
+	repositories.RecurringRepository
+	mu        sync.Mutex
+	recurring map[uuid.UUID]*models.RecurringTransaction
+}
+
+func newMemoryRecurringRepository(recurring ...*models.RecurringTransaction) *memoryRecurringRepository {
+	r := &memoryRecurringRepository{recurring: make(map[uuid.UUID]*models.RecurringTransaction)}
+	for _, item := range recurring {
+		r.recurring[item.ID] = item
+	}
+	return r
+}
+
+func (r *memoryRecurringRepository) GetDue(asOf time.Time) ([]*models.RecurringTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*models.RecurringTransaction
+	for _, recurring := range r.recurring {
+		if recurring.IsActive && recurring.NextOccurrence != nil && !recurring.NextOccurrence.After(asOf) {
+			copied := *recurring
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryRecurringRepository) UpdateNextOccurrence(id uuid.UUID, next *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recurring, ok := r.recurring[id]
+	if !ok {
+		return repositories.ErrRecurringNotFound
+	}
+	recurring.NextOccurrence = next
+	return nil
+}
@@ -0,0 +1,471 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/importer"
+	"github.com/vasujain275/expense-tracker-api/internal/iso4217"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+type importService struct {
+	uow             repositories.UnitOfWork
+	profileRepo     repositories.ImportProfileRepository
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+	categoryRepo    repositories.CategoryRepository
+}
+
+// NewImportService creates a new statement import service
+func NewImportService(
+	uow repositories.UnitOfWork,
+	profileRepo repositories.ImportProfileRepository,
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+) ImportService {
+	return &importService{
+		uow:             uow,
+		profileRepo:     profileRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// CreateProfile creates a CSV column mapping profile
+func (s *importService) CreateProfile(req ImportProfileRequest) (*models.ImportProfile, error) {
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	profile := &models.ImportProfile{UserID: req.UserID}
+	if err := s.applyProfileRequest(profile, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Create(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// GetProfile retrieves an import profile if it belongs to the user
+func (s *importService) GetProfile(userID, id uuid.UUID) (*models.ImportProfile, error) {
+	return s.getOwnedProfile(userID, id)
+}
+
+// GetUserProfiles retrieves all import profiles for a user
+func (s *importService) GetUserProfiles(userID uuid.UUID) ([]*models.ImportProfile, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.profileRepo.GetByUserID(userID)
+}
+
+// UpdateProfile replaces the mapping of an import profile owned by the user
+func (s *importService) UpdateProfile(userID, id uuid.UUID, req ImportProfileRequest) (*models.ImportProfile, error) {
+	profile, err := s.getOwnedProfile(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyProfileRequest(profile, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Update(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// DeleteProfile deletes an import profile owned by the user
+func (s *importService) DeleteProfile(userID, id uuid.UUID) error {
+	if _, err := s.getOwnedProfile(userID, id); err != nil {
+		return err
+	}
+
+	return s.profileRepo.Delete(id)
+}
+
+// ImportCSV parses a CSV statement with a saved profile and either previews
+// the rows or commits them to the account
+func (s *importService) ImportCSV(req CSVImportRequest) (*ImportResult, error) {
+	account, err := ownedAccount(s.accountRepo, req.UserID, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getOwnedProfile(req.UserID, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := importer.ParseCSV(req.File, profileMapping(profile))
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID := profile.DefaultCategoryID
+	if req.CategoryID != nil {
+		categoryID = *req.CategoryID
+	}
+
+	return s.importStatement(account, categoryID, statement, req.Commit)
+}
+
+// ImportOFX parses an OFX or QFX statement and either previews the rows or
+// commits them to the account. FITIDs make re-imports of overlapping
+// statements idempotent.
+func (s *importService) ImportOFX(req StatementImportRequest) (*ImportResult, error) {
+	return s.importFile(req, importer.ParseOFX)
+}
+
+// ImportCAMT053 parses an ISO 20022 camt.053 statement and either previews
+// the rows or commits them to the account. Bank references make re-imports
+// idempotent.
+func (s *importService) ImportCAMT053(req StatementImportRequest) (*ImportResult, error) {
+	return s.importFile(req, importer.ParseCAMT053)
+}
+
+// ImportMT940 parses a SWIFT MT940 statement and either previews the rows or
+// commits them to the account. Bank references make re-imports idempotent.
+func (s *importService) ImportMT940(req StatementImportRequest) (*ImportResult, error) {
+	return s.importFile(req, importer.ParseMT940)
+}
+
+// importFile parses a self-describing statement file and imports it into the
+// requested account
+func (s *importService) importFile(req StatementImportRequest, parse func(io.Reader) (*importer.Statement, error)) (*ImportResult, error) {
+	account, err := ownedAccount(s.accountRepo, req.UserID, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := parse(req.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.importStatement(account, req.CategoryID, statement, req.Commit)
+}
+
+// importStatement flags rows that already exist on the account and, when
+// committing, inserts the remaining rows and applies their total to the
+// balance in a single transaction. A statement in another currency than the
+// account's is refused.
+func (s *importService) importStatement(account *models.Account, categoryID uuid.UUID, statement *importer.Statement, commit bool) (*ImportResult, error) {
+	if statement.Currency != "" && statement.Currency != account.Currency {
+		return nil, fmt.Errorf("statement is in %s but the account is in %s", statement.Currency, account.Currency)
+	}
+
+	exists, err := s.categoryRepo.Exists(account.UserID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, repositories.ErrCategoryNotFound
+	}
+
+	// Amounts are kept to the minor units of the account's currency; a row
+	// that rounds to nothing cannot be booked
+	kept := statement.Rows[:0]
+	for _, row := range statement.Rows {
+		row.Amount = roundAmount(row.Amount, account.Currency)
+		if row.Amount.IsZero() {
+			statement.Errors = append(statement.Errors, importer.RowError{
+				Line:    row.Line,
+				Message: fmt.Sprintf("amount rounds to zero in %s", account.Currency),
+			})
+			continue
+		}
+		kept = append(kept, row)
+	}
+	statement.Rows = kept
+
+	rows, err := s.markDuplicates(account.ID, statement.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Rows:           rows,
+		Errors:         statement.Errors,
+		TotalRows:      len(rows),
+		NetAmount:      decimal.Zero,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+	}
+
+	var transactions []*models.Transaction
+	for i := range rows {
+		if rows[i].Duplicate {
+			result.DuplicateRows++
+			continue
+		}
+		result.NewRows++
+		result.NetAmount = result.NetAmount.Add(rows[i].Amount)
+
+		transaction := &models.Transaction{
+			ID:          uuid.New(),
+			UserID:      account.UserID,
+			AccountID:   account.ID,
+			CategoryID:  &categoryID,
+			Amount:      rows[i].Amount,
+			Description: rows[i].Description,
+			Date:        rows[i].Date,
+		}
+		if rows[i].ExternalID != "" {
+			externalID := rows[i].ExternalID
+			transaction.ExternalID = &externalID
+		}
+		rows[i].TransactionID = &transaction.ID
+		transactions = append(transactions, transaction)
+	}
+
+	statementTotal := decimal.Zero
+	for _, row := range rows {
+		statementTotal = statementTotal.Add(row.Amount)
+	}
+	result.Reconciliation = reconcile(account.Balance, result.NetAmount, statementTotal, statement)
+
+	if !commit {
+		for i := range rows {
+			rows[i].TransactionID = nil
+		}
+		return result, nil
+	}
+
+	if len(statement.Errors) > 0 {
+		return nil, fmt.Errorf("%d line(s) could not be parsed; fix them or adjust the profile before committing", len(statement.Errors))
+	}
+
+	// Re-importing a statement that is already fully imported is a no-op
+	if len(transactions) == 0 {
+		result.Committed = true
+		return result, nil
+	}
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Transactions.CreateBatch(transactions); err != nil {
+			return err
+		}
+		if result.NetAmount.IsZero() {
+			return nil
+		}
+		return applyToBalance(repos.Accounts, account.ID, result.NetAmount)
+	})
+	if errors.Is(err, repositories.ErrDuplicateTransaction) {
+		return nil, errors.New("some lines were imported concurrently; preview the statement again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// markDuplicates flags rows that match an existing transaction on the account,
+// either by external ID or by date, amount and description. Each existing
+// transaction matches at most one row, so repeated identical lines are only
+// flagged as often as they already exist.
+func (s *importService) markDuplicates(accountID uuid.UUID, rows []importer.Row) ([]ImportRow, error) {
+	result := make([]ImportRow, len(rows))
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	var externalIDs []string
+	start, end := rows[0].Date, rows[0].Date
+	for i, row := range rows {
+		result[i] = ImportRow{Row: row}
+		if row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+		if row.Date.Before(start) {
+			start = row.Date
+		}
+		if row.Date.After(end) {
+			end = row.Date
+		}
+	}
+
+	found, err := s.transactionRepo.FindExternalIDs(accountID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	seenExternalIDs := make(map[string]bool, len(found))
+	for _, id := range found {
+		seenExternalIDs[id] = true
+	}
+
+	existing, err := s.transactionRepo.GetByAccountAndDateRange(
+		accountID, dateOnly(start), dateOnly(end).AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	unmatched := make(map[string]int, len(existing))
+	for _, t := range existing {
+		unmatched[duplicateKey(t.Date, t.Amount, t.Description)]++
+	}
+
+	for i := range result {
+		row := &result[i]
+		if row.ExternalID != "" {
+			if seenExternalIDs[row.ExternalID] {
+				row.Duplicate = true
+				continue
+			}
+			seenExternalIDs[row.ExternalID] = true
+		}
+
+		key := duplicateKey(row.Date, row.Amount, row.Description)
+		if unmatched[key] > 0 {
+			unmatched[key]--
+			row.Duplicate = true
+		}
+	}
+
+	return result, nil
+}
+
+// reconcile compares the statement balances with the account balance before
+// and after the new rows are applied. statementTotal is the sum of every
+// statement row, duplicates included.
+func reconcile(accountBalance, netAmount, statementTotal decimal.Decimal, statement *importer.Statement) *Reconciliation {
+	if statement.OpeningBalance == nil && statement.ClosingBalance == nil {
+		return nil
+	}
+
+	projected := accountBalance.Add(netAmount)
+	reconciliation := &Reconciliation{
+		AccountBalance:   accountBalance,
+		ProjectedBalance: projected,
+		Reconciled:       true,
+	}
+
+	if statement.OpeningBalance != nil {
+		expected := projected.Sub(statementTotal)
+		difference := statement.OpeningBalance.Sub(expected)
+		reconciliation.OpeningBalance = statement.OpeningBalance
+		reconciliation.ExpectedOpeningBalance = &expected
+		reconciliation.OpeningDifference = &difference
+		reconciliation.Reconciled = difference.IsZero()
+	}
+
+	if statement.ClosingBalance != nil {
+		difference := statement.ClosingBalance.Sub(projected)
+		reconciliation.ClosingBalance = statement.ClosingBalance
+		reconciliation.BalanceDate = statement.BalanceDate
+		reconciliation.ClosingDifference = &difference
+		reconciliation.Reconciled = reconciliation.Reconciled && difference.IsZero()
+	}
+
+	return reconciliation
+}
+
+// duplicateKey identifies a transaction by calendar date, amount and description
+func duplicateKey(date time.Time, amount decimal.Decimal, description string) string {
+	return dateOnly(date).Format("2006-01-02") + "|" + amount.StringFixed(iso4217.MaxMinorUnits) + "|" +
+		strings.ToLower(strings.Join(strings.Fields(description), " "))
+}
+
+// applyProfileRequest validates the request and copies it onto the profile
+func (s *importService) applyProfileRequest(profile *models.ImportProfile, req ImportProfileRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+
+	delimiter := req.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+	}
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return errors.New("delimiter must be a single character")
+	}
+
+	decimalSeparator := req.DecimalSeparator
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+
+	signConvention := req.SignConvention
+	if signConvention == "" {
+		signConvention = string(importer.SignNegativeIsDebit)
+	}
+
+	exists, err := s.categoryRepo.Exists(profile.UserID, req.DefaultCategoryID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repositories.ErrCategoryNotFound
+	}
+
+	profile.Name = name
+	profile.Delimiter = delimiter
+	profile.HasHeader = req.HasHeader
+	profile.SkipRows = req.SkipRows
+	profile.DateColumn = strings.TrimSpace(req.DateColumn)
+	profile.AmountColumn = strings.TrimSpace(req.AmountColumn)
+	profile.DebitColumn = strings.TrimSpace(req.DebitColumn)
+	profile.CreditColumn = strings.TrimSpace(req.CreditColumn)
+	profile.DescriptionColumn = strings.TrimSpace(req.DescriptionColumn)
+	profile.ExternalIDColumn = strings.TrimSpace(req.ExternalIDColumn)
+	profile.DateFormat = strings.TrimSpace(req.DateFormat)
+	profile.DecimalSeparator = decimalSeparator
+	profile.SignConvention = signConvention
+	profile.DefaultCategoryID = req.DefaultCategoryID
+
+	return profileMapping(profile).Validate()
+}
+
+// getOwnedProfile retrieves an import profile, treating profiles owned by other users as missing
+func (s *importService) getOwnedProfile(userID, id uuid.UUID) (*models.ImportProfile, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid import profile ID")
+	}
+
+	profile, err := s.profileRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, repositories.ErrImportProfileNotFound
+	}
+
+	return profile, nil
+}
+
+// profileMapping converts a saved profile into a CSV mapping
+func profileMapping(profile *models.ImportProfile) importer.CSVMapping {
+	delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter)
+	return importer.CSVMapping{
+		Delimiter:         delimiter,
+		HasHeader:         profile.HasHeader,
+		SkipRows:          profile.SkipRows,
+		DateColumn:        profile.DateColumn,
+		AmountColumn:      profile.AmountColumn,
+		DebitColumn:       profile.DebitColumn,
+		CreditColumn:      profile.CreditColumn,
+		DescriptionColumn: profile.DescriptionColumn,
+		ExternalIDColumn:  profile.ExternalIDColumn,
+		DateFormat:        profile.DateFormat,
+		DecimalSeparator:  profile.DecimalSeparator,
+		SignConvention:    importer.SignConvention(profile.SignConvention),
+	}
+}
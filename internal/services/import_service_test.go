@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// importFixture commits a statement from the importer's testdata through import
+func importFixture(t *testing.T, name string, importFile func(StatementImportRequest) (*ImportResult, error), userID, accountID, categoryID uuid.UUID) *ImportResult {
+	t.Helper()
+	f, err := os.Open(filepath.Join("..", "importer", "testdata", name))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	result, err := importFile(StatementImportRequest{
+		UserID: userID, AccountID: accountID, CategoryID: categoryID, File: f, Commit: true,
+	})
+	if err != nil {
+		t.Fatalf("import %s: %v", name, err)
+	}
+	return result
+}
+
+// TestReimportIsNoOp imports each statement twice. The second import must
+// find every row already present and leave the account untouched.
+func TestReimportIsNoOp(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		currency string
+		format   func(ImportService, StatementImportRequest) (*ImportResult, error)
+		rows     int
+		net      string
+	}{
+		{"ofx1_sgml.ofx", "USD", ImportService.ImportOFX, 3, "2447.84"},
+		{"ofx2_xml.ofx", "EUR", ImportService.ImportOFX, 3, "-113.50"},
+		{"camt053.xml", "EUR", ImportService.ImportCAMT053, 4, "2490.01"},
+		{"mt940.sta", "EUR", ImportService.ImportMT940, 5, "2457.84"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			store := newMemoryStore()
+			repos := store.repositories()
+			user, account, category := store.addUser(tt.currency)
+			service := NewImportService(&memoryUnitOfWork{store: store}, nil,
+				repos.Transactions, repos.Accounts, repos.Categories)
+			importFile := func(req StatementImportRequest) (*ImportResult, error) { return tt.format(service, req) }
+
+			first := importFixture(t, tt.fixture, importFile, user.ID, account.ID, category.ID)
+			if first.NewRows != tt.rows || first.DuplicateRows != 0 || !first.Committed {
+				t.Fatalf("first import: %d new, %d duplicate, committed %v; want %d new",
+					first.NewRows, first.DuplicateRows, first.Committed, tt.rows)
+			}
+			want := decimal.RequireFromString(tt.net)
+			if balance := store.account(account.ID).Balance; !balance.Equal(want) {
+				t.Fatalf("balance after first import = %s, want %s", balance, want)
+			}
+			transactions := len(store.transactions)
+
+			second := importFixture(t, tt.fixture, importFile, user.ID, account.ID, category.ID)
+			if second.NewRows != 0 || second.DuplicateRows != tt.rows || !second.Committed {
+				t.Errorf("second import: %d new, %d duplicate, committed %v; want all %d duplicate",
+					second.NewRows, second.DuplicateRows, second.Committed, tt.rows)
+			}
+			if balance := store.account(account.ID).Balance; !balance.Equal(want) {
+				t.Errorf("balance after second import = %s, want %s", balance, want)
+			}
+			if len(store.transactions) != transactions {
+				t.Errorf("second import created %d transactions", len(store.transactions)-transactions)
+			}
+		})
+	}
+}
+
+// TestImportLedgerBalanceDifference imports an OFX statement whose LEDGERBAL
+// is 1000.00 higher than its transactions explain, as when the account was
+// opened without its starting balance
+func TestImportLedgerBalanceDifference(t *testing.T) {
+	store := newMemoryStore()
+	repos := store.repositories()
+	user, account, category := store.addUser("USD")
+	service := NewImportService(&memoryUnitOfWork{store: store}, nil,
+		repos.Transactions, repos.Accounts, repos.Categories)
+
+	result := importFixture(t, "ofx1_sgml.ofx", service.ImportOFX, user.ID, account.ID, category.ID)
+	check := func(label string, reconciliation *Reconciliation, projected, difference string) {
+		t.Helper()
+		if reconciliation == nil || reconciliation.ClosingDifference == nil {
+			t.Fatalf("%s: no closing balance reconciliation: %+v", label, reconciliation)
+		}
+		if !reconciliation.ProjectedBalance.Equal(decimal.RequireFromString(projected)) {
+			t.Errorf("%s: projected balance = %s, want %s", label, reconciliation.ProjectedBalance, projected)
+		}
+		if !reconciliation.ClosingDifference.Equal(decimal.RequireFromString(difference)) {
+			t.Errorf("%s: closing difference = %s, want %s", label, reconciliation.ClosingDifference, difference)
+		}
+		if reconciliation.Reconciled != (difference == "0") {
+			t.Errorf("%s: reconciled = %v with difference %s", label, reconciliation.Reconciled, difference)
+		}
+	}
+	check("first import", result.Reconciliation, "2447.84", "1000")
+
+	// Re-importing changes nothing, so the difference remains
+	result = importFixture(t, "ofx1_sgml.ofx", service.ImportOFX, user.ID, account.ID, category.ID)
+	check("re-import", result.Reconciliation, "2447.84", "1000")
+
+	// Once the missing opening balance is booked the statement reconciles
+	if err := repos.Accounts.AdjustBalance(account.ID, decimal.NewFromInt(1000)); err != nil {
+		t.Fatalf("adjust balance: %v", err)
+	}
+	result = importFixture(t, "ofx1_sgml.ofx", service.ImportOFX, user.ID, account.ID, category.ID)
+	check("after opening balance", result.Reconciliation, "3447.84", "0")
+}
+
+// TestImportMT940RepeatedCustomerReference imports two monthly statements
+// whose rent payments carry the same customer reference and no bank
+// reference. The second payment is a new transaction, not a duplicate.
+func TestImportMT940RepeatedCustomerReference(t *testing.T) {
+	store := newMemoryStore()
+	repos := store.repositories()
+	user, account, category := store.addUser("EUR")
+	service := NewImportService(&memoryUnitOfWork{store: store}, nil,
+		repos.Transactions, repos.Accounts, repos.Categories)
+
+	statement := func(month int, opening, closing string) string {
+		return fmt.Sprintf(":20:STMT25%02d\n:25:37040044/0532013000\n:28C:%05d/001\n:60F:C25%02d01EUR%s\n"+
+			":61:25%02d01D850,00NSTORENT-FLAT3\n:86:MIETE WOHNUNG 3\n:62F:C25%02d28EUR%s\n",
+			month, month, month, opening, month, month, closing)
+	}
+	for _, tt := range []struct {
+		month            int
+		opening, closing string
+	}{
+		{1, "2000,00", "1150,00"},
+		{2, "1150,00", "300,00"},
+	} {
+		result, err := service.ImportMT940(StatementImportRequest{
+			UserID: user.ID, AccountID: account.ID, CategoryID: category.ID,
+			File: strings.NewReader(statement(tt.month, tt.opening, tt.closing)), Commit: true,
+		})
+		if err != nil {
+			t.Fatalf("import month %d: %v", tt.month, err)
+		}
+		if result.NewRows != 1 || result.DuplicateRows != 0 {
+			t.Errorf("month %d: %d new, %d duplicate; want 1 new", tt.month, result.NewRows, result.DuplicateRows)
+		}
+	}
+	if balance := store.account(account.ID).Balance; !balance.Equal(decimal.NewFromInt(-1700)) {
+		t.Errorf("balance = %s, want -1700", balance)
+	}
+}
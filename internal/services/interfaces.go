@@ -1,60 +1,225 @@
 package services
 
 import (
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/exporter"
+	"github.com/vasujain275/expense-tracker-api/internal/importer"
 	"github.com/vasujain275/expense-tracker-api/internal/models"
 	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 )
 
+// AuthService interface defines credential checks and token issuance
+type AuthService interface {
+	Login(email, password string) (*models.User, error)
+	ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error
+	GenerateToken(userID uuid.UUID) (string, time.Time, error)
+	ValidateToken(token string) (uuid.UUID, error)
+}
+
 // UserService interface defines business logic for user operations
 type UserService interface {
-	CreateUser(email, name, currency string) (*models.User, error)
-	GetUserByID(id uuid.UUID) (*models.User, error)
+	CreateUser(email, name, currency, password string) (*models.User, error)
+	GetUserByID(actingUserID, id uuid.UUID) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
-	UpdateUser(id uuid.UUID, name, currency string) (*models.User, error)
-	DeleteUser(id uuid.UUID) error
+	UpdateUser(actingUserID, id uuid.UUID, name, currency string) (*models.User, error)
+	DeleteUser(actingUserID, id uuid.UUID) error
 }
 
 // AccountService interface defines business logic for account operations
 type AccountService interface {
-	CreateAccount(userID uuid.UUID, name string, accountType models.AccountType, initialBalance decimal.Decimal) (*models.Account, error)
-	GetAccountByID(id uuid.UUID) (*models.Account, error)
+	CreateAccount(userID uuid.UUID, name string, accountType models.AccountType, currency string, initialBalance decimal.Decimal, card CreditCardSettings) (*models.Account, error)
+	GetAccountByID(userID, id uuid.UUID) (*models.Account, error)
 	GetUserAccounts(userID uuid.UUID, activeOnly bool) ([]*models.Account, error)
-	UpdateAccount(id uuid.UUID, name string, accountType models.AccountType, isActive bool) (*models.Account, error)
-	DeleteAccount(id uuid.UUID) error
-	GetAccountBalance(id uuid.UUID) (decimal.Decimal, error)
+	UpdateAccount(userID, id uuid.UUID, name string, accountType models.AccountType, isActive *bool, card CreditCardSettings) (*models.Account, error)
+	DeleteAccount(userID, id uuid.UUID) error
+	GetAccountBalance(userID, id uuid.UUID) (decimal.Decimal, error)
+	ReconcileAccount(userID, id uuid.UUID) (*repositories.AccountReconciliation, error)
+	RecomputeBalance(userID, id uuid.UUID) (*BalanceRecomputeResult, error)
+	FindBalanceMismatches() ([]*repositories.AccountReconciliation, error)
+	GetBalanceHistory(userID, id uuid.UUID, from, to *time.Time, interval string) (*BalanceHistory, error)
+}
+
+// BalanceHistory is an account's balance at the end of every interval from
+// From to To, reconstructed from its opening balance and transactions.
+// Balanced reports whether the stored balance equals the opening balance
+// plus every transaction, in which case the history ends at the stored
+// balance once To is on or after the account's latest transaction.
+type BalanceHistory struct {
+	AccountID     uuid.UUID                           `json:"account_id"`
+	Currency      string                              `json:"currency"`
+	Interval      string                              `json:"interval" example:"month"`
+	From          time.Time                           `json:"from"`
+	To            time.Time                           `json:"to"`
+	StoredBalance decimal.Decimal                     `json:"stored_balance"`
+	Balanced      bool                                `json:"balanced"`
+	Points        []*repositories.BalanceHistoryPoint `json:"points"`
+}
+
+// CreditCardSettings holds the credit card terms of an account. Nil fields are
+// left unset on create and unchanged on update.
+type CreditCardSettings struct {
+	CreditLimit         *decimal.Decimal
+	StatementClosingDay *int
+	PaymentDueDay       *int
+	APR                 *decimal.Decimal
+}
+
+// BalanceRecomputeResult reports an account's balance before and after a recompute
+type BalanceRecomputeResult struct {
+	PreviousBalance decimal.Decimal                     `json:"previous_balance"`
+	Reconciliation  *repositories.AccountReconciliation `json:"reconciliation"`
+}
+
+// CreditCardService interface defines business logic for credit card statements
+type CreditCardService interface {
+	GetStatements(userID, accountID uuid.UUID) ([]*models.CreditCardStatement, error)
+	GenerateDue(asOf time.Time) (int, error)
 }
 
 // CategoryService interface defines business logic for category operations
 type CategoryService interface {
-	CreateCategory(name string, categoryType models.CategoryType, color string) (*models.Category, error)
-	GetCategoryByID(id uuid.UUID) (*models.Category, error)
-	GetAllCategories() ([]*models.Category, error)
-	GetCategoriesByType(categoryType models.CategoryType) ([]*models.Category, error)
-	UpdateCategory(id uuid.UUID, name, color string) (*models.Category, error)
-	DeleteCategory(id uuid.UUID) error
+	CreateCategory(userID uuid.UUID, name string, categoryType models.CategoryType, color string, parentID *uuid.UUID) (*models.Category, error)
+	GetCategoryByID(userID, id uuid.UUID) (*models.Category, error)
+	GetAllCategories(userID uuid.UUID) ([]*models.Category, error)
+	GetCategoriesByType(userID uuid.UUID, categoryType models.CategoryType) ([]*models.Category, error)
+	UpdateCategory(userID, id uuid.UUID, req CategoryUpdateRequest) (*models.Category, error)
+	DeleteCategory(userID, id uuid.UUID, reassignTo *uuid.UUID) (*CategoryMergeResult, error)
+	MergeCategory(userID, id, targetID uuid.UUID) (*CategoryMergeResult, error)
+}
+
+// TagService interface defines business logic for tag operations
+type TagService interface {
+	CreateTag(userID uuid.UUID, name, color string) (*models.Tag, error)
+	GetTagByID(userID, id uuid.UUID) (*models.Tag, error)
+	GetUserTags(userID uuid.UUID) ([]*models.Tag, error)
+	UpdateTag(userID, id uuid.UUID, name, color string) (*models.Tag, error)
+	DeleteTag(userID, id uuid.UUID) error
+	TagTransactions(userID, id uuid.UUID, transactionIDs []uuid.UUID) (int64, error)
+	UntagTransactions(userID, id uuid.UUID, transactionIDs []uuid.UUID) (int64, error)
+}
+
+// ExchangeRateRequest represents an exchange rate to load: one unit of
+// BaseCurrency costs Rate units of QuoteCurrency on Date
+type ExchangeRateRequest struct {
+	BaseCurrency  string          `json:"base_currency"`
+	QuoteCurrency string          `json:"quote_currency"`
+	Rate          decimal.Decimal `json:"rate"`
+	Date          time.Time       `json:"date"`
+}
+
+// ExchangeRateListRequest represents a request to list exchange rates
+type ExchangeRateListRequest struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	BaseCurrency  string     `json:"base_currency,omitempty"`
+	QuoteCurrency string     `json:"quote_currency,omitempty"`
+	StartDate     *time.Time `json:"start_date,omitempty"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	Limit         int        `json:"limit"`
+	Offset        int        `json:"offset"`
+}
+
+// ExchangeRateLoadResult reports how many rates a load stored. Rates already
+// stored for the same pair and date are replaced.
+type ExchangeRateLoadResult struct {
+	Loaded int `json:"loaded"`
+}
+
+// ExchangeRateService interface defines business logic for exchange rates
+type ExchangeRateService interface {
+	LoadRates(userID uuid.UUID, rates []ExchangeRateRequest) (*ExchangeRateLoadResult, error)
+	ImportRatesCSV(userID uuid.UUID, r io.Reader) (*ExchangeRateLoadResult, error)
+	GetRates(req ExchangeRateListRequest) ([]*models.ExchangeRate, error)
+	DeleteRate(userID, id uuid.UUID) error
+}
+
+// NetWorthService interface defines business logic for net worth and its history
+type NetWorthService interface {
+	GetNetWorth(userID uuid.UUID) (*NetWorth, error)
+	GetHistory(userID uuid.UUID, startDate, endDate *time.Time) ([]*models.NetWorthSnapshot, error)
+	TakeSnapshots(asOf time.Time) (int, error)
+}
+
+// NetWorth is what a user owns minus what they owe, over their active
+// accounts, in the user's currency. Liabilities are amounts owed, so they are
+// positive while the user is in debt; an overdrawn asset lowers Assets and an
+// overpaid liability lowers Liabilities, so NetWorth is the sum of all
+// converted balances.
+type NetWorth struct {
+	Date        time.Time                       `json:"date"`
+	Currency    string                          `json:"currency"`
+	Assets      decimal.Decimal                 `json:"assets"`
+	Liabilities decimal.Decimal                 `json:"liabilities"`
+	NetWorth    decimal.Decimal                 `json:"net_worth"`
+	Accounts    []*repositories.AccountNetWorth `json:"accounts"`
+
+	// Totals formatted for display in the requested locale
+	FormattedAssets      string `json:"formatted_assets,omitempty" example:"$15,000.00"`
+	FormattedLiabilities string `json:"formatted_liabilities,omitempty" example:"$2,654.33"`
+	FormattedNetWorth    string `json:"formatted_net_worth,omitempty" example:"$12,345.67"`
+}
+
+// CategoryUpdateRequest represents the fields of a category to change. A nil
+// ParentID keeps the current parent; RemoveParent makes the category top level.
+type CategoryUpdateRequest struct {
+	Name         string
+	Color        string
+	ParentID     *uuid.UUID
+	RemoveParent bool
 }
 
-// TransactionCreateRequest represents a request to create a transaction
+// CategoryMergeResult reports how many records moved from a deleted category
+// to the category that replaced it
+type CategoryMergeResult struct {
+	SourceID uuid.UUID                  `json:"source_id"`
+	TargetID uuid.UUID                  `json:"target_id"`
+	Moved    repositories.CategoryUsage `json:"moved"`
+}
+
+// TransactionSplitRequest represents one category allocation of a split transaction
+type TransactionSplitRequest struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Memo       string          `json:"memo"`
+}
+
+// TransactionCreateRequest represents a request to create a transaction. Split
+// transactions leave CategoryID empty and allocate the amount across Splits.
 type TransactionCreateRequest struct {
-	UserID      uuid.UUID       `json:"user_id"`
-	AccountID   uuid.UUID       `json:"account_id"`
-	CategoryID  uuid.UUID       `json:"category_id"`
-	Amount      decimal.Decimal `json:"amount"`
-	Description string          `json:"description"`
-	Date        time.Time       `json:"date"`
+	UserID      uuid.UUID                 `json:"user_id"`
+	AccountID   uuid.UUID                 `json:"account_id"`
+	CategoryID  uuid.UUID                 `json:"category_id"`
+	Amount      decimal.Decimal           `json:"amount"`
+	Description string                    `json:"description"`
+	Date        time.Time                 `json:"date"`
+	Splits      []TransactionSplitRequest `json:"splits,omitempty"`
+
+	// Set together on purchases made in a currency other than the account's
+	OriginalAmount   *decimal.Decimal `json:"original_amount,omitempty"`
+	OriginalCurrency *string          `json:"original_currency,omitempty"`
+
+	// Set when the transaction is generated from a recurring transaction
+	RecurringID    *uuid.UUID `json:"recurring_id,omitempty"`
+	OccurrenceDate *time.Time `json:"occurrence_date,omitempty"`
 }
 
-// TransactionUpdateRequest represents a request to update a transaction
+// TransactionUpdateRequest represents a request to update a transaction. A
+// non-nil Splits replaces all split lines; an empty slice removes them.
+// OriginalAmount and OriginalCurrency are set together; RemoveOriginal
+// clears them.
 type TransactionUpdateRequest struct {
-	AccountID   *uuid.UUID       `json:"account_id,omitempty"`
-	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
-	Amount      *decimal.Decimal `json:"amount,omitempty"`
-	Description *string          `json:"description,omitempty"`
-	Date        *time.Time       `json:"date,omitempty"`
+	AccountID        *uuid.UUID                 `json:"account_id,omitempty"`
+	CategoryID       *uuid.UUID                 `json:"category_id,omitempty"`
+	Amount           *decimal.Decimal           `json:"amount,omitempty"`
+	Description      *string                    `json:"description,omitempty"`
+	Date             *time.Time                 `json:"date,omitempty"`
+	Splits           *[]TransactionSplitRequest `json:"splits,omitempty"`
+	OriginalAmount   *decimal.Decimal           `json:"original_amount,omitempty"`
+	OriginalCurrency *string                    `json:"original_currency,omitempty"`
+	RemoveOriginal   bool                       `json:"remove_original,omitempty"`
 }
 
 // TransactionListRequest represents a request to list transactions
@@ -66,17 +231,302 @@ type TransactionListRequest struct {
 	EndDate    *time.Time       `json:"end_date,omitempty"`
 	MinAmount  *decimal.Decimal `json:"min_amount,omitempty"`
 	MaxAmount  *decimal.Decimal `json:"max_amount,omitempty"`
-	Limit      int              `json:"limit"`
-	Offset     int              `json:"offset"`
+	// Tags restricts the list to transactions with any (or, with
+	// TagMatchAll, all) of the named tags
+	Tags     []string              `json:"tags,omitempty"`
+	TagMatch repositories.TagMatch `json:"tag_match,omitempty"`
+	Limit    int                   `json:"limit"`
+	Offset   int                   `json:"offset"`
 }
 
 // TransactionService interface defines business logic for transaction operations
 type TransactionService interface {
 	CreateTransaction(req TransactionCreateRequest) (*models.Transaction, error)
-	GetTransactionByID(id uuid.UUID) (*models.Transaction, error)
+	GetTransactionByID(userID, id uuid.UUID) (*models.Transaction, error)
 	GetTransactions(req TransactionListRequest) ([]*models.Transaction, int64, error)
-	UpdateTransaction(id uuid.UUID, req TransactionUpdateRequest) (*models.Transaction, error)
-	DeleteTransaction(id uuid.UUID) error
-	GetTransactionSummary(userID uuid.UUID, startDate, endDate *time.Time) ([]*repositories.TransactionSummary, error)
-	GetMonthlyTotal(userID uuid.UUID, year int, month int) (decimal.Decimal, error)
+	ExportTransactions(req TransactionListRequest, format exporter.Format, w io.Writer) error
+	UpdateTransaction(userID, id uuid.UUID, req TransactionUpdateRequest) (*models.Transaction, error)
+	DeleteTransaction(userID, id uuid.UUID) error
+	GetTransactionSummary(userID uuid.UUID, startDate, endDate *time.Time) (*CategorySummaryReport, error)
+	GetTagSummary(userID uuid.UUID, startDate, endDate *time.Time) (*TagSummaryReport, error)
+	GetMonthlyTotal(userID uuid.UUID, year int, month int) (*MonthlyTotalReport, error)
+}
+
+// CategorySummaryReport is the spending summary by category in the user's
+// currency. Conversions lists the exchange rates used to convert amounts
+// booked in other currencies.
+type CategorySummaryReport struct {
+	Currency    string                             `json:"currency"`
+	Categories  []*repositories.TransactionSummary `json:"categories"`
+	Conversions []*repositories.CurrencyConversion `json:"conversions"`
+}
+
+// TagSummaryReport is the summary by tag in the user's currency, with the
+// exchange rates used
+type TagSummaryReport struct {
+	Currency    string                             `json:"currency"`
+	Tags        []*repositories.TagSummary         `json:"tags"`
+	Conversions []*repositories.CurrencyConversion `json:"conversions"`
+}
+
+// MonthlyTotalReport is a month's net total in the user's currency, with the
+// exchange rates used
+type MonthlyTotalReport struct {
+	Currency       string                             `json:"currency"`
+	Total          decimal.Decimal                    `json:"total"`
+	FormattedTotal string                             `json:"formatted_total,omitempty"`
+	Conversions    []*repositories.CurrencyConversion `json:"conversions"`
+}
+
+// TransferCreateRequest represents a request to move money between two
+// accounts. Amount leaves the source account; ToAmount, required between
+// accounts in different currencies, arrives in the destination account.
+type TransferCreateRequest struct {
+	UserID        uuid.UUID        `json:"user_id"`
+	FromAccountID uuid.UUID        `json:"from_account_id"`
+	ToAccountID   uuid.UUID        `json:"to_account_id"`
+	Amount        decimal.Decimal  `json:"amount"`
+	ToAmount      *decimal.Decimal `json:"to_amount,omitempty"`
+	Description   string           `json:"description"`
+	Date          time.Time        `json:"date"`
+}
+
+// Transfer represents an account-to-account transfer and its two linked legs.
+// Amount is what left the source account and ToAmount what arrived; they
+// only differ between accounts in different currencies.
+type Transfer struct {
+	ID          uuid.UUID           `json:"id"`
+	Amount      decimal.Decimal     `json:"amount"`
+	ToAmount    decimal.Decimal     `json:"to_amount"`
+	Description string              `json:"description"`
+	Date        time.Time           `json:"date"`
+	From        *models.Transaction `json:"from"`
+	To          *models.Transaction `json:"to"`
+
+	// Amount and ToAmount formatted for display in the requested locale
+	FormattedAmount   string `json:"formatted_amount,omitempty"`
+	FormattedToAmount string `json:"formatted_to_amount,omitempty"`
+}
+
+// TransferService interface defines business logic for account-to-account transfers
+type TransferService interface {
+	CreateTransfer(req TransferCreateRequest) (*Transfer, error)
+	GetTransfer(userID, id uuid.UUID) (*Transfer, error)
+	DeleteTransfer(userID, id uuid.UUID) error
+}
+
+// BudgetCreateRequest represents a request to create a budget. StartDate
+// defaults to the current period.
+type BudgetCreateRequest struct {
+	UserID      uuid.UUID           `json:"user_id"`
+	Name        string              `json:"name"`
+	CategoryIDs []uuid.UUID         `json:"category_ids"`
+	Amount      decimal.Decimal     `json:"amount"`
+	Period      models.BudgetPeriod `json:"period"`
+	Rollover    bool                `json:"rollover"`
+	StartDate   *time.Time          `json:"start_date,omitempty"`
+}
+
+// BudgetUpdateRequest represents a request to update a budget. A non-nil
+// CategoryIDs replaces the budget's categories.
+type BudgetUpdateRequest struct {
+	Name        *string              `json:"name,omitempty"`
+	CategoryIDs *[]uuid.UUID         `json:"category_ids,omitempty"`
+	Amount      *decimal.Decimal     `json:"amount,omitempty"`
+	Period      *models.BudgetPeriod `json:"period,omitempty"`
+	Rollover    *bool                `json:"rollover,omitempty"`
+	StartDate   *time.Time           `json:"start_date,omitempty"`
+}
+
+// BudgetPeriodStatus reports spending against a budget for one period.
+// Budgeted is the budget amount plus any amount rolled over from the
+// previous period.
+type BudgetPeriodStatus struct {
+	StartDate   time.Time       `json:"start_date"`
+	EndDate     time.Time       `json:"end_date"`
+	Budgeted    decimal.Decimal `json:"budgeted"`
+	Rollover    decimal.Decimal `json:"rollover"`
+	Spent       decimal.Decimal `json:"spent"`
+	Remaining   decimal.Decimal `json:"remaining"`
+	PercentUsed decimal.Decimal `json:"percent_used"`
+	Overspent   bool            `json:"overspent"`
+}
+
+// BudgetStatus reports a budget's spending for its most recent periods
+type BudgetStatus struct {
+	Budget  *models.Budget       `json:"budget"`
+	Periods []BudgetPeriodStatus `json:"periods"`
+}
+
+// BudgetService interface defines business logic for budget operations
+type BudgetService interface {
+	CreateBudget(req BudgetCreateRequest) (*models.Budget, error)
+	GetBudget(userID, id uuid.UUID) (*models.Budget, error)
+	GetUserBudgets(userID uuid.UUID) ([]*models.Budget, error)
+	UpdateBudget(userID, id uuid.UUID, req BudgetUpdateRequest) (*models.Budget, error)
+	DeleteBudget(userID, id uuid.UUID) error
+	GetBudgetStatus(userID uuid.UUID, periods int) ([]*BudgetStatus, error)
+}
+
+// RecurringCreateRequest represents a request to create a recurring transaction
+type RecurringCreateRequest struct {
+	UserID      uuid.UUID       `json:"user_id"`
+	AccountID   uuid.UUID       `json:"account_id"`
+	CategoryID  uuid.UUID       `json:"category_id"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+	RRule       string          `json:"rrule"`
+	StartDate   time.Time       `json:"start_date"`
+}
+
+// RecurringUpdateRequest represents a request to update a recurring transaction
+type RecurringUpdateRequest struct {
+	AccountID   *uuid.UUID       `json:"account_id,omitempty"`
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
+	Amount      *decimal.Decimal `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	RRule       *string          `json:"rrule,omitempty"`
+	StartDate   *time.Time       `json:"start_date,omitempty"`
+	IsActive    *bool            `json:"is_active,omitempty"`
+}
+
+// OccurrenceModifyRequest changes what a single occurrence generates; nil
+// fields keep the recurring transaction's values
+type OccurrenceModifyRequest struct {
+	Amount      *decimal.Decimal `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
+	Date        *time.Time       `json:"date,omitempty"`
+}
+
+// Occurrence describes the transaction an upcoming occurrence will generate
+type Occurrence struct {
+	OccurrenceDate time.Time       `json:"occurrence_date"`
+	Date           time.Time       `json:"date"`
+	Amount         decimal.Decimal `json:"amount"`
+	Description    string          `json:"description"`
+	CategoryID     uuid.UUID       `json:"category_id"`
+	Skipped        bool            `json:"skipped"`
+	Modified       bool            `json:"modified"`
+}
+
+// RecurringService interface defines business logic for recurring transactions
+type RecurringService interface {
+	CreateRecurring(req RecurringCreateRequest) (*models.RecurringTransaction, error)
+	GetRecurring(userID, id uuid.UUID) (*models.RecurringTransaction, error)
+	GetUserRecurring(userID uuid.UUID) ([]*models.RecurringTransaction, error)
+	UpdateRecurring(userID, id uuid.UUID, req RecurringUpdateRequest) (*models.RecurringTransaction, error)
+	DeleteRecurring(userID, id uuid.UUID) error
+	PreviewOccurrences(userID, id uuid.UUID, count int) ([]Occurrence, error)
+	SkipOccurrence(userID, id uuid.UUID, occurrenceDate time.Time) error
+	ModifyOccurrence(userID, id uuid.UUID, occurrenceDate time.Time, req OccurrenceModifyRequest) (*models.RecurringException, error)
+	RestoreOccurrence(userID, id uuid.UUID, occurrenceDate time.Time) error
+	GenerateDue(asOf time.Time) (int, error)
+}
+
+// ImportProfileRequest represents a request to create or replace a CSV import
+// profile. Empty Delimiter, DecimalSeparator and SignConvention use the defaults
+// ",", "." and negative_is_debit.
+type ImportProfileRequest struct {
+	UserID            uuid.UUID `json:"user_id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"has_header"`
+	SkipRows          int       `json:"skip_rows"`
+	DateColumn        string    `json:"date_column"`
+	AmountColumn      string    `json:"amount_column"`
+	DebitColumn       string    `json:"debit_column"`
+	CreditColumn      string    `json:"credit_column"`
+	DescriptionColumn string    `json:"description_column"`
+	ExternalIDColumn  string    `json:"external_id_column"`
+	DateFormat        string    `json:"date_format"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	SignConvention    string    `json:"sign_convention"`
+	DefaultCategoryID uuid.UUID `json:"default_category_id"`
+}
+
+// CSVImportRequest represents a request to import a CSV statement into an
+// account. CategoryID overrides the profile's default category. Without
+// Commit the rows are only previewed.
+type CSVImportRequest struct {
+	UserID     uuid.UUID
+	AccountID  uuid.UUID
+	ProfileID  uuid.UUID
+	CategoryID *uuid.UUID
+	File       io.Reader
+	Commit     bool
+}
+
+// StatementImportRequest represents a request to import a self-describing
+// statement file such as OFX into an account. Without Commit the rows are only
+// previewed.
+type StatementImportRequest struct {
+	UserID     uuid.UUID
+	AccountID  uuid.UUID
+	CategoryID uuid.UUID
+	File       io.Reader
+	Commit     bool
+}
+
+// ImportRow is a parsed statement line and whether it already exists on the account
+type ImportRow struct {
+	importer.Row
+	Duplicate     bool       `json:"duplicate"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+}
+
+// ImportResult reports the outcome of a statement preview or commit.
+// Duplicate rows are never imported.
+type ImportResult struct {
+	Rows           []ImportRow         `json:"rows"`
+	Errors         []importer.RowError `json:"errors"`
+	TotalRows      int                 `json:"total_rows"`
+	NewRows        int                 `json:"new_rows"`
+	DuplicateRows  int                 `json:"duplicate_rows"`
+	NetAmount      decimal.Decimal     `json:"net_amount"`
+	OpeningBalance *decimal.Decimal    `json:"opening_balance,omitempty"`
+	ClosingBalance *decimal.Decimal    `json:"closing_balance,omitempty"`
+	Reconciliation *Reconciliation     `json:"reconciliation,omitempty"`
+	Committed      bool                `json:"committed"`
+}
+
+// Reconciliation compares a statement's balances with the account. The
+// closing balance should match the balance the import leaves behind, and the
+// opening balance should match that balance less every statement row. A
+// non-zero difference usually means transactions are missing on one side.
+type Reconciliation struct {
+	AccountBalance         decimal.Decimal  `json:"account_balance"`
+	ProjectedBalance       decimal.Decimal  `json:"projected_balance"`
+	OpeningBalance         *decimal.Decimal `json:"opening_balance,omitempty"`
+	ExpectedOpeningBalance *decimal.Decimal `json:"expected_opening_balance,omitempty"`
+	OpeningDifference      *decimal.Decimal `json:"opening_difference,omitempty"`
+	ClosingBalance         *decimal.Decimal `json:"closing_balance,omitempty"`
+	BalanceDate            *time.Time       `json:"balance_date,omitempty"`
+	ClosingDifference      *decimal.Decimal `json:"closing_difference,omitempty"`
+	Reconciled             bool             `json:"reconciled"`
+}
+
+// ImportService interface defines business logic for importing bank statements
+type ImportService interface {
+	CreateProfile(req ImportProfileRequest) (*models.ImportProfile, error)
+	GetProfile(userID, id uuid.UUID) (*models.ImportProfile, error)
+	GetUserProfiles(userID uuid.UUID) ([]*models.ImportProfile, error)
+	UpdateProfile(userID, id uuid.UUID, req ImportProfileRequest) (*models.ImportProfile, error)
+	DeleteProfile(userID, id uuid.UUID) error
+	ImportCSV(req CSVImportRequest) (*ImportResult, error)
+	ImportOFX(req StatementImportRequest) (*ImportResult, error)
+	ImportCAMT053(req StatementImportRequest) (*ImportResult, error)
+	ImportMT940(req StatementImportRequest) (*ImportResult, error)
+}
+
+// ExportService interface defines business logic for plain-text accounting exports
+type ExportService interface {
+	ExportJournal(userID uuid.UUID, format exporter.JournalFormat, w io.Writer) error
+}
+
+// BackupService interface defines business logic for backing up and restoring
+// a user's data
+type BackupService interface {
+	Backup(userID uuid.UUID, w io.Writer) error
+	Restore(r io.Reader, password string, preserveIDs bool) (*models.User, error)
 }
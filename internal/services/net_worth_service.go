@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+type netWorthService struct {
+	netWorthRepo repositories.NetWorthRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewNetWorthService creates a new net worth service
+func NewNetWorthService(netWorthRepo repositories.NetWorthRepository, userRepo repositories.UserRepository) NetWorthService {
+	return &netWorthService{
+		netWorthRepo: netWorthRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GetNetWorth computes a user's current net worth
+func (s *netWorthService) GetNetWorth(userID uuid.UUID) (*NetWorth, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.compute(user, time.Now())
+}
+
+// GetHistory retrieves a user's daily net worth snapshots in the date range,
+// oldest first
+func (s *netWorthService) GetHistory(userID uuid.UUID, startDate, endDate *time.Time) ([]*models.NetWorthSnapshot, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+
+	exists, err := s.userRepo.Exists(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, repositories.ErrUserNotFound
+	}
+
+	return s.netWorthRepo.GetSnapshots(userID, startDate, endDate)
+}
+
+// TakeSnapshots records the net worth on the day of asOf of every user with
+// an active account, replacing snapshots already taken that day, and returns
+// how many were recorded
+func (s *netWorthService) TakeSnapshots(asOf time.Time) (int, error) {
+	users, err := s.netWorthRepo.GetSnapshotUsers()
+	if err != nil {
+		return 0, err
+	}
+
+	taken := 0
+	var errs []error
+	for _, user := range users {
+		netWorth, err := s.compute(user, asOf)
+		if err == nil {
+			err = s.netWorthRepo.UpsertSnapshot(&models.NetWorthSnapshot{
+				UserID:      user.ID,
+				Date:        netWorth.Date,
+				Currency:    netWorth.Currency,
+				Assets:      netWorth.Assets,
+				Liabilities: netWorth.Liabilities,
+				NetWorth:    netWorth.NetWorth,
+			})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.ID, err))
+			continue
+		}
+		taken++
+	}
+
+	return taken, errors.Join(errs...)
+}
+
+// compute sums the user's active account balances on the day of asOf, in the
+// user's currency, by account class. Accounts in another currency need an
+// exchange rate on or before that day.
+func (s *netWorthService) compute(user *models.User, asOf time.Time) (*NetWorth, error) {
+	date := dateOnly(asOf)
+	balances, err := s.netWorthRepo.GetAccountBalances(user.ID, user.Currency, date)
+	if err != nil {
+		return nil, err
+	}
+
+	netWorth := &NetWorth{
+		Date:        date,
+		Currency:    user.Currency,
+		Assets:      decimal.Zero,
+		Liabilities: decimal.Zero,
+		Accounts:    balances,
+	}
+	for _, balance := range balances {
+		if balance.ConvertedBalance == nil {
+			return nil, fmt.Errorf("%w: no %s to %s rate on or before %s, needed for account %q",
+				ErrExchangeRateMissing, balance.Currency, user.Currency, date.Format("2006-01-02"), balance.AccountName)
+		}
+
+		balance.Class = balance.Type.Class()
+		if balance.Class == models.AccountClassLiability {
+			netWorth.Liabilities = netWorth.Liabilities.Sub(*balance.ConvertedBalance)
+		} else {
+			netWorth.Assets = netWorth.Assets.Add(*balance.ConvertedBalance)
+		}
+	}
+	netWorth.NetWorth = netWorth.Assets.Sub(netWorth.Liabilities)
+
+	return netWorth, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// ownershipFixture sets up two users, each with an account, a category and
+// one transaction
+type ownershipFixture struct {
+	store        *memoryStore
+	alice, bob   *models.User
+	aliceAccount *models.Account
+	aliceTx      *models.Transaction
+	bobCategory  *models.Category
+	transactions TransactionService
+}
+
+func newOwnershipFixture(t *testing.T) *ownershipFixture {
+	t.Helper()
+	store := newMemoryStore()
+	repos := store.repositories()
+	f := &ownershipFixture{store: store}
+
+	var aliceCategory *models.Category
+	f.alice, f.aliceAccount, aliceCategory = store.addUser("USD")
+	var bobAccount *models.Account
+	f.bob, bobAccount, f.bobCategory = store.addUser("USD")
+
+	f.transactions = NewTransactionService(&memoryUnitOfWork{store: store},
+		repos.Transactions, repos.Accounts, repos.Categories, repos.Users)
+
+	var err error
+	f.aliceTx, err = f.transactions.CreateTransaction(TransactionCreateRequest{
+		UserID: f.alice.ID, AccountID: f.aliceAccount.ID, CategoryID: aliceCategory.ID,
+		Amount: decimal.NewFromInt(-40), Description: "Groceries", Date: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("create alice transaction: %v", err)
+	}
+	_, err = f.transactions.CreateTransaction(TransactionCreateRequest{
+		UserID: f.bob.ID, AccountID: bobAccount.ID, CategoryID: f.bobCategory.ID,
+		Amount: decimal.NewFromInt(-7), Description: "Coffee", Date: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("create bob transaction: %v", err)
+	}
+	return f
+}
+
+func TestAccountOwnership(t *testing.T) {
+	f := newOwnershipFixture(t)
+	repos := f.store.repositories()
+	accounts := NewAccountService(repos.Accounts, repos.Users)
+	cards := NewCreditCardService(repos.Accounts, nil)
+	id := f.aliceAccount.ID
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get", func() error { _, err := accounts.GetAccountByID(f.bob.ID, id); return err }},
+		{"update", func() error {
+			_, err := accounts.UpdateAccount(f.bob.ID, id, "Stolen", "", nil, CreditCardSettings{})
+			return err
+		}},
+		{"delete", func() error { return accounts.DeleteAccount(f.bob.ID, id) }},
+		{"balance", func() error { _, err := accounts.GetAccountBalance(f.bob.ID, id); return err }},
+		{"reconcile", func() error { _, err := accounts.ReconcileAccount(f.bob.ID, id); return err }},
+		{"recompute", func() error { _, err := accounts.RecomputeBalance(f.bob.ID, id); return err }},
+		{"balance history", func() error {
+			_, err := accounts.GetBalanceHistory(f.bob.ID, id, nil, nil, "")
+			return err
+		}},
+		{"statements", func() error { _, err := cards.GetStatements(f.bob.ID, id); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, repositories.ErrAccountNotFound) {
+				t.Fatalf("got error %v, want %v", err, repositories.ErrAccountNotFound)
+			}
+		})
+	}
+
+	if account := f.store.account(id); account.Name != "Checking" {
+		t.Errorf("account was changed by another user: name %q", account.Name)
+	}
+}
+
+func TestTransactionOwnership(t *testing.T) {
+	f := newOwnershipFixture(t)
+	id := f.aliceTx.ID
+	description := "Stolen"
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"get", func() error { _, err := f.transactions.GetTransactionByID(f.bob.ID, id); return err },
+			repositories.ErrTransactionNotFound},
+		{"update", func() error {
+			_, err := f.transactions.UpdateTransaction(f.bob.ID, id, TransactionUpdateRequest{Description: &description})
+			return err
+		}, repositories.ErrTransactionNotFound},
+		{"delete", func() error { return f.transactions.DeleteTransaction(f.bob.ID, id) },
+			repositories.ErrTransactionNotFound},
+		{"create in another user's account", func() error {
+			_, err := f.transactions.CreateTransaction(TransactionCreateRequest{
+				UserID: f.bob.ID, AccountID: f.aliceAccount.ID, CategoryID: f.bobCategory.ID,
+				Amount: decimal.NewFromInt(-1), Description: "Sneaky", Date: time.Now(),
+			})
+			return err
+		}, repositories.ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	transaction, err := f.transactions.GetTransactionByID(f.alice.ID, id)
+	if err != nil {
+		t.Fatalf("owner lost access: %v", err)
+	}
+	if transaction.Description != "Groceries" {
+		t.Errorf("transaction was changed by another user: description %q", transaction.Description)
+	}
+	if balance := f.store.account(f.aliceAccount.ID).Balance; !balance.Equal(decimal.NewFromInt(-40)) {
+		t.Errorf("balance = %s, want -40", balance)
+	}
+}
+
+func TestTransactionSummaryOwnership(t *testing.T) {
+	f := newOwnershipFixture(t)
+
+	report, err := f.transactions.GetTransactionSummary(f.bob.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if len(report.Categories) != 1 || report.Categories[0].CategoryID != f.bobCategory.ID {
+		t.Fatalf("summary includes other users' categories: %+v", report.Categories)
+	}
+	if total := report.Categories[0].TotalAmount; !total.Equal(decimal.NewFromInt(-7)) {
+		t.Errorf("total = %s, want -7", total)
+	}
+}
+
+func TestUserOwnership(t *testing.T) {
+	f := newOwnershipFixture(t)
+	users := NewUserService(&memoryUnitOfWork{store: f.store}, f.store.repositories().Users)
+	id := f.alice.ID
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get", func() error { _, err := users.GetUserByID(f.bob.ID, id); return err }},
+		{"update", func() error { _, err := users.UpdateUser(f.bob.ID, id, "Mallory", ""); return err }},
+		{"delete", func() error { return users.DeleteUser(f.bob.ID, id) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, repositories.ErrUserNotFound) {
+				t.Fatalf("got error %v, want %v", err, repositories.ErrUserNotFound)
+			}
+		})
+	}
+
+	user, err := users.GetUserByID(id, id)
+	if err != nil {
+		t.Fatalf("owner lost access: %v", err)
+	}
+	if user.Name != "Test" {
+		t.Errorf("user was changed by another user: name %q", user.Name)
+	}
+}
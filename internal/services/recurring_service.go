@@ -0,0 +1,520 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/recurrence"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+const (
+	defaultPreviewCount = 10
+	maxPreviewCount     = 100
+)
+
+type recurringService struct {
+	recurringRepo      repositories.RecurringRepository
+	accountRepo        repositories.AccountRepository
+	categoryRepo       repositories.CategoryRepository
+	transactionService TransactionService
+}
+
+// NewRecurringService creates a new recurring transaction service. Occurrences
+// are materialized through the transaction service so they follow the same
+// validation and balance rules as manually entered transactions.
+func NewRecurringService(
+	recurringRepo repositories.RecurringRepository,
+	accountRepo repositories.AccountRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactionService TransactionService,
+) RecurringService {
+	return &recurringService{
+		recurringRepo:      recurringRepo,
+		accountRepo:        accountRepo,
+		categoryRepo:       categoryRepo,
+		transactionService: transactionService,
+	}
+}
+
+// CreateRecurring creates a recurring transaction. A start date in the past
+// makes the worker backfill the occurrences since then.
+func (s *recurringService) CreateRecurring(req RecurringCreateRequest) (*models.RecurringTransaction, error) {
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		return nil, errors.New("description cannot be empty")
+	}
+	if req.StartDate.IsZero() {
+		return nil, errors.New("start date is required")
+	}
+
+	rule, err := recurrence.Parse(req.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ownedAccount(s.accountRepo, req.UserID, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(req.UserID, req.CategoryID); err != nil {
+		return nil, err
+	}
+
+	amount := roundAmount(req.Amount, account.Currency)
+	if amount.IsZero() {
+		return nil, errors.New("amount cannot be zero")
+	}
+
+	recurring := &models.RecurringTransaction{
+		UserID:      req.UserID,
+		AccountID:   req.AccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      amount,
+		Description: strings.TrimSpace(req.Description),
+		RRule:       rule.String(),
+		StartDate:   dateOnly(req.StartDate),
+		IsActive:    true,
+	}
+	recurring.NextOccurrence = firstOccurrence(rule, recurring.StartDate, recurring.StartDate)
+
+	if err := s.recurringRepo.Create(recurring); err != nil {
+		return nil, err
+	}
+
+	return s.recurringRepo.GetByID(recurring.ID)
+}
+
+// GetRecurring retrieves a recurring transaction if it belongs to the user
+func (s *recurringService) GetRecurring(userID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	return s.getOwnedRecurring(userID, id)
+}
+
+// GetUserRecurring retrieves all recurring transactions for a user
+func (s *recurringService) GetUserRecurring(userID uuid.UUID) ([]*models.RecurringTransaction, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.recurringRepo.GetByUserID(userID)
+}
+
+// UpdateRecurring updates a recurring transaction. Changing the schedule never
+// regenerates occurrences that were already materialized, and reactivating a
+// paused schedule resumes from today instead of catching up.
+func (s *recurringService) UpdateRecurring(userID, id uuid.UUID, req RecurringUpdateRequest) (*models.RecurringTransaction, error) {
+	recurring, err := s.getOwnedRecurring(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := recurring.Account.Currency
+	if req.AccountID != nil {
+		account, err := ownedAccount(s.accountRepo, userID, *req.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		recurring.AccountID = *req.AccountID
+		currency = account.Currency
+	}
+
+	if req.CategoryID != nil {
+		if err := s.checkCategory(userID, *req.CategoryID); err != nil {
+			return nil, err
+		}
+		recurring.CategoryID = *req.CategoryID
+	}
+
+	if req.Amount != nil {
+		amount := roundAmount(*req.Amount, currency)
+		if amount.IsZero() {
+			return nil, errors.New("amount cannot be zero")
+		}
+		recurring.Amount = amount
+	}
+
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		if description == "" {
+			return nil, errors.New("description cannot be empty")
+		}
+		recurring.Description = description
+	}
+
+	reschedule := false
+	today := dateOnly(time.Now())
+	from := today
+	if recurring.NextOccurrence != nil {
+		from = *recurring.NextOccurrence
+	}
+
+	if req.RRule != nil {
+		rule, err := recurrence.Parse(*req.RRule)
+		if err != nil {
+			return nil, err
+		}
+		recurring.RRule = rule.String()
+		reschedule = true
+	}
+
+	if req.StartDate != nil {
+		recurring.StartDate = dateOnly(*req.StartDate)
+		reschedule = true
+	}
+
+	if req.IsActive != nil {
+		if *req.IsActive && !recurring.IsActive {
+			from = today
+			reschedule = true
+		}
+		recurring.IsActive = *req.IsActive
+	}
+
+	if reschedule {
+		rule, err := recurrence.Parse(recurring.RRule)
+		if err != nil {
+			return nil, err
+		}
+		start := dateOnly(recurring.StartDate)
+		if from.Before(start) {
+			from = start
+		}
+		recurring.NextOccurrence = firstOccurrence(rule, start, from)
+	}
+
+	if err := s.recurringRepo.Update(recurring); err != nil {
+		return nil, err
+	}
+
+	return s.recurringRepo.GetByID(recurring.ID)
+}
+
+// DeleteRecurring deletes a recurring transaction. Transactions it already
+// generated are kept.
+func (s *recurringService) DeleteRecurring(userID, id uuid.UUID) error {
+	if _, err := s.getOwnedRecurring(userID, id); err != nil {
+		return err
+	}
+
+	return s.recurringRepo.Delete(id)
+}
+
+// PreviewOccurrences lists the next occurrences that have not been generated
+// yet, with skip and modify exceptions applied
+func (s *recurringService) PreviewOccurrences(userID, id uuid.UUID, count int) ([]Occurrence, error) {
+	if count == 0 {
+		count = defaultPreviewCount
+	}
+	if count < 1 || count > maxPreviewCount {
+		return nil, errors.New("count must be between 1 and 100")
+	}
+
+	recurring, err := s.getOwnedRecurring(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []Occurrence{}
+	if recurring.NextOccurrence == nil {
+		return occurrences, nil
+	}
+
+	rule, err := recurrence.Parse(recurring.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions := exceptionsByDate(recurring.Exceptions)
+	it := rule.Iterator(dateOnly(recurring.StartDate))
+	for date, ok := it.Next(); ok && len(occurrences) < count; date, ok = it.Next() {
+		if date.Before(*recurring.NextOccurrence) {
+			continue
+		}
+		occurrences = append(occurrences, newOccurrence(recurring, date, exceptions[date.Unix()]))
+	}
+
+	return occurrences, nil
+}
+
+// SkipOccurrence prevents a single upcoming occurrence from being generated
+func (s *recurringService) SkipOccurrence(userID, id uuid.UUID, occurrenceDate time.Time) error {
+	recurring, date, err := s.getUpcomingOccurrence(userID, id, occurrenceDate)
+	if err != nil {
+		return err
+	}
+
+	return s.recurringRepo.SaveException(&models.RecurringException{
+		RecurringID:    recurring.ID,
+		OccurrenceDate: date,
+		Action:         models.RecurringExceptionSkip,
+	})
+}
+
+// ModifyOccurrence changes what a single upcoming occurrence generates
+func (s *recurringService) ModifyOccurrence(userID, id uuid.UUID, occurrenceDate time.Time, req OccurrenceModifyRequest) (*models.RecurringException, error) {
+	recurring, date, err := s.getUpcomingOccurrence(userID, id, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount == nil && req.Description == nil && req.CategoryID == nil && req.Date == nil {
+		return nil, errors.New("at least one field must be modified")
+	}
+	if req.Amount != nil {
+		amount := roundAmount(*req.Amount, recurring.Account.Currency)
+		if amount.IsZero() {
+			return nil, errors.New("amount cannot be zero")
+		}
+		req.Amount = &amount
+	}
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		if description == "" {
+			return nil, errors.New("description cannot be empty")
+		}
+		req.Description = &description
+	}
+	if req.CategoryID != nil {
+		if err := s.checkCategory(userID, *req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Date != nil {
+		moved := dateOnly(*req.Date)
+		req.Date = &moved
+	}
+
+	exception := &models.RecurringException{
+		RecurringID:    recurring.ID,
+		OccurrenceDate: date,
+		Action:         models.RecurringExceptionModify,
+		Amount:         req.Amount,
+		Description:    req.Description,
+		CategoryID:     req.CategoryID,
+		Date:           req.Date,
+	}
+	if err := s.recurringRepo.SaveException(exception); err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// RestoreOccurrence removes any skip or modify exception from an upcoming occurrence
+func (s *recurringService) RestoreOccurrence(userID, id uuid.UUID, occurrenceDate time.Time) error {
+	recurring, date, err := s.getUpcomingOccurrence(userID, id, occurrenceDate)
+	if err != nil {
+		return err
+	}
+
+	return s.recurringRepo.DeleteException(recurring.ID, date)
+}
+
+// GenerateDue materializes every occurrence on or before asOf across all
+// active recurring transactions and returns how many transactions were created.
+// Progress is recorded after each occurrence, and the unique index on
+// (recurring_id, occurrence_date) turns a repeated attempt into a no-op, so an
+// occurrence is generated exactly once even if the worker is interrupted.
+func (s *recurringService) GenerateDue(asOf time.Time) (int, error) {
+	today := dateOnly(asOf)
+
+	due, err := s.recurringRepo.GetDue(today)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	var errs []error
+	for _, recurring := range due {
+		n, err := s.generate(recurring, today)
+		generated += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurring transaction %s: %w", recurring.ID, err))
+		}
+	}
+
+	return generated, errors.Join(errs...)
+}
+
+// generate creates the transactions for one recurring transaction's due
+// occurrences. An occurrence that can never be generated, such as one whose
+// category is gone, is reported and skipped so that it cannot stall the rest
+// of the schedule. A transient database failure stops before the schedule
+// advances, so the next run retries the occurrence.
+func (s *recurringService) generate(recurring *models.RecurringTransaction, today time.Time) (int, error) {
+	rule, err := recurrence.Parse(recurring.RRule)
+	if err != nil {
+		return 0, err
+	}
+
+	exceptions := exceptionsByDate(recurring.Exceptions)
+	it := rule.Iterator(dateOnly(recurring.StartDate))
+
+	date, ok := it.Next()
+	for ok && date.Before(*recurring.NextOccurrence) {
+		date, ok = it.Next()
+	}
+
+	generated := 0
+	var failures []error
+	for ok && !date.After(today) {
+		occurrence := newOccurrence(recurring, date, exceptions[date.Unix()])
+		if !occurrence.Skipped {
+			occurrenceDate := date
+			_, err := s.transactionService.CreateTransaction(TransactionCreateRequest{
+				UserID:         recurring.UserID,
+				AccountID:      recurring.AccountID,
+				CategoryID:     occurrence.CategoryID,
+				Amount:         occurrence.Amount,
+				Description:    occurrence.Description,
+				Date:           occurrence.Date,
+				RecurringID:    &recurring.ID,
+				OccurrenceDate: &occurrenceDate,
+			})
+			switch {
+			case err == nil:
+				generated++
+			case errors.Is(err, repositories.ErrDuplicateTransaction):
+				// Generated by an earlier run that stopped before advancing the schedule
+			case repositories.IsTransient(err):
+				failures = append(failures, fmt.Errorf("occurrence %s: %w", occurrenceDate.Format("2006-01-02"), err))
+				return generated, errors.Join(failures...)
+			default:
+				failures = append(failures, fmt.Errorf("occurrence %s: %w", occurrenceDate.Format("2006-01-02"), err))
+			}
+		}
+
+		var next *time.Time
+		if date, ok = it.Next(); ok {
+			nextDate := date
+			next = &nextDate
+		}
+		if err := s.recurringRepo.UpdateNextOccurrence(recurring.ID, next); err != nil {
+			return generated, errors.Join(append(failures, err)...)
+		}
+	}
+
+	return generated, errors.Join(failures...)
+}
+
+// getUpcomingOccurrence verifies that the date is an occurrence of the schedule
+// that has not been generated yet
+func (s *recurringService) getUpcomingOccurrence(userID, id uuid.UUID, occurrenceDate time.Time) (*models.RecurringTransaction, time.Time, error) {
+	recurring, err := s.getOwnedRecurring(userID, id)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	rule, err := recurrence.Parse(recurring.RRule)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	date := dateOnly(occurrenceDate)
+	if recurring.NextOccurrence == nil || date.Before(*recurring.NextOccurrence) {
+		return nil, time.Time{}, errors.New("occurrence has already been generated or the schedule has ended")
+	}
+
+	if first := firstOccurrence(rule, dateOnly(recurring.StartDate), date); first == nil || !first.Equal(date) {
+		return nil, time.Time{}, errors.New("date is not an occurrence of this schedule")
+	}
+
+	return recurring, date, nil
+}
+
+// getOwnedRecurring retrieves a recurring transaction, treating those owned by
+// other users as missing
+func (s *recurringService) getOwnedRecurring(userID, id uuid.UUID) (*models.RecurringTransaction, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid recurring transaction ID")
+	}
+
+	recurring, err := s.recurringRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if recurring.UserID != userID {
+		return nil, repositories.ErrRecurringNotFound
+	}
+
+	return recurring, nil
+}
+
+// checkCategory verifies the category exists and belongs to the user
+func (s *recurringService) checkCategory(userID, categoryID uuid.UUID) error {
+	exists, err := s.categoryRepo.Exists(userID, categoryID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repositories.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// newOccurrence describes what an occurrence generates after applying its exception
+func newOccurrence(recurring *models.RecurringTransaction, date time.Time, exception *models.RecurringException) Occurrence {
+	occurrence := Occurrence{
+		OccurrenceDate: date,
+		Date:           date,
+		Amount:         recurring.Amount,
+		Description:    recurring.Description,
+		CategoryID:     recurring.CategoryID,
+	}
+	if exception == nil {
+		return occurrence
+	}
+
+	switch exception.Action {
+	case models.RecurringExceptionSkip:
+		occurrence.Skipped = true
+	case models.RecurringExceptionModify:
+		occurrence.Modified = true
+		if exception.Amount != nil {
+			occurrence.Amount = *exception.Amount
+		}
+		if exception.Description != nil {
+			occurrence.Description = *exception.Description
+		}
+		if exception.CategoryID != nil {
+			occurrence.CategoryID = *exception.CategoryID
+		}
+		if exception.Date != nil {
+			occurrence.Date = *exception.Date
+		}
+	}
+	return occurrence
+}
+
+// exceptionsByDate indexes exceptions by the Unix time of their occurrence date
+func exceptionsByDate(exceptions []models.RecurringException) map[int64]*models.RecurringException {
+	byDate := make(map[int64]*models.RecurringException, len(exceptions))
+	for i := range exceptions {
+		byDate[dateOnly(exceptions[i].OccurrenceDate).Unix()] = &exceptions[i]
+	}
+	return byDate
+}
+
+// firstOccurrence returns the first occurrence of the schedule on or after from
+func firstOccurrence(rule *recurrence.Rule, start, from time.Time) *time.Time {
+	it := rule.Iterator(start)
+	for date, ok := it.Next(); ok; date, ok = it.Next() {
+		if !date.Before(from) {
+			return &date
+		}
+	}
+	return nil
+}
+
+// dateOnly strips the time of day from t in UTC. Occurrence dates are stored
+// as UTC midnights, so this also normalizes dates read back from the database.
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// TestGenerateDueAdvancesPastFailedOccurrence makes one occurrence fail with a
+// modify exception pointing at a category the user does not own. The failure
+// must be reported without stalling the schedule.
+func TestGenerateDueAdvancesPastFailedOccurrence(t *testing.T) {
+	store := newMemoryStore()
+	repos := store.repositories()
+	user, account, category := store.addUser("USD")
+	_, _, foreignCategory := store.addUser("USD")
+	transactions := NewTransactionService(&memoryUnitOfWork{store: store},
+		repos.Transactions, repos.Accounts, repos.Categories, repos.Users)
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	failing := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	recurring := &models.RecurringTransaction{
+		ID: uuid.New(), UserID: user.ID, AccountID: account.ID, CategoryID: category.ID,
+		Amount: decimal.NewFromInt(-15), Description: "Subscription", RRule: "FREQ=MONTHLY;BYMONTHDAY=1",
+		StartDate: start, NextOccurrence: &start, IsActive: true,
+		Exceptions: []models.RecurringException{{
+			OccurrenceDate: failing, Action: models.RecurringExceptionModify, CategoryID: &foreignCategory.ID,
+		}},
+	}
+	recurringRepo := newMemoryRecurringRepository(recurring)
+	service := NewRecurringService(recurringRepo, repos.Accounts, repos.Categories, transactions)
+
+	generated, err := service.GenerateDue(time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, repositories.ErrCategoryNotFound) {
+		t.Fatalf("got error %v, want %v", err, repositories.ErrCategoryNotFound)
+	}
+	if generated != 2 {
+		t.Errorf("generated = %d, want 2", generated)
+	}
+
+	want := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	if next := recurring.NextOccurrence; next == nil || !next.Equal(want) {
+		t.Errorf("next occurrence = %v, want %s", next, want)
+	}
+	if balance := store.account(account.ID).Balance; !balance.Equal(decimal.NewFromInt(-30)) {
+		t.Errorf("balance = %s, want -30", balance)
+	}
+
+	// A later run does not retry or repeat anything
+	generated, err = service.GenerateDue(time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil || generated != 0 {
+		t.Errorf("second run generated %d with error %v, want 0 and no error", generated, err)
+	}
+}
+
+// TestGenerateDueRetriesTransientFailure makes one occurrence fail with a
+// deadlock. The schedule must stop before that occurrence so that the next
+// run generates it, and every occurrence is generated exactly once.
+func TestGenerateDueRetriesTransientFailure(t *testing.T) {
+	store := newMemoryStore()
+	repos := store.repositories()
+	user, account, category := store.addUser("USD")
+	transactions := NewTransactionService(&memoryUnitOfWork{store: store},
+		repos.Transactions, repos.Accounts, repos.Categories, repos.Users)
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	failing := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	recurring := &models.RecurringTransaction{
+		ID: uuid.New(), UserID: user.ID, AccountID: account.ID, CategoryID: category.ID,
+		Amount: decimal.NewFromInt(-15), Description: "Subscription", RRule: "FREQ=MONTHLY;BYMONTHDAY=1",
+		StartDate: start, NextOccurrence: &start, IsActive: true,
+	}
+	service := NewRecurringService(newMemoryRecurringRepository(recurring), repos.Accounts, repos.Categories, transactions)
+	asOf := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	deadlock := &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+	store.failCreate = func(transaction *models.Transaction) error {
+		if transaction.OccurrenceDate != nil && transaction.OccurrenceDate.Equal(failing) {
+			return deadlock
+		}
+		return nil
+	}
+	generated, err := service.GenerateDue(asOf)
+	if !errors.Is(err, deadlock) {
+		t.Fatalf("got error %v, want %v", err, deadlock)
+	}
+	if generated != 1 {
+		t.Errorf("generated = %d, want 1", generated)
+	}
+	if next := recurring.NextOccurrence; next == nil || !next.Equal(failing) {
+		t.Errorf("next occurrence = %v, want %s", next, failing)
+	}
+
+	store.failCreate = nil
+	generated, err = service.GenerateDue(asOf)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if generated != 2 {
+		t.Errorf("retry generated = %d, want 2", generated)
+	}
+	want := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	if next := recurring.NextOccurrence; next == nil || !next.Equal(want) {
+		t.Errorf("next occurrence = %v, want %s", next, want)
+	}
+	if balance := store.account(account.ID).Balance; !balance.Equal(decimal.NewFromInt(-45)) {
+		t.Errorf("balance = %s, want -45", balance)
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+type tagService struct {
+	tagRepo         repositories.TagRepository
+	transactionRepo repositories.TransactionRepository
+}
+
+// NewTagService creates a new tag service
+func NewTagService(tagRepo repositories.TagRepository, transactionRepo repositories.TransactionRepository) TagService {
+	return &tagService{
+		tagRepo:         tagRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// CreateTag creates a new tag for the user. Tag names are unique per user,
+// ignoring case.
+func (s *tagService) CreateTag(userID uuid.UUID, name, color string) (*models.Tag, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	name = strings.TrimSpace(name)
+	if err := s.validateTagInput(name, color); err != nil {
+		return nil, err
+	}
+
+	// Set default color if not provided
+	if color == "" {
+		color = "#6c757d"
+	}
+
+	tag := &models.Tag{
+		UserID: userID,
+		Name:   name,
+		Color:  color,
+	}
+	if err := s.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// GetTagByID retrieves a tag owned by the user
+func (s *tagService) GetTagByID(userID, id uuid.UUID) (*models.Tag, error) {
+	return s.getOwnedTag(userID, id)
+}
+
+// GetUserTags retrieves all of the user's tags
+func (s *tagService) GetUserTags(userID uuid.UUID) ([]*models.Tag, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.tagRepo.GetByUserID(userID)
+}
+
+// UpdateTag renames or recolors a tag owned by the user. Empty values keep
+// the current ones.
+func (s *tagService) UpdateTag(userID, id uuid.UUID, name, color string) (*models.Tag, error) {
+	tag, err := s.getOwnedTag(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name != "" {
+		tag.Name = name
+	}
+	if color != "" {
+		tag.Color = color
+	}
+	if err := s.validateTagInput(tag.Name, tag.Color); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag owned by the user and removes it from every
+// transaction
+func (s *tagService) DeleteTag(userID, id uuid.UUID) error {
+	if _, err := s.getOwnedTag(userID, id); err != nil {
+		return err
+	}
+
+	return s.tagRepo.Delete(id)
+}
+
+// TagTransactions adds a tag to the user's transactions and returns how
+// many were not tagged with it yet
+func (s *tagService) TagTransactions(userID, id uuid.UUID, transactionIDs []uuid.UUID) (int64, error) {
+	ids, err := s.checkTransactions(userID, id, transactionIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.tagRepo.AddToTransactions(id, ids)
+}
+
+// UntagTransactions removes a tag from the user's transactions and returns
+// how many were tagged with it
+func (s *tagService) UntagTransactions(userID, id uuid.UUID, transactionIDs []uuid.UUID) (int64, error) {
+	ids, err := s.checkTransactions(userID, id, transactionIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.tagRepo.RemoveFromTransactions(id, ids)
+}
+
+// checkTransactions verifies that the tag and every transaction belong to
+// the user and returns the distinct transaction IDs
+func (s *tagService) checkTransactions(userID, id uuid.UUID, transactionIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if _, err := s.getOwnedTag(userID, id); err != nil {
+		return nil, err
+	}
+	if len(transactionIDs) == 0 {
+		return nil, errors.New("at least one transaction ID is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(transactionIDs))
+	ids := make([]uuid.UUID, 0, len(transactionIDs))
+	for _, transactionID := range transactionIDs {
+		if !seen[transactionID] {
+			seen[transactionID] = true
+			ids = append(ids, transactionID)
+		}
+	}
+
+	count, err := s.transactionRepo.CountOwned(userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(ids)) {
+		return nil, repositories.ErrTransactionNotFound
+	}
+	return ids, nil
+}
+
+// getOwnedTag retrieves a tag and reports tags of other users as not found
+func (s *tagService) getOwnedTag(userID, id uuid.UUID) (*models.Tag, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid tag ID")
+	}
+
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.UserID != userID {
+		return nil, repositories.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// validateTagInput validates tag input parameters
+func (s *tagService) validateTagInput(name, color string) error {
+	if name == "" {
+		return errors.New("tag name cannot be empty")
+	}
+	if len(name) > 50 {
+		return errors.New("tag name cannot be longer than 50 characters")
+	}
+	if strings.Contains(name, ",") {
+		return errors.New("tag name cannot contain commas")
+	}
+	if color != "" && !isValidColor(color) {
+		return errors.New("invalid color format (expected hex color like #6c757d)")
+	}
+	return nil
+}
@@ -2,16 +2,20 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/exporter"
 	"github.com/vasujain275/expense-tracker-api/internal/models"
 	"github.com/vasujain275/expense-tracker-api/internal/repositories"
 )
 
 type transactionService struct {
+	uow             repositories.UnitOfWork
 	transactionRepo repositories.TransactionRepository
 	accountRepo     repositories.AccountRepository
 	categoryRepo    repositories.CategoryRepository
@@ -20,12 +24,14 @@ type transactionService struct {
 
 // NewTransactionService creates a new transaction service
 func NewTransactionService(
+	uow repositories.UnitOfWork,
 	transactionRepo repositories.TransactionRepository,
 	accountRepo repositories.AccountRepository,
 	categoryRepo repositories.CategoryRepository,
 	userRepo repositories.UserRepository,
 ) TransactionService {
 	return &transactionService{
+		uow:             uow,
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
 		categoryRepo:    categoryRepo,
@@ -46,59 +52,78 @@ func (s *transactionService) CreateTransaction(req TransactionCreateRequest) (*m
 		return nil, err
 	}
 	if !exists {
-		return nil, errors.New("user not found")
+		return nil, repositories.ErrUserNotFound
 	}
 
 	// Verify account exists and belongs to user
 	account, err := s.accountRepo.GetByID(req.AccountID)
 	if err != nil {
-		return nil, errors.New("account not found")
+		return nil, repositories.ErrAccountNotFound
 	}
 	if account.UserID != req.UserID {
-		return nil, errors.New("account does not belong to user")
+		return nil, repositories.ErrAccountNotFound
 	}
 
-	// Verify category exists
-	exists, err = s.categoryRepo.Exists(req.CategoryID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.New("category not found")
+	// Amounts are kept to the minor units of the account's currency
+	amount := roundAmount(req.Amount, account.Currency)
+	if amount.IsZero() {
+		return nil, errors.New("transaction amount cannot be zero")
 	}
 
 	// Create transaction
 	transaction := &models.Transaction{
-		UserID:      req.UserID,
-		AccountID:   req.AccountID,
-		CategoryID:  req.CategoryID,
-		Amount:      req.Amount,
-		Description: strings.TrimSpace(req.Description),
-		Date:        req.Date,
+		UserID:         req.UserID,
+		AccountID:      req.AccountID,
+		Amount:         amount,
+		Description:    strings.TrimSpace(req.Description),
+		Date:           req.Date,
+		RecurringID:    req.RecurringID,
+		OccurrenceDate: req.OccurrenceDate,
 	}
 
-	if err := s.transactionRepo.Create(transaction); err != nil {
-		return nil, err
+	if req.OriginalAmount != nil || req.OriginalCurrency != nil {
+		if err := setOriginalAmount(transaction, req.OriginalAmount, req.OriginalCurrency, account.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.Splits) > 0 {
+		// Split transactions carry their categories on the allocations
+		splits, err := s.buildSplits(req.UserID, req.Splits, amount, account.Currency)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Splits = splits
+	} else {
+		// Verify category exists
+		exists, err = s.categoryRepo.Exists(req.UserID, req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, repositories.ErrCategoryNotFound
+		}
+		transaction.CategoryID = &req.CategoryID
 	}
 
-	// Update account balance
-	newBalance := account.Balance.Add(req.Amount)
-	if err := s.accountRepo.UpdateBalance(req.AccountID, newBalance); err != nil {
-		// TODO: Consider implementing transaction rollback here
-		return nil, errors.New("failed to update account balance")
+	// Insert the transaction and apply it to the account balance atomically
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Transactions.Create(transaction); err != nil {
+			return err
+		}
+		return applyToBalance(repos.Accounts, req.AccountID, amount)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get transaction with related data
 	return s.transactionRepo.GetByID(transaction.ID)
 }
 
-// GetTransactionByID retrieves a transaction by ID
-func (s *transactionService) GetTransactionByID(id uuid.UUID) (*models.Transaction, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid transaction ID")
-	}
-
-	return s.transactionRepo.GetByID(id)
+// GetTransactionByID retrieves a transaction by ID if it belongs to the user
+func (s *transactionService) GetTransactionByID(userID, id uuid.UUID) (*models.Transaction, error) {
+	return s.getOwnedTransaction(userID, id)
 }
 
 // GetTransactions retrieves transactions with filtering and pagination
@@ -114,7 +139,7 @@ func (s *transactionService) GetTransactions(req TransactionListRequest) ([]*mod
 		return nil, 0, err
 	}
 	if !exists {
-		return nil, 0, errors.New("user not found")
+		return nil, 0, repositories.ErrUserNotFound
 	}
 
 	// Create filter
@@ -126,6 +151,8 @@ func (s *transactionService) GetTransactions(req TransactionListRequest) ([]*mod
 		EndDate:    req.EndDate,
 		MinAmount:  req.MinAmount,
 		MaxAmount:  req.MaxAmount,
+		Tags:       normalizeTagNames(req.Tags),
+		TagMatch:   req.TagMatch,
 		Limit:      req.Limit,
 		Offset:     req.Offset,
 	}
@@ -144,52 +171,163 @@ func (s *transactionService) GetTransactions(req TransactionListRequest) ([]*mod
 	return transactions, count, nil
 }
 
-// UpdateTransaction updates a transaction and adjusts account balances
-func (s *transactionService) UpdateTransaction(id uuid.UUID, req TransactionUpdateRequest) (*models.Transaction, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid transaction ID")
+// ExportTransactions writes every transaction matching the filters to w in
+// the given format. Rows are streamed from the database, so memory use does
+// not grow with the size of the export. Limit and Offset are optional.
+func (s *transactionService) ExportTransactions(req TransactionListRequest, format exporter.Format, w io.Writer) error {
+	if err := s.validateTransactionListRequest(req); err != nil {
+		return err
+	}
+
+	exists, err := s.userRepo.Exists(req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repositories.ErrUserNotFound
 	}
 
+	writer, err := exporter.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	filter := repositories.TransactionFilter{
+		UserID:     req.UserID,
+		AccountID:  req.AccountID,
+		CategoryID: req.CategoryID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		MinAmount:  req.MinAmount,
+		MaxAmount:  req.MaxAmount,
+		Tags:       normalizeTagNames(req.Tags),
+		TagMatch:   req.TagMatch,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	err = s.transactionRepo.StreamByFilter(filter, func(row *repositories.TransactionExportRow) error {
+		return writer.Write(&exporter.Row{
+			ID:           row.ID,
+			Date:         row.Date,
+			AccountID:    row.AccountID,
+			AccountName:  row.AccountName,
+			CategoryID:   row.CategoryID,
+			CategoryName: row.CategoryName,
+			Description:  row.Description,
+			Amount:       row.Amount,
+			Currency:     row.Currency,
+			TransferID:   row.TransferID,
+			ExternalID:   row.ExternalID,
+		})
+	})
+	if err != nil {
+		writer.Abort()
+		return err
+	}
+
+	return writer.Close()
+}
+
+// UpdateTransaction updates a transaction owned by the user and adjusts account balances
+func (s *transactionService) UpdateTransaction(userID, id uuid.UUID, req TransactionUpdateRequest) (*models.Transaction, error) {
 	// Get existing transaction
-	transaction, err := s.transactionRepo.GetByID(id)
+	transaction, err := s.getOwnedTransaction(userID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Store old values for balance adjustment
-	oldAccountID := transaction.AccountID
-	oldAmount := transaction.Amount
+	// Transfer legs are edited as a pair to keep both sides consistent
+	if transaction.IsTransfer() {
+		return s.updateTransferLeg(transaction, req)
+	}
+
+	// Apply the update to the transaction as locked inside the unit of work,
+	// so concurrent updates and deletes of it run one after the other and the
+	// balances are adjusted by the amounts actually stored
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		transaction, err := lockOwnedTransaction(repos.Transactions, userID, id)
+		if err != nil {
+			return err
+		}
+		if transaction.IsTransfer() {
+			return repositories.ErrTransactionNotFound
+		}
+
+		// Store old values for balance adjustment
+		oldAccountID := transaction.AccountID
+		oldAmount := transaction.Amount
+
+		newSplits, err := s.applyTransactionUpdate(repos, transaction, req)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Transactions.Update(transaction); err != nil {
+			return err
+		}
+		if req.Splits != nil {
+			if err := repos.Transactions.ReplaceSplits(transaction.ID, newSplits); err != nil {
+				return err
+			}
+		}
+		if transaction.AccountID == oldAccountID && transaction.Amount.Equal(oldAmount) {
+			return nil
+		}
+		// Revert old transaction from old account, then apply it to the new one
+		if err := applyToBalance(repos.Accounts, oldAccountID, oldAmount.Neg()); err != nil {
+			return err
+		}
+		return applyToBalance(repos.Accounts, transaction.AccountID, transaction.Amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get updated transaction with related data
+	return s.transactionRepo.GetByID(id)
+}
+
+// applyTransactionUpdate validates the update and applies it to the
+// transaction, returning the split allocations that replace the current ones
+// when req.Splits is set
+func (s *transactionService) applyTransactionUpdate(repos repositories.Repositories, transaction *models.Transaction, req TransactionUpdateRequest) ([]models.TransactionSplit, error) {
+	accountCurrency := transaction.Account.Currency
 
 	// Validate and update fields
 	if req.AccountID != nil {
 		// Verify new account exists and belongs to user
-		account, err := s.accountRepo.GetByID(*req.AccountID)
+		account, err := repos.Accounts.GetByID(*req.AccountID)
 		if err != nil {
-			return nil, errors.New("account not found")
+			return nil, repositories.ErrAccountNotFound
 		}
 		if account.UserID != transaction.UserID {
-			return nil, errors.New("account does not belong to user")
+			return nil, repositories.ErrAccountNotFound
 		}
 		transaction.AccountID = *req.AccountID
+		accountCurrency = account.Currency
 	}
 
 	if req.CategoryID != nil {
 		// Verify category exists
-		exists, err := s.categoryRepo.Exists(*req.CategoryID)
+		exists, err := repos.Categories.Exists(transaction.UserID, *req.CategoryID)
 		if err != nil {
 			return nil, err
 		}
 		if !exists {
-			return nil, errors.New("category not found")
+			return nil, repositories.ErrCategoryNotFound
 		}
-		transaction.CategoryID = *req.CategoryID
+		transaction.CategoryID = req.CategoryID
 	}
 
 	if req.Amount != nil {
-		if req.Amount.IsZero() {
+		amount := roundAmount(*req.Amount, accountCurrency)
+		if amount.IsZero() {
 			return nil, errors.New("transaction amount cannot be zero")
 		}
-		transaction.Amount = *req.Amount
+		transaction.Amount = amount
+	} else if req.AccountID != nil && !roundAmount(transaction.Amount, accountCurrency).Equal(transaction.Amount) {
+		return nil, fmt.Errorf("amount %s has more decimal places than %s allows; set the amount when moving the transaction",
+			transaction.Amount, accountCurrency)
 	}
 
 	if req.Description != nil {
@@ -204,99 +342,314 @@ func (s *transactionService) UpdateTransaction(id uuid.UUID, req TransactionUpda
 		transaction.Date = *req.Date
 	}
 
-	// Update transaction
-	if err := s.transactionRepo.Update(transaction); err != nil {
-		return nil, err
+	switch {
+	case req.RemoveOriginal && (req.OriginalAmount != nil || req.OriginalCurrency != nil):
+		return nil, errors.New("original amount cannot be set when removing it")
+	case req.RemoveOriginal:
+		transaction.OriginalAmount = nil
+		transaction.OriginalCurrency = nil
+	case req.OriginalAmount != nil || req.OriginalCurrency != nil:
+		// A field not given keeps its current value
+		amount, currency := req.OriginalAmount, req.OriginalCurrency
+		if amount == nil {
+			amount = transaction.OriginalAmount
+		}
+		if currency == nil {
+			currency = transaction.OriginalCurrency
+		}
+		if err := setOriginalAmount(transaction, amount, currency, accountCurrency); err != nil {
+			return nil, err
+		}
+	case transaction.OriginalCurrency != nil && *transaction.OriginalCurrency == accountCurrency:
+		return nil, errors.New("original currency must differ from the account's currency")
 	}
 
-	// Update account balances if account or amount changed
-	if req.AccountID != nil || req.Amount != nil {
-		// Revert old transaction from old account
-		if oldAccountID != uuid.Nil {
-			oldAccount, err := s.accountRepo.GetByID(oldAccountID)
-			if err == nil {
-				revertedBalance := oldAccount.Balance.Sub(oldAmount)
-				s.accountRepo.UpdateBalance(oldAccountID, revertedBalance)
-			}
+	// Replace split allocations, or check existing ones still add up
+	switch {
+	case req.Splits != nil && len(*req.Splits) > 0:
+		if req.CategoryID != nil {
+			return nil, errors.New("category ID cannot be set on a split transaction")
+		}
+		newSplits, err := s.buildSplits(transaction.UserID, *req.Splits, transaction.Amount, accountCurrency)
+		if err != nil {
+			return nil, err
+		}
+		transaction.CategoryID = nil
+		return newSplits, nil
+	case req.Splits != nil:
+		if transaction.CategoryID == nil {
+			return nil, errors.New("category ID is required when removing splits")
+		}
+	case transaction.IsSplit():
+		if req.CategoryID != nil {
+			return nil, errors.New("category ID cannot be set on a split transaction")
+		}
+		if err := checkSplitTotal(transaction.Splits, transaction.Amount); err != nil {
+			return nil, err
 		}
+	}
+
+	return nil, nil
+}
 
-		// Apply new transaction to new account
-		newAccount, err := s.accountRepo.GetByID(transaction.AccountID)
-		if err == nil {
-			newBalance := newAccount.Balance.Add(transaction.Amount)
-			s.accountRepo.UpdateBalance(transaction.AccountID, newBalance)
+// DeleteTransaction deletes a transaction owned by the user and adjusts account balance
+func (s *transactionService) DeleteTransaction(userID, id uuid.UUID) error {
+	// Get transaction to adjust account balance
+	transaction, err := s.getOwnedTransaction(userID, id)
+	if err != nil {
+		return err
+	}
+
+	// Deleting either leg of a transfer removes the whole transfer
+	if transaction.IsTransfer() {
+		return s.uow.Do(func(repos repositories.Repositories) error {
+			return deleteTransferLegs(repos, *transaction.TransferID)
+		})
+	}
+
+	// Delete the transaction and reverse the amount stored on the locked row
+	// from the account balance atomically
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		transaction, err := lockOwnedTransaction(repos.Transactions, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Transactions.Delete(id); err != nil {
+			return err
 		}
+		return applyToBalance(repos.Accounts, transaction.AccountID, transaction.Amount.Neg())
+	})
+}
+
+// GetTransactionSummary gets spending summary by category in the user's
+// currency
+func (s *transactionService) GetTransactionSummary(userID uuid.UUID, startDate, endDate *time.Time) (*CategorySummaryReport, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get updated transaction with related data
-	return s.transactionRepo.GetByID(transaction.ID)
+	conversions, err := reportConversions(s.transactionRepo, userID, user.Currency, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.transactionRepo.GetSummaryByCategory(userID, user.Currency, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CategorySummaryReport{
+		Currency:    user.Currency,
+		Categories:  categories,
+		Conversions: conversions,
+	}, nil
 }
 
-// DeleteTransaction deletes a transaction and adjusts account balance
-func (s *transactionService) DeleteTransaction(id uuid.UUID) error {
-	if id == uuid.Nil {
-		return errors.New("invalid transaction ID")
+// GetTagSummary gets income and spending totals by tag in the user's currency
+func (s *transactionService) GetTagSummary(userID uuid.UUID, startDate, endDate *time.Time) (*TagSummaryReport, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get transaction to adjust account balance
-	transaction, err := s.transactionRepo.GetByID(id)
+	conversions, err := reportConversions(s.transactionRepo, userID, user.Currency, startDate, endDate)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	tags, err := s.transactionRepo.GetSummaryByTag(userID, user.Currency, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete transaction
-	if err := s.transactionRepo.Delete(id); err != nil {
-		return err
+	return &TagSummaryReport{
+		Currency:    user.Currency,
+		Tags:        tags,
+		Conversions: conversions,
+	}, nil
+}
+
+// GetMonthlyTotal gets total transactions for a specific month in the user's
+// currency
+func (s *transactionService) GetMonthlyTotal(userID uuid.UUID, year int, month int) (*MonthlyTotalReport, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Adjust account balance (reverse the transaction)
-	account, err := s.accountRepo.GetByID(transaction.AccountID)
-	if err == nil {
-		newBalance := account.Balance.Sub(transaction.Amount)
-		s.accountRepo.UpdateBalance(transaction.AccountID, newBalance)
+	loc := time.UTC
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	conversions, err := reportConversions(s.transactionRepo, userID, user.Currency, &startDate, &endDate)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.transactionRepo.GetTotalByDateRange(userID, user.Currency, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &MonthlyTotalReport{
+		Currency:    user.Currency,
+		Total:       total,
+		Conversions: conversions,
+	}, nil
 }
 
-// GetTransactionSummary gets spending summary by category
-func (s *transactionService) GetTransactionSummary(userID uuid.UUID, startDate, endDate *time.Time) ([]*repositories.TransactionSummary, error) {
+// getUser retrieves the user a report is for
+func (s *transactionService) getUser(userID uuid.UUID) (*models.User, error) {
 	if userID == uuid.Nil {
 		return nil, errors.New("invalid user ID")
 	}
+	return s.userRepo.GetByID(userID)
+}
 
-	// Verify user exists
-	exists, err := s.userRepo.Exists(userID)
+// updateTransferLeg applies an update to one leg of a transfer and mirrors it on
+// the other leg, so both legs keep the same date, description and magnitude
+func (s *transactionService) updateTransferLeg(transaction *models.Transaction, req TransactionUpdateRequest) (*models.Transaction, error) {
+	if req.AccountID != nil || req.CategoryID != nil || req.Splits != nil {
+		return nil, errors.New("transfer legs cannot change account or category; delete and recreate the transfer instead")
+	}
+	if req.OriginalAmount != nil || req.OriginalCurrency != nil || req.RemoveOriginal {
+		return nil, errors.New("transfer legs have no original amount")
+	}
+
+	update := transferUpdate{Date: req.Date}
+	if req.Amount != nil {
+		if req.Amount.IsZero() {
+			return nil, errors.New("transaction amount cannot be zero")
+		}
+		amount := req.Amount.Abs()
+		update.Amount = &amount
+	}
+	if req.Description != nil {
+		desc := strings.TrimSpace(*req.Description)
+		if desc == "" {
+			return nil, errors.New("transaction description cannot be empty")
+		}
+		update.Description = &desc
+	}
+
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		return updateTransferLegs(repos, *transaction.TransferID, update)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, errors.New("user not found")
+
+	return s.transactionRepo.GetByID(transaction.ID)
+}
+
+// setOriginalAmount validates the amount and currency a foreign purchase was
+// made in and records them on the transaction
+func setOriginalAmount(transaction *models.Transaction, amount *decimal.Decimal, currency *string, accountCurrency string) error {
+	if amount == nil || currency == nil {
+		return errors.New("original amount and original currency must be set together")
+	}
+	code, err := normalizeCurrency(*currency)
+	if err != nil {
+		return err
+	}
+	if code == accountCurrency {
+		return errors.New("original currency must differ from the account's currency")
+	}
+	rounded := roundAmount(*amount, code)
+	if rounded.IsZero() {
+		return errors.New("original amount cannot be zero")
+	}
+	transaction.OriginalAmount = &rounded
+	transaction.OriginalCurrency = &code
+	return nil
+}
+
+// buildSplits validates split allocations and converts them to models. Every
+// allocation needs an existing category and the amounts, rounded to the
+// minor units of currency, must sum to total.
+func (s *transactionService) buildSplits(userID uuid.UUID, reqs []TransactionSplitRequest, total decimal.Decimal, currency string) ([]models.TransactionSplit, error) {
+	splits := make([]models.TransactionSplit, 0, len(reqs))
+	for _, req := range reqs {
+		if req.CategoryID == uuid.Nil {
+			return nil, errors.New("split category ID is required")
+		}
+		amount := roundAmount(req.Amount, currency)
+		if amount.IsZero() {
+			return nil, errors.New("split amount cannot be zero")
+		}
+		exists, err := s.categoryRepo.Exists(userID, req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, repositories.ErrCategoryNotFound
+		}
+		splits = append(splits, models.TransactionSplit{
+			CategoryID: req.CategoryID,
+			Amount:     amount,
+			Memo:       strings.TrimSpace(req.Memo),
+		})
+	}
+
+	if err := checkSplitTotal(splits, total); err != nil {
+		return nil, err
+	}
+
+	return splits, nil
+}
+
+// checkSplitTotal ensures split allocations add up to the transaction amount
+func checkSplitTotal(splits []models.TransactionSplit, total decimal.Decimal) error {
+	sum := decimal.Zero
+	for _, split := range splits {
+		sum = sum.Add(split.Amount)
 	}
+	if !sum.Equal(total) {
+		return errors.New("split amounts must sum to the transaction amount")
+	}
+	return nil
+}
 
-	return s.transactionRepo.GetSummaryByCategory(userID, startDate, endDate)
+// applyToBalance adds amount to the balance of the given account as an atomic
+// increment rather than a read-modify-write, so concurrent updates are not lost
+func applyToBalance(accounts repositories.AccountRepository, accountID uuid.UUID, amount decimal.Decimal) error {
+	if err := accounts.AdjustBalance(accountID, amount); err != nil {
+		return fmt.Errorf("failed to update account balance: %w", err)
+	}
+	return nil
 }
 
-// GetMonthlyTotal gets total transactions for a specific month
-func (s *transactionService) GetMonthlyTotal(userID uuid.UUID, year int, month int) (decimal.Decimal, error) {
+// getOwnedTransaction loads a transaction and reports it as not found when it
+// belongs to another user, so callers cannot probe for other users' transactions
+func (s *transactionService) getOwnedTransaction(userID, id uuid.UUID) (*models.Transaction, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid transaction ID")
+	}
 	if userID == uuid.Nil {
-		return decimal.Zero, errors.New("invalid user ID")
+		return nil, errors.New("invalid user ID")
 	}
 
-	// Verify user exists
-	exists, err := s.userRepo.Exists(userID)
+	transaction, err := s.transactionRepo.GetByID(id)
 	if err != nil {
-		return decimal.Zero, err
+		return nil, err
 	}
-	if !exists {
-		return decimal.Zero, errors.New("user not found")
+	if transaction.UserID != userID {
+		return nil, repositories.ErrTransactionNotFound
 	}
 
-	loc := time.UTC
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
-	endDate := startDate.AddDate(0, 1, -1)
+	return transaction, nil
+}
 
-	return s.transactionRepo.GetTotalByDateRange(userID, startDate, endDate)
+// lockOwnedTransaction loads and locks a transaction inside a unit of work,
+// reporting it as not found when it was deleted meanwhile or belongs to
+// another user
+func lockOwnedTransaction(transactions repositories.TransactionRepository, userID, id uuid.UUID) (*models.Transaction, error) {
+	transaction, err := transactions.GetForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.UserID != userID {
+		return nil, repositories.ErrTransactionNotFound
+	}
+	return transaction, nil
 }
 
 // validateTransactionCreateRequest validates the transaction creation request
@@ -307,9 +660,12 @@ func (s *transactionService) validateTransactionCreateRequest(req TransactionCre
 	if req.AccountID == uuid.Nil {
 		return errors.New("account ID is required")
 	}
-	if req.CategoryID == uuid.Nil {
+	if len(req.Splits) == 0 && req.CategoryID == uuid.Nil {
 		return errors.New("category ID is required")
 	}
+	if len(req.Splits) > 0 && req.CategoryID != uuid.Nil {
+		return errors.New("category ID cannot be set on a split transaction")
+	}
 	if req.Amount.IsZero() {
 		return errors.New("transaction amount cannot be zero")
 	}
@@ -324,6 +680,22 @@ func (s *transactionService) validateTransactionCreateRequest(req TransactionCre
 
 // validateTransactionListRequest validates the transaction list request
 func (s *transactionService) validateTransactionListRequest(req TransactionListRequest) error {
-	// Add validation logic if needed
+	switch req.TagMatch {
+	case "", repositories.TagMatchAny, repositories.TagMatchAll:
+	default:
+		return errors.New("invalid tag match mode (expected any or all)")
+	}
 	return nil
 }
+
+// normalizeTagNames trims tag names and drops empty ones. Tag names are
+// matched case-insensitively.
+func normalizeTagNames(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
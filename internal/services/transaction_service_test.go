@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestCreateTransactionRoundsToCurrency books amounts in accounts whose
+// currencies have no minor units (JPY) and three (KWD)
+func TestCreateTransactionRoundsToCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		want     string
+	}{
+		{"JPY", "-1234.5", "-1235"},
+		{"JPY", "980.4", "980"},
+		{"KWD", "-12.3455", "-12.346"},
+		{"KWD", "7.0001", "7"},
+		{"USD", "19.995", "20"},
+		{"JPY", "0.4", ""},
+		{"KWD", "-0.0004", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency+" "+tt.amount, func(t *testing.T) {
+			store := newMemoryStore()
+			repos := store.repositories()
+			user, account, category := store.addUser(tt.currency)
+			transactions := NewTransactionService(&memoryUnitOfWork{store: store},
+				repos.Transactions, repos.Accounts, repos.Categories, repos.Users)
+
+			transaction, err := transactions.CreateTransaction(TransactionCreateRequest{
+				UserID: user.ID, AccountID: account.ID, CategoryID: category.ID,
+				Amount: decimal.RequireFromString(tt.amount), Description: "Rounded", Date: time.Now(),
+			})
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("created %s %s, want an error for an amount that rounds to zero", transaction.Amount, tt.currency)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create transaction: %v", err)
+			}
+
+			want := decimal.RequireFromString(tt.want)
+			if !transaction.Amount.Equal(want) {
+				t.Errorf("amount = %s, want %s", transaction.Amount, want)
+			}
+			if balance := store.account(account.ID).Balance; !balance.Equal(want) {
+				t.Errorf("balance = %s, want %s", balance, want)
+			}
+		})
+	}
+}
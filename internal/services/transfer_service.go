@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// ErrTransferNotFound is returned when a transfer does not exist or belongs to another user
+var ErrTransferNotFound = errors.New("transfer not found")
+
+type transferService struct {
+	uow             repositories.UnitOfWork
+	transactionRepo repositories.TransactionRepository
+	accountRepo     repositories.AccountRepository
+}
+
+// NewTransferService creates a new transfer service
+func NewTransferService(
+	uow repositories.UnitOfWork,
+	transactionRepo repositories.TransactionRepository,
+	accountRepo repositories.AccountRepository,
+) TransferService {
+	return &transferService{
+		uow:             uow,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// CreateTransfer moves money between two of the user's accounts by creating a
+// linked debit/credit pair and adjusting both balances in one transaction.
+// Between accounts in different currencies the credit is ToAmount.
+func (s *transferService) CreateTransfer(req TransferCreateRequest) (*Transfer, error) {
+	if err := s.validateTransferCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Verify both accounts exist and belong to the user
+	accounts := make([]*models.Account, 0, 2)
+	for _, accountID := range []uuid.UUID{req.FromAccountID, req.ToAccountID} {
+		account, err := s.accountRepo.GetByID(accountID)
+		if err != nil || account.UserID != req.UserID {
+			return nil, repositories.ErrAccountNotFound
+		}
+		accounts = append(accounts, account)
+	}
+
+	// Each leg is rounded to the minor units of its account's currency
+	amount := roundAmount(req.Amount, accounts[0].Currency)
+	if !amount.IsPositive() {
+		return nil, errors.New("transfer amount must be positive")
+	}
+	toAmount := amount
+	switch {
+	case accounts[0].Currency != accounts[1].Currency && req.ToAmount == nil:
+		return nil, errors.New("to amount is required for a transfer between currencies")
+	case accounts[0].Currency != accounts[1].Currency:
+		toAmount = roundAmount(*req.ToAmount, accounts[1].Currency)
+		if !toAmount.IsPositive() {
+			return nil, errors.New("to amount must be positive")
+		}
+	case req.ToAmount != nil && !roundAmount(*req.ToAmount, accounts[1].Currency).Equal(amount):
+		return nil, errors.New("to amount must equal the amount for a transfer within one currency")
+	}
+
+	transferID := uuid.New()
+	description := strings.TrimSpace(req.Description)
+
+	debit := &models.Transaction{
+		UserID:      req.UserID,
+		AccountID:   req.FromAccountID,
+		TransferID:  &transferID,
+		Amount:      amount.Neg(),
+		Description: description,
+		Date:        req.Date,
+	}
+	credit := &models.Transaction{
+		UserID:      req.UserID,
+		AccountID:   req.ToAccountID,
+		TransferID:  &transferID,
+		Amount:      toAmount,
+		Description: description,
+		Date:        req.Date,
+	}
+
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		for _, leg := range []*models.Transaction{debit, credit} {
+			if err := repos.Transactions.Create(leg); err != nil {
+				return err
+			}
+			if err := applyToBalance(repos.Accounts, leg.AccountID, leg.Amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTransfer(req.UserID, transferID)
+}
+
+// GetTransfer retrieves both legs of a transfer owned by the user
+func (s *transferService) GetTransfer(userID, id uuid.UUID) (*Transfer, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid transfer ID")
+	}
+
+	legs, err := s.transactionRepo.GetByTransferID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransfer(userID, id, legs)
+}
+
+// DeleteTransfer deletes both legs of a transfer and reverses their balance changes
+func (s *transferService) DeleteTransfer(userID, id uuid.UUID) error {
+	if _, err := s.GetTransfer(userID, id); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		return deleteTransferLegs(repos, id)
+	})
+}
+
+// validateTransferCreateRequest validates the transfer creation request
+func (s *transferService) validateTransferCreateRequest(req TransferCreateRequest) error {
+	if req.UserID == uuid.Nil {
+		return errors.New("user ID is required")
+	}
+	if req.FromAccountID == uuid.Nil || req.ToAccountID == uuid.Nil {
+		return errors.New("source and destination accounts are required")
+	}
+	if req.FromAccountID == req.ToAccountID {
+		return errors.New("cannot transfer to the same account")
+	}
+	if !req.Amount.IsPositive() {
+		return errors.New("transfer amount must be positive")
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		return errors.New("transfer description cannot be empty")
+	}
+	if req.Date.IsZero() {
+		return errors.New("transfer date is required")
+	}
+	return nil
+}
+
+// newTransfer assembles a Transfer from its legs, treating transfers owned by
+// other users as missing
+func newTransfer(userID, id uuid.UUID, legs []*models.Transaction) (*Transfer, error) {
+	transfer := &Transfer{ID: id}
+	for _, leg := range legs {
+		if leg.UserID != userID {
+			return nil, ErrTransferNotFound
+		}
+		if leg.IsExpense() {
+			transfer.From = leg
+		} else {
+			transfer.To = leg
+		}
+	}
+	if transfer.From == nil || transfer.To == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	transfer.Amount = transfer.From.Amount.Neg()
+	transfer.ToAmount = transfer.To.Amount
+	transfer.Description = transfer.To.Description
+	transfer.Date = transfer.To.Date
+	return transfer, nil
+}
+
+// transferUpdate holds the fields shared by both legs of a transfer
+type transferUpdate struct {
+	Amount      *decimal.Decimal
+	Description *string
+	Date        *time.Time
+}
+
+// updateTransferLegs applies the update to both legs of a transfer, keeping the
+// debit leg negative and the credit leg positive, and adjusts both balances.
+// The legs are locked first, so concurrent edits of a transfer run one after
+// the other.
+func updateTransferLegs(repos repositories.Repositories, transferID uuid.UUID, update transferUpdate) error {
+	legs, err := repos.Transactions.GetByTransferIDForUpdate(transferID)
+	if err != nil {
+		return err
+	}
+	if len(legs) == 0 {
+		return ErrTransferNotFound
+	}
+	// The legs of a transfer between currencies hold different amounts
+	if update.Amount != nil && len(legs) == 2 && legs[0].Account.Currency != legs[1].Account.Currency {
+		return errors.New("the amount of a transfer between currencies cannot be changed; delete and recreate the transfer instead")
+	}
+
+	for _, leg := range legs {
+		oldAmount := leg.Amount
+
+		if update.Amount != nil {
+			amount := roundAmount(*update.Amount, leg.Account.Currency)
+			if amount.IsZero() {
+				return errors.New("transfer amount must be positive")
+			}
+			if leg.IsExpense() {
+				leg.Amount = amount.Neg()
+			} else {
+				leg.Amount = amount
+			}
+		}
+		if update.Description != nil {
+			leg.Description = *update.Description
+		}
+		if update.Date != nil {
+			leg.Date = *update.Date
+		}
+
+		if err := repos.Transactions.Update(leg); err != nil {
+			return err
+		}
+		if delta := leg.Amount.Sub(oldAmount); !delta.IsZero() {
+			if err := applyToBalance(repos.Accounts, leg.AccountID, delta); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteTransferLegs deletes every leg of a transfer and reverses its balance
+// changes, reporting a transfer deleted meanwhile as not found
+func deleteTransferLegs(repos repositories.Repositories, transferID uuid.UUID) error {
+	legs, err := repos.Transactions.GetByTransferIDForUpdate(transferID)
+	if err != nil {
+		return err
+	}
+	if len(legs) == 0 {
+		return ErrTransferNotFound
+	}
+
+	for _, leg := range legs {
+		if err := repos.Transactions.Delete(leg.ID); err != nil {
+			return err
+		}
+		if err := applyToBalance(repos.Accounts, leg.AccountID, leg.Amount.Neg()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
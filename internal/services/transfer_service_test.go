@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateTransferBetweenCurrencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to     string
+		amount       string
+		toAmount     string
+		wantAmount   string
+		wantToAmount string
+	}{
+		{"USD to EUR", "USD", "EUR", "100", "91.875", "100", "91.88"},
+		{"EUR to JPY", "EUR", "JPY", "10.005", "1633.5", "10.01", "1634"},
+		{"USD to KWD", "USD", "KWD", "3.256", "1.0005", "3.26", "1.001"},
+		{"JPY to USD", "JPY", "USD", "1500.4", "9.994", "1500", "9.99"},
+		{"same currency without to amount", "EUR", "EUR", "12.345", "", "12.35", "12.35"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			repos := store.repositories()
+			user, from, _ := store.addUser(tt.from)
+			to := store.addAccount(user.ID, tt.to)
+			transfers := NewTransferService(&memoryUnitOfWork{store: store}, repos.Transactions, repos.Accounts)
+
+			req := TransferCreateRequest{
+				UserID: user.ID, FromAccountID: from.ID, ToAccountID: to.ID,
+				Amount: decimal.RequireFromString(tt.amount), Description: "Move", Date: time.Now(),
+			}
+			if tt.toAmount != "" {
+				toAmount := decimal.RequireFromString(tt.toAmount)
+				req.ToAmount = &toAmount
+			}
+
+			transfer, err := transfers.CreateTransfer(req)
+			if err != nil {
+				t.Fatalf("create transfer: %v", err)
+			}
+
+			wantAmount := decimal.RequireFromString(tt.wantAmount)
+			wantToAmount := decimal.RequireFromString(tt.wantToAmount)
+			if !transfer.Amount.Equal(wantAmount) || !transfer.ToAmount.Equal(wantToAmount) {
+				t.Errorf("transfer amounts = %s -> %s, want %s -> %s",
+					transfer.Amount, transfer.ToAmount, wantAmount, wantToAmount)
+			}
+			if !transfer.From.Amount.Equal(wantAmount.Neg()) || transfer.From.AccountID != from.ID {
+				t.Errorf("debit leg = %s on %s, want -%s on %s", transfer.From.Amount, transfer.From.AccountID, wantAmount, from.ID)
+			}
+			if !transfer.To.Amount.Equal(wantToAmount) || transfer.To.AccountID != to.ID {
+				t.Errorf("credit leg = %s on %s, want %s on %s", transfer.To.Amount, transfer.To.AccountID, wantToAmount, to.ID)
+			}
+			if balance := store.account(from.ID).Balance; !balance.Equal(wantAmount.Neg()) {
+				t.Errorf("source balance = %s, want -%s", balance, wantAmount)
+			}
+			if balance := store.account(to.ID).Balance; !balance.Equal(wantToAmount) {
+				t.Errorf("destination balance = %s, want %s", balance, wantToAmount)
+			}
+
+			// Deleting the transfer reverses each leg in its own currency
+			if err := transfers.DeleteTransfer(user.ID, transfer.ID); err != nil {
+				t.Fatalf("delete transfer: %v", err)
+			}
+			for _, id := range []uuid.UUID{from.ID, to.ID} {
+				account := store.account(id)
+				if !account.Balance.IsZero() {
+					t.Errorf("%s balance after delete = %s, want 0", account.Currency, account.Balance)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateTransferRejectsInvalidToAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		toAmount string
+	}{
+		{"missing between currencies", "USD", "EUR", ""},
+		{"rounds to zero", "USD", "JPY", "0.4"},
+		{"negative", "USD", "EUR", "-5"},
+		{"differs within one currency", "EUR", "EUR", "11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			repos := store.repositories()
+			user, from, _ := store.addUser(tt.from)
+			to := store.addAccount(user.ID, tt.to)
+			transfers := NewTransferService(&memoryUnitOfWork{store: store}, repos.Transactions, repos.Accounts)
+
+			req := TransferCreateRequest{
+				UserID: user.ID, FromAccountID: from.ID, ToAccountID: to.ID,
+				Amount: decimal.NewFromInt(10), Description: "Move", Date: time.Now(),
+			}
+			if tt.toAmount != "" {
+				toAmount := decimal.RequireFromString(tt.toAmount)
+				req.ToAmount = &toAmount
+			}
+
+			if _, err := transfers.CreateTransfer(req); err == nil {
+				t.Fatal("expected an error")
+			}
+			if len(store.transactions) != 0 {
+				t.Errorf("a rejected transfer created %d transactions", len(store.transactions))
+			}
+		})
+	}
+}
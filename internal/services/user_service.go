@@ -10,50 +10,63 @@ import (
 )
 
 type userService struct {
+	uow      repositories.UnitOfWork
 	userRepo repositories.UserRepository
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(uow repositories.UnitOfWork, userRepo repositories.UserRepository) UserService {
 	return &userService{
+		uow:      uow,
 		userRepo: userRepo,
 	}
 }
 
-// CreateUser creates a new user with validation
-func (s *userService) CreateUser(email, name, currency string) (*models.User, error) {
+// CreateUser creates a new user with a hashed password and the default
+// category set
+func (s *userService) CreateUser(email, name, currency, password string) (*models.User, error) {
 	// Validate inputs
 	if err := s.validateUserInput(email, name, currency); err != nil {
 		return nil, err
 	}
 
+	email = strings.ToLower(strings.TrimSpace(email))
+
 	// Check if user already exists
 	existingUser, _ := s.userRepo.GetByEmail(email)
 	if existingUser != nil {
 		return nil, errors.New("user with this email already exists")
 	}
 
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create user
 	user := &models.User{
-		Email:    strings.ToLower(strings.TrimSpace(email)),
-		Name:     strings.TrimSpace(name),
-		Currency: strings.ToUpper(strings.TrimSpace(currency)),
+		Email:        email,
+		Name:         strings.TrimSpace(name),
+		Currency:     strings.ToUpper(strings.TrimSpace(currency)),
+		PasswordHash: passwordHash,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Users.Create(user); err != nil {
+			return err
+		}
+		return repos.Categories.CreateBatch(newDefaultCategories(user.ID))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-// GetUserByID retrieves a user by ID
-func (s *userService) GetUserByID(id uuid.UUID) (*models.User, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid user ID")
-	}
-
-	return s.userRepo.GetByID(id)
+// GetUserByID retrieves a user by ID if it is the acting user
+func (s *userService) GetUserByID(actingUserID, id uuid.UUID) (*models.User, error) {
+	return s.getOwnedUser(actingUserID, id)
 }
 
 // GetUserByEmail retrieves a user by email
@@ -65,14 +78,10 @@ func (s *userService) GetUserByEmail(email string) (*models.User, error) {
 	return s.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
 }
 
-// UpdateUser updates user information
-func (s *userService) UpdateUser(id uuid.UUID, name, currency string) (*models.User, error) {
-	if id == uuid.Nil {
-		return nil, errors.New("invalid user ID")
-	}
-
+// UpdateUser updates user information of the acting user
+func (s *userService) UpdateUser(actingUserID, id uuid.UUID, name, currency string) (*models.User, error) {
 	// Get existing user
-	user, err := s.userRepo.GetByID(id)
+	user, err := s.getOwnedUser(actingUserID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -86,10 +95,11 @@ func (s *userService) UpdateUser(id uuid.UUID, name, currency string) (*models.U
 	}
 
 	if currency != "" {
-		if len(strings.TrimSpace(currency)) != 3 {
-			return nil, errors.New("currency must be a 3-letter code (e.g., USD, EUR)")
+		code, err := normalizeCurrency(currency)
+		if err != nil {
+			return nil, err
 		}
-		user.Currency = strings.ToUpper(strings.TrimSpace(currency))
+		user.Currency = code
 	}
 
 	// Update user
@@ -100,22 +110,35 @@ func (s *userService) UpdateUser(id uuid.UUID, name, currency string) (*models.U
 	return user, nil
 }
 
-// DeleteUser deletes a user
-func (s *userService) DeleteUser(id uuid.UUID) error {
-	if id == uuid.Nil {
-		return errors.New("invalid user ID")
-	}
-
+// DeleteUser deletes the acting user together with everything they own
+func (s *userService) DeleteUser(actingUserID, id uuid.UUID) error {
 	// Check if user exists
-	exists, err := s.userRepo.Exists(id)
-	if err != nil {
+	if _, err := s.getOwnedUser(actingUserID, id); err != nil {
 		return err
 	}
-	if !exists {
-		return errors.New("user not found")
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Backups.DeleteUserData(id); err != nil {
+			return err
+		}
+		return repos.Users.Delete(id)
+	})
+}
+
+// getOwnedUser loads a user and reports it as not found when it is not the
+// acting user, so callers cannot probe for other users
+func (s *userService) getOwnedUser(actingUserID, id uuid.UUID) (*models.User, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if actingUserID == uuid.Nil {
+		return nil, errors.New("invalid acting user ID")
+	}
+	if id != actingUserID {
+		return nil, repositories.ErrUserNotFound
 	}
 
-	return s.userRepo.Delete(id)
+	return s.userRepo.GetByID(id)
 }
 
 // validateUserInput validates user input fields
@@ -143,8 +166,8 @@ func (s *userService) validateUserInput(email, name, currency string) error {
 	if currency == "" {
 		return errors.New("currency is required")
 	}
-	if len(currency) != 3 {
-		return errors.New("currency must be a 3-letter code (e.g., USD, EUR)")
+	if _, err := normalizeCurrency(currency); err != nil {
+		return err
 	}
 
 	return nil
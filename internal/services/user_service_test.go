@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/vasujain275/expense-tracker-api/internal/models"
+	"github.com/vasujain275/expense-tracker-api/internal/repositories"
+)
+
+// TestDeleteUserRemovesOwnedRecords registers a user, gives them a record of
+// each kind that references them or their categories, and deletes them. The
+// user and every record they owned must be gone.
+func TestDeleteUserRemovesOwnedRecords(t *testing.T) {
+	db := openTestDB(t)
+	uow := repositories.NewUnitOfWork(db)
+	repos := repositories.NewRepositories(db)
+	users := NewUserService(uow, repos.Users)
+
+	fresh, err := users.CreateUser(uuid.NewString()+"@example.com", "Fresh", "USD", "correct-horse-battery")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := users.DeleteUser(fresh.ID, fresh.ID); err != nil {
+		t.Fatalf("delete user without records: %v", err)
+	}
+
+	user, err := users.CreateUser(uuid.NewString()+"@example.com", "Owner", "USD", "correct-horse-battery")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	categories := NewCategoryService(uow, repos.Categories)
+	parent, err := categories.CreateCategory(user.ID, "Household", models.CategoryTypeExpense, "", nil)
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	child, err := categories.CreateCategory(user.ID, "Cleaning", models.CategoryTypeExpense, "", &parent.ID)
+	if err != nil {
+		t.Fatalf("create subcategory: %v", err)
+	}
+	account, err := NewAccountService(repos.Accounts, repos.Users).CreateAccount(user.ID, "Checking",
+		models.AccountTypeBank, "USD", decimal.NewFromInt(100), CreditCardSettings{})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	transaction, err := NewTransactionService(uow, repos.Transactions, repos.Accounts, repos.Categories, repos.Users).
+		CreateTransaction(TransactionCreateRequest{
+			UserID: user.ID, AccountID: account.ID, CategoryID: child.ID,
+			Amount: decimal.NewFromInt(-20), Description: "Soap", Date: time.Now(),
+		})
+	if err != nil {
+		t.Fatalf("create transaction: %v", err)
+	}
+	tags := NewTagService(repositories.NewTagRepository(db), repos.Transactions)
+	tag, err := tags.CreateTag(user.ID, "groceries", "")
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	if _, err := tags.TagTransactions(user.ID, tag.ID, []uuid.UUID{transaction.ID}); err != nil {
+		t.Fatalf("tag transaction: %v", err)
+	}
+	_, err = NewBudgetService(repositories.NewBudgetRepository(db), repos.Categories, repos.Transactions, repos.Users).
+		CreateBudget(BudgetCreateRequest{
+			UserID: user.ID, Name: "Household", CategoryIDs: []uuid.UUID{parent.ID, child.ID},
+			Amount: decimal.NewFromInt(200), Period: models.BudgetPeriodMonthly,
+		})
+	if err != nil {
+		t.Fatalf("create budget: %v", err)
+	}
+
+	if err := users.DeleteUser(user.ID, user.ID); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if _, err := users.GetUserByID(user.ID, user.ID); !errors.Is(err, repositories.ErrUserNotFound) {
+		t.Fatalf("get deleted user: got %v, want ErrUserNotFound", err)
+	}
+	for _, table := range []string{"categories", "tags", "accounts", "transactions", "budgets"} {
+		var count int64
+		if err := db.Table(table).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("%s: %d records left, want 0", table, count)
+		}
+	}
+}